@@ -0,0 +1,46 @@
+package database
+
+import (
+	"database/sql"
+	"time"
+)
+
+// AsTime converts a timestamp-like value, as returned by the drivers
+// for date and time columns, to time.Time.
+//
+// The supported values are time.Time, *time.Time, sql.NullTime, and
+// string or []byte in the text formats of the drivers, i.e.
+// "2006-01-02 15:04:05", "2006-01-02T15:04:05Z07:00" or "2006-01-02".
+// Text without a time zone is parsed as UTC.
+//
+// Example usage:
+//
+//	row, err := SelectToMapAny(ctx, "SELECT created_at FROM users WHERE id = ?", 1)
+//	createdAt, ok := AsTime(row[0]["created_at"])
+//
+// Parameters:
+// - v (any): The value to convert.
+//
+// Returns:
+// - time.Time: The time, or the zero time if the value is not timestamp-like.
+// - bool: True if the value was converted.
+func AsTime(v any) (time.Time, bool) {
+	switch value := v.(type) {
+	case time.Time:
+		return value, true
+	case *time.Time:
+		if value != nil {
+			return *value, true
+		}
+	case sql.NullTime:
+		if value.Valid {
+			return value.Time, true
+		}
+	case string:
+		return parseTimeText(value)
+	case []byte:
+		return parseTimeText(string(value))
+	}
+
+	return time.Time{}, false
+}
@@ -0,0 +1,54 @@
+package database_test
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	database "github.com/dracory/database"
+)
+
+func TestAsTime(t *testing.T) {
+	expected := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		value any
+		ok    bool
+	}{
+		{"time", expected, true},
+		{"pointer", &expected, true},
+		{"null time", sql.NullTime{Time: expected, Valid: true}, true},
+		{"space separated", "2024-01-02 03:04:05", true},
+		{"RFC3339", "2024-01-02T03:04:05Z", true},
+		{"bytes", []byte("2024-01-02 03:04:05"), true},
+		{"nil pointer", (*time.Time)(nil), false},
+		{"invalid null time", sql.NullTime{}, false},
+		{"invalid text", "yesterday", false},
+		{"integer", 1704164645, false},
+		{"nil", nil, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, ok := database.AsTime(test.value)
+
+			if ok != test.ok {
+				t.Fatalf("Expected ok %v, got %v", test.ok, ok)
+			}
+
+			if ok && !result.Equal(expected) {
+				t.Errorf("Expected %v, got %v", expected, result)
+			}
+
+			if !ok && !result.IsZero() {
+				t.Errorf("Expected the zero time, got %v", result)
+			}
+		})
+	}
+
+	date, ok := database.AsTime("2024-01-02")
+	if !ok || !date.Equal(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected the date at midnight UTC, got %v %v", date, ok)
+	}
+}
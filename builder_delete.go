@@ -0,0 +1,135 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+)
+
+// DeleteBuilder builds a DELETE statement for a single table, with the
+// placeholders and identifier quoting of the dialect it is executed on.
+//
+// To guard against accidental deletes of all the rows, it refuses to build
+// a statement without a condition, unless AllowFullTableDelete is called.
+//
+// Use DeleteFrom to create it.
+//
+// Example:
+//
+//	result, err := database.DeleteFrom("sessions").
+//		Where("expires_at < ?", time.Now()).
+//		Exec(ctx)
+//
+//	deleted, err := result.RowsAffected()
+type DeleteBuilder struct {
+	table      string
+	conditions []string
+	args       []any
+	allowAll   bool
+
+	// softDeleteColumn is the column set to the current time
+	// instead of deleting the rows, empty for a hard delete
+	softDeleteColumn string
+}
+
+// DeleteFrom returns a new DeleteBuilder for the table.
+//
+// Parameters:
+// - table (string): The name of the table.
+//
+// Returns:
+// - *DeleteBuilder: The builder.
+func DeleteFrom(table string) *DeleteBuilder {
+	return &DeleteBuilder{table: table}
+}
+
+// Where adds a condition with ? placeholders and its arguments,
+// like SelectBuilder.Where. The conditions are joined with AND.
+func (b *DeleteBuilder) Where(condition string, args ...any) *DeleteBuilder {
+	b.conditions = append(b.conditions, condition)
+	b.args = append(b.args, args...)
+	return b
+}
+
+// AllowFullTableDelete allows building the statement without a condition,
+// which deletes all the rows of the table.
+func (b *DeleteBuilder) AllowFullTableDelete() *DeleteBuilder {
+	b.allowAll = true
+	return b
+}
+
+// Soft turns the delete into a soft delete, which sets the column to the
+// current time of the database (see Dialect.Now) instead of deleting the rows.
+// The rows which are already soft deleted are left untouched.
+//
+// Example:
+//
+//	// UPDATE "users" SET "deleted_at" = NOW() WHERE (id = $1) AND ("deleted_at" IS NULL)
+//	result, err := database.DeleteFrom("users").Where("id = ?", 1).Soft("deleted_at").Exec(ctx)
+func (b *DeleteBuilder) Soft(column string) *DeleteBuilder {
+	b.softDeleteColumn = column
+	return b
+}
+
+// ToSQL builds the statement for the database type, and returns it with
+// its arguments.
+//
+// Parameters:
+// - dbType (string): The database type, i.e. DATABASE_TYPE_POSTGRES.
+//
+// Returns:
+// - string: The SQL statement.
+// - []any: The arguments of the statement.
+// - error: An error if the table name is invalid, or no condition without AllowFullTableDelete.
+func (b *DeleteBuilder) ToSQL(dbType string) (string, []any, error) {
+	if len(b.conditions) == 0 && !b.allowAll {
+		return "", nil, errors.New("delete without a where condition, call AllowFullTableDelete to delete all the rows")
+	}
+
+	quotedTable, err := quoteIdentifier(dbType, b.table)
+	if err != nil {
+		return "", nil, err
+	}
+
+	conditions := b.conditions
+	sqlStr := "DELETE FROM " + quotedTable
+
+	if b.softDeleteColumn != "" {
+		quotedColumn, err := quoteIdentifier(dbType, b.softDeleteColumn)
+		if err != nil {
+			return "", nil, err
+		}
+
+		if conditions, err = softDeleteFilter(dbType, conditions, b.softDeleteColumn, true); err != nil {
+			return "", nil, err
+		}
+
+		sqlStr = "UPDATE " + quotedTable + " SET " + quotedColumn + " = " + DialectFor(dbType).Now()
+	}
+
+	if len(conditions) > 0 {
+		sqlStr += " WHERE " + joinConditions(conditions)
+	}
+
+	return Rebind(dbType, sqlStr), b.args, nil
+}
+
+// Exec builds the statement for the database of the context, and executes it.
+//
+// Parameters:
+// - ctx (QueryableContext): The context to use for the query execution.
+//
+// Returns:
+// - sql.Result: The result of the statement, i.e. for RowsAffected.
+// - error: An error if the statement is invalid, or failed.
+func (b *DeleteBuilder) Exec(ctx QueryableContext) (sql.Result, error) {
+	if ctx.queryable == nil {
+		return nil, ErrNoQueryable
+	}
+
+	sqlStr, args, err := b.ToSQL(ctx.databaseType())
+	if err != nil {
+		return nil, err
+	}
+
+	return ctx.execContext("DeleteFrom", sqlStr, args...)
+}
@@ -0,0 +1,162 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	database "github.com/dracory/database"
+)
+
+func TestDeleteBuilderToSQL(t *testing.T) {
+	sqlStr, args, err := database.DeleteFrom("users").
+		Where("id = ?", 1).
+		Where("name = ?", "Alice").
+		ToSQL(database.DATABASE_TYPE_POSTGRES)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `DELETE FROM "users" WHERE (id = $1) AND (name = $2)`
+	if sqlStr != expected {
+		t.Errorf("Expected %q, got %q", expected, sqlStr)
+	}
+
+	if len(args) != 2 || args[0] != 1 || args[1] != "Alice" {
+		t.Errorf("Unexpected args: %v", args)
+	}
+
+	sqlStr, _, err = database.DeleteFrom("users").Where("id = ?", 1).ToSQL(database.DATABASE_TYPE_MYSQL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if sqlStr != "DELETE FROM `users` WHERE id = ?" {
+		t.Errorf("Unexpected SQL: %q", sqlStr)
+	}
+}
+
+func TestDeleteBuilderRequiresWhere(t *testing.T) {
+	if _, _, err := database.DeleteFrom("users").ToSQL(database.DATABASE_TYPE_SQLITE); err == nil {
+		t.Fatal("Expected an error without a where condition")
+	}
+
+	sqlStr, _, err := database.DeleteFrom("users").AllowFullTableDelete().ToSQL(database.DATABASE_TYPE_SQLITE)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if sqlStr != `DELETE FROM "users"` {
+		t.Errorf("Unexpected SQL: %q", sqlStr)
+	}
+}
+
+func TestDeleteBuilderExec(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	if err := createUserTableAndInserTesttData(db); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := database.Context(context.Background(), db)
+
+	if _, err := database.DeleteFrom("users").Exec(ctx); err == nil {
+		t.Fatal("Expected an error without a where condition")
+	}
+
+	result, err := database.DeleteFrom("users").Where("id > ?", 1).Exec(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if affected, _ := result.RowsAffected(); affected != 2 {
+		t.Errorf("Expected 2 rows affected, got %d", affected)
+	}
+
+	result, err = database.DeleteFrom("users").AllowFullTableDelete().Exec(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if affected, _ := result.RowsAffected(); affected != 1 {
+		t.Errorf("Expected 1 row affected, got %d", affected)
+	}
+}
+
+func TestDeleteBuilderSoft(t *testing.T) {
+	sqlStr, args, err := database.DeleteFrom("users").
+		Where("id = ?", 1).
+		Soft("deleted_at").
+		ToSQL(database.DATABASE_TYPE_POSTGRES)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `UPDATE "users" SET "deleted_at" = NOW() WHERE (id = $1) AND ("deleted_at" IS NULL)`
+	if sqlStr != expected {
+		t.Errorf("Expected %q, got %q", expected, sqlStr)
+	}
+
+	if len(args) != 1 {
+		t.Errorf("Expected 1 arg, got %v", args)
+	}
+
+	sqlStr, _, err = database.DeleteFrom("users").
+		Soft("deleted_at").
+		AllowFullTableDelete().
+		ToSQL(database.DATABASE_TYPE_MSSQL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if sqlStr != "UPDATE [users] SET [deleted_at] = SYSDATETIME() WHERE [deleted_at] IS NULL" {
+		t.Errorf("Unexpected SQL: %q", sqlStr)
+	}
+
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	ctx := database.Context(context.Background(), db)
+
+	if _, err := database.Execute(ctx, "CREATE TABLE posts (id INTEGER PRIMARY KEY, deleted_at DATETIME)"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := database.Execute(ctx, "INSERT INTO posts (deleted_at) VALUES (NULL), (NULL)"); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := database.DeleteFrom("posts").Where("id = ?", 1).Soft("deleted_at").Exec(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if affected, _ := result.RowsAffected(); affected != 1 {
+		t.Errorf("Expected 1 row affected, got %d", affected)
+	}
+
+	// Already soft deleted
+	result, err = database.DeleteFrom("posts").Where("id = ?", 1).Soft("deleted_at").Exec(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if affected, _ := result.RowsAffected(); affected != 0 {
+		t.Errorf("Expected no rows affected, got %d", affected)
+	}
+
+	count, err := database.Count(ctx, "SELECT COUNT(*) FROM posts WHERE deleted_at IS NOT NULL")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if count != 1 {
+		t.Errorf("Expected 1 soft deleted row, got %d", count)
+	}
+}
@@ -0,0 +1,227 @@
+package database
+
+import (
+	"database/sql"
+	"maps"
+)
+
+// InsertBuilder builds an INSERT statement of a row for a single table,
+// with the placeholders and identifier quoting of the dialect it is
+// executed on. The columns are sorted by name, so the same row always
+// results in the same statement.
+//
+// Use InsertInto to create it.
+//
+// Example:
+//
+//	result, err := database.InsertInto("users").
+//		Values(map[string]any{"name": "John", "email": "john@example.com"}).
+//		Returning("id").
+//		Exec(ctx)
+//
+//	id, err := result.LastInsertId()
+type InsertBuilder struct {
+	table     string
+	values    map[string]any
+	returning string
+}
+
+// InsertInto returns a new InsertBuilder for the table.
+//
+// Parameters:
+// - table (string): The name of the table.
+//
+// Returns:
+// - *InsertBuilder: The builder.
+func InsertInto(table string) *InsertBuilder {
+	return &InsertBuilder{table: table, values: map[string]any{}}
+}
+
+// Values adds the values to insert, by column name.
+func (b *InsertBuilder) Values(values map[string]any) *InsertBuilder {
+	maps.Copy(b.values, values)
+	return b
+}
+
+// Returning sets the generated id column, which is returned by LastInsertId
+// of the result of Exec for all the dialects:
+//   - Postgres: INSERT ... RETURNING id
+//   - MSSQL: INSERT ... OUTPUT INSERTED.id
+//   - MySQL, SQLite: LastInsertId() of the auto increment column
+//
+// The id must be an integer.
+func (b *InsertBuilder) Returning(idColumn string) *InsertBuilder {
+	b.returning = idColumn
+	return b
+}
+
+// ToSQL builds the statement for the database type, and returns it with
+// its arguments.
+//
+// Parameters:
+// - dbType (string): The database type, i.e. DATABASE_TYPE_POSTGRES.
+//
+// Returns:
+// - string: The SQL statement.
+// - []any: The arguments of the statement.
+// - error: An error if the table or a column name is invalid, or there are no values.
+func (b *InsertBuilder) ToSQL(dbType string) (string, []any, error) {
+	return insertSQL(dbType, b.table, b.returning, b.values)
+}
+
+// Exec builds the statement for the database of the context, and executes it.
+//
+// Parameters:
+// - ctx (QueryableContext): The context to use for the query execution.
+//
+// Returns:
+// - sql.Result: The result of the statement, see Returning for the id.
+// - error: An error if the statement is invalid, or failed.
+func (b *InsertBuilder) Exec(ctx QueryableContext) (sql.Result, error) {
+	if ctx.queryable == nil {
+		return nil, ErrNoQueryable
+	}
+
+	dbType := ctx.databaseType()
+
+	sqlStr, args, err := b.ToSQL(dbType)
+	if err != nil {
+		return nil, err
+	}
+
+	// In dry run mode the statement is not executed, so no id is returned
+	if b.returning == "" || (dbType != DATABASE_TYPE_POSTGRES && dbType != DATABASE_TYPE_MSSQL) || ctx.dryRun {
+		return ctx.execContext("InsertInto", sqlStr, args...)
+	}
+
+	var id int64
+
+	row, run := ctx.queryRowContext("InsertInto", sqlStr, args...)
+
+	if err := row.Scan(&id); err != nil {
+		run.end(-1, err)
+		return nil, err
+	}
+
+	run.end(1, nil)
+
+	return returningResult{id: id}, nil
+}
+
+// ReturningAll builds the statement for the database of the context,
+// executes it, and returns the stored row, including the columns set by
+// the database, i.e. defaults, timestamps and generated ids.
+//
+// The row is returned as follows, based on DatabaseType:
+//   - Postgres: INSERT ... RETURNING *
+//   - MSSQL: INSERT ... OUTPUT INSERTED.*
+//   - MySQL, SQLite and others: the INSERT, and then a second query
+//     SELECT * FROM table WHERE id = ?, with the LastInsertId of the
+//     auto increment column (the id column set by Returning, "id" by default)
+//
+// Note the extra round trip on MySQL and SQLite, in which the row could be
+// modified in between, unless the context carries a transaction.
+//
+// In dry run mode (see WithDryRun) the statement is not executed,
+// and an empty map is returned.
+//
+// Example:
+//
+//	user, err := database.InsertInto("users").
+//		Values(map[string]any{"name": "John"}).
+//		ReturningAll(ctx)
+//
+//	createdAt := user["created_at"]
+//
+// Parameters:
+// - ctx (QueryableContext): The context to use for the query execution.
+//
+// Returns:
+// - map[string]any: The stored row, the same way as SelectOneMap.
+// - error: An error if the statement is invalid, or failed.
+func (b *InsertBuilder) ReturningAll(ctx QueryableContext) (map[string]any, error) {
+	if ctx.queryable == nil {
+		return nil, ErrNoQueryable
+	}
+
+	dbType := ctx.databaseType()
+
+	if ctx.dryRun || (dbType != DATABASE_TYPE_POSTGRES && dbType != DATABASE_TYPE_MSSQL) {
+		return b.insertAndSelect(ctx, dbType)
+	}
+
+	sqlStr, args, err := insertSQL(dbType, b.table, "*", b.values)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, run, err := ctx.queryContext("InsertInto", sqlStr, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	row, err := scanFirstRowToMap(rows)
+	if err != nil {
+		run.end(-1, err)
+		return nil, err
+	}
+
+	run.end(1, nil)
+
+	return row, nil
+}
+
+// insertAndSelect inserts the row, and selects it by its LastInsertId,
+// for the databases without RETURNING
+func (b *InsertBuilder) insertAndSelect(ctx QueryableContext, dbType string) (map[string]any, error) {
+	idColumn := b.returning
+	if idColumn == "" {
+		idColumn = "id"
+	}
+
+	quotedIDColumn, err := quoteIdentifier(dbType, idColumn)
+	if err != nil {
+		return nil, err
+	}
+
+	sqlStr, args, err := insertSQL(dbType, b.table, "", b.values)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := ctx.execContext("InsertInto", sqlStr, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	if ctx.dryRun {
+		return map[string]any{}, nil
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	selectSQL, selectArgs, err := Select(b.table).Where(quotedIDColumn+" = ?", id).ToSQL(dbType)
+	if err != nil {
+		return nil, err
+	}
+
+	return SelectOneMap(ctx, selectSQL, selectArgs...)
+}
+
+// returningResult is the result of an insert, which returned the id
+// with RETURNING or OUTPUT
+type returningResult struct {
+	id int64
+}
+
+func (r returningResult) LastInsertId() (int64, error) {
+	return r.id, nil
+}
+
+func (r returningResult) RowsAffected() (int64, error) {
+	return 1, nil
+}
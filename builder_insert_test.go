@@ -0,0 +1,184 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	database "github.com/dracory/database"
+)
+
+func TestInsertBuilderToSQL(t *testing.T) {
+	builder := database.InsertInto("users").
+		Values(map[string]any{"name": "John", "email": "john@example.com"}).
+		Returning("id")
+
+	tests := []struct {
+		dbType   string
+		expected string
+	}{
+		{database.DATABASE_TYPE_POSTGRES, `INSERT INTO "users" ("email", "name") VALUES ($1, $2) RETURNING "id"`},
+		{database.DATABASE_TYPE_MSSQL, `INSERT INTO [users] ([email], [name]) OUTPUT INSERTED.[id] VALUES (@p1, @p2)`},
+		{database.DATABASE_TYPE_MYSQL, "INSERT INTO `users` (`email`, `name`) VALUES (?, ?)"},
+	}
+
+	for _, test := range tests {
+		sqlStr, args, err := builder.ToSQL(test.dbType)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", test.dbType, err)
+		}
+
+		if sqlStr != test.expected {
+			t.Errorf("%s: expected %q, got %q", test.dbType, test.expected, sqlStr)
+		}
+
+		if len(args) != 2 || args[0] != "john@example.com" || args[1] != "John" {
+			t.Errorf("%s: expected args in column order, got %v", test.dbType, args)
+		}
+	}
+
+	sqlStr, _, err := database.InsertInto("users").
+		Values(map[string]any{"name": "John"}).
+		ToSQL(database.DATABASE_TYPE_POSTGRES)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if sqlStr != `INSERT INTO "users" ("name") VALUES ($1)` {
+		t.Errorf("Expected no RETURNING without Returning, got %q", sqlStr)
+	}
+
+	if _, _, err := database.InsertInto("users").ToSQL(database.DATABASE_TYPE_SQLITE); err == nil {
+		t.Error("Expected an error without values")
+	}
+}
+
+func TestInsertBuilderExec(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	if err := createUserTableAndInserTesttData(db); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := database.Context(context.Background(), db)
+
+	result, err := database.InsertInto("users").
+		Values(map[string]any{"name": "Dave"}).
+		Values(map[string]any{"email": "dave@example.com"}).
+		Returning("id").
+		Exec(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if id, _ := result.LastInsertId(); id != 4 {
+		t.Errorf("Expected id 4, got %d", id)
+	}
+
+	email, err := database.Scalar[string](ctx, "SELECT email FROM users WHERE id = 4")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if email != "dave@example.com" {
+		t.Errorf("Expected the merged values to be inserted, got %q", email)
+	}
+}
+
+func TestInsertBuilderReturningWithMock(t *testing.T) {
+	mock := database.NewMockQueryable().SetDatabaseType(database.DATABASE_TYPE_POSTGRES)
+	defer mock.Close()
+
+	mock.ExpectQuery(`INSERT INTO "users" ("name") VALUES ($1) RETURNING "user_id"`).
+		WithArgs("Eve").
+		WillReturnRows([]string{"user_id"}, []any{int64(42)})
+
+	result, err := database.InsertInto("users").
+		Values(map[string]any{"name": "Eve"}).
+		Returning("user_id").
+		Exec(database.Context(context.Background(), mock))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if id, _ := result.LastInsertId(); id != 42 {
+		t.Errorf("Expected id 42, got %d", id)
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestInsertBuilderReturningAllFallback(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	ctx := database.Context(context.Background(), db)
+
+	if _, err := database.Execute(ctx, "CREATE TABLE posts (id INTEGER PRIMARY KEY, title TEXT, status TEXT DEFAULT 'draft')"); err != nil {
+		t.Fatal(err)
+	}
+
+	logs := []database.QueryLog{}
+	database.SetLogger(func(info database.QueryLog) {
+		logs = append(logs, info)
+	})
+	defer database.SetLogger(nil)
+
+	row, err := database.InsertInto("posts").
+		Values(map[string]any{"title": "Hello"}).
+		ReturningAll(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if row["id"] != int64(1) || row["title"] != "Hello" || row["status"] != "draft" {
+		t.Errorf("Expected the stored row with the defaults, got %v", row)
+	}
+
+	// SQLite inserts, and then selects the row by its id
+	if len(logs) != 2 {
+		t.Fatalf("Expected 2 statements, got %d", len(logs))
+	}
+
+	if logs[1].SQL != `SELECT * FROM "posts" WHERE "id" = ?` || len(logs[1].Args) != 1 || logs[1].Args[0] != int64(1) {
+		t.Errorf("Unexpected follow-up select: %+v", logs[1])
+	}
+}
+
+func TestInsertBuilderReturningAllWithMock(t *testing.T) {
+	tests := []struct {
+		dbType      string
+		expectedSQL string
+	}{
+		{database.DATABASE_TYPE_POSTGRES, `INSERT INTO "posts" ("title") VALUES ($1) RETURNING *`},
+		{database.DATABASE_TYPE_MSSQL, `INSERT INTO [posts] ([title]) OUTPUT INSERTED.* VALUES (@p1)`},
+	}
+
+	for _, test := range tests {
+		mock := database.NewMockQueryable().SetDatabaseType(test.dbType)
+
+		mock.ExpectQuery(test.expectedSQL).
+			WithArgs("Hello").
+			WillReturnRows([]string{"id", "title", "status"}, []any{int64(7), "Hello", "draft"})
+
+		row, err := database.InsertInto("posts").
+			Values(map[string]any{"title": "Hello"}).
+			ReturningAll(database.Context(context.Background(), mock))
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", test.dbType, err)
+		}
+
+		if row["id"] != int64(7) || row["status"] != "draft" {
+			t.Errorf("%s: unexpected row %v", test.dbType, row)
+		}
+
+		mock.AssertExpectations(t)
+		mock.Close()
+	}
+}
@@ -0,0 +1,238 @@
+package database
+
+import (
+	"errors"
+	"strings"
+)
+
+// SelectBuilder builds a SELECT statement for a single table, with the
+// placeholders and identifier quoting of the dialect it is executed on,
+// as a safer alternative to concatenating SQL for simple queries.
+//
+// Use Select to create it. The conditions are written with ? placeholders,
+// which are rewritten for the dialect, and are joined with AND.
+//
+// Example:
+//
+//	rows, err := database.Select("users").
+//		Columns("id", "name").
+//		Where("active = ?", 1).
+//		Where("created_at > ?", since).
+//		OrderBy("name", "id DESC").
+//		Limit(20).
+//		ToMapAny(ctx)
+type SelectBuilder struct {
+	table      string
+	columns    []string
+	conditions []string
+	args       []any
+	orderBy    []string
+	limit      int
+	offset     int
+
+	softDeleteColumn string
+	includeDeleted   *bool
+}
+
+// Select returns a new SelectBuilder for the table, selecting all the columns.
+//
+// Parameters:
+// - table (string): The name of the table.
+//
+// Returns:
+// - *SelectBuilder: The builder.
+func Select(table string) *SelectBuilder {
+	return &SelectBuilder{table: table}
+}
+
+// Columns sets the columns to select, which are quoted. Without columns,
+// all the columns are selected (*).
+func (b *SelectBuilder) Columns(columns ...string) *SelectBuilder {
+	b.columns = append(b.columns, columns...)
+	return b
+}
+
+// Where adds a condition with ? placeholders and its arguments,
+// i.e. Where("age > ? AND age < ?", 18, 65). The conditions are joined with AND.
+//
+// The condition is added to the SQL as it is, so it must not contain
+// untrusted input, which must be passed as arguments instead.
+func (b *SelectBuilder) Where(condition string, args ...any) *SelectBuilder {
+	b.conditions = append(b.conditions, condition)
+	b.args = append(b.args, args...)
+	return b
+}
+
+// OrderBy adds columns to order by, which are quoted. A column can be
+// followed by ASC or DESC, i.e. OrderBy("name", "created_at DESC").
+func (b *SelectBuilder) OrderBy(columns ...string) *SelectBuilder {
+	b.orderBy = append(b.orderBy, columns...)
+	return b
+}
+
+// Limit sets the maximum number of rows to select. For MSSQL, which pages
+// only ordered rows, ORDER BY (SELECT NULL) is added without an OrderBy.
+func (b *SelectBuilder) Limit(limit int) *SelectBuilder {
+	b.limit = limit
+	return b
+}
+
+// Offset sets the number of rows to skip. It requires a limit.
+func (b *SelectBuilder) Offset(offset int) *SelectBuilder {
+	b.offset = offset
+	return b
+}
+
+// IncludeDeleted sets whether the soft deleted rows, whose soft delete
+// column is not NULL, are selected. It takes precedence over the option
+// of the context (see WithExcludeDeleted).
+//
+// Example:
+//
+//	// SELECT * FROM "users" WHERE "deleted_at" IS NULL
+//	users, err := database.Select("users").IncludeDeleted(false).ToMapAny(ctx)
+func (b *SelectBuilder) IncludeDeleted(include bool) *SelectBuilder {
+	b.includeDeleted = &include
+	return b
+}
+
+// SoftDeleteColumn sets the column marking the soft deleted rows,
+// "deleted_at" by default.
+func (b *SelectBuilder) SoftDeleteColumn(column string) *SelectBuilder {
+	b.softDeleteColumn = column
+	return b
+}
+
+// ToSQL builds the statement for the database type, and returns it with
+// its arguments.
+//
+// Parameters:
+// - dbType (string): The database type, i.e. DATABASE_TYPE_POSTGRES.
+//
+// Returns:
+// - string: The SQL statement.
+// - []any: The arguments of the statement.
+// - error: An error if a table or column name is invalid, or the limit and offset are invalid.
+func (b *SelectBuilder) ToSQL(dbType string) (string, []any, error) {
+	return b.toSQL(dbType, false)
+}
+
+// toSQL builds the statement, excluding the soft deleted rows
+// if the context does, unless the builder option is set
+func (b *SelectBuilder) toSQL(dbType string, excludeDeleted bool) (string, []any, error) {
+	conditions, err := softDeleteFilter(dbType, b.conditions, b.softDeleteColumn, isExcludingDeleted(b.includeDeleted, excludeDeleted))
+	if err != nil {
+		return "", nil, err
+	}
+
+	quotedTable, err := quoteIdentifier(dbType, b.table)
+	if err != nil {
+		return "", nil, err
+	}
+
+	columns := "*"
+
+	if len(b.columns) > 0 {
+		quotedColumns, err := quoteIdentifiers(dbType, b.columns)
+		if err != nil {
+			return "", nil, err
+		}
+
+		columns = strings.Join(quotedColumns, ", ")
+	}
+
+	sqlStr := "SELECT " + columns + " FROM " + quotedTable
+
+	if len(conditions) > 0 {
+		sqlStr += " WHERE " + joinConditions(conditions)
+	}
+
+	if len(b.orderBy) > 0 {
+		orderBy := make([]string, len(b.orderBy))
+
+		for i, column := range b.orderBy {
+			orderBy[i], err = quoteOrderByColumn(dbType, column)
+			if err != nil {
+				return "", nil, err
+			}
+		}
+
+		sqlStr += " ORDER BY " + strings.Join(orderBy, ", ")
+	} else if b.limit > 0 && DialectFor(dbType).Name() == DATABASE_TYPE_MSSQL {
+		// OFFSET FETCH requires an ORDER BY clause, the order is unspecified
+		sqlStr += " ORDER BY (SELECT NULL)"
+	}
+
+	if b.limit < 0 || b.offset < 0 {
+		return "", nil, errors.New("limit and offset cannot be negative")
+	}
+
+	if b.offset > 0 && b.limit == 0 {
+		return "", nil, errors.New("offset requires a limit")
+	}
+
+	if b.limit > 0 {
+		sqlStr += " " + DialectFor(dbType).LimitOffset(b.limit, b.offset)
+	}
+
+	return Rebind(dbType, sqlStr), b.args, nil
+}
+
+// ToMapAny builds the statement for the database of the context,
+// executes it, and returns the rows like SelectToMapAny.
+//
+// Parameters:
+// - ctx (QueryableContext): The context to use for the query execution.
+//
+// Returns:
+// - []map[string]any: The rows.
+// - error: An error if the statement is invalid, or the query failed.
+func (b *SelectBuilder) ToMapAny(ctx QueryableContext) ([]map[string]any, error) {
+	if ctx.queryable == nil {
+		return []map[string]any{}, ErrNoQueryable
+	}
+
+	sqlStr, args, err := b.toSQL(ctx.databaseType(), ctx.excludeDeleted)
+	if err != nil {
+		return []map[string]any{}, err
+	}
+
+	return SelectToMapAny(ctx, sqlStr, args...)
+}
+
+// joinConditions joins the conditions with AND,
+// wrapping each in parentheses if there are several
+func joinConditions(conditions []string) string {
+	if len(conditions) == 1 {
+		return conditions[0]
+	}
+
+	wrapped := make([]string, len(conditions))
+	for i, condition := range conditions {
+		wrapped[i] = "(" + condition + ")"
+	}
+
+	return strings.Join(wrapped, " AND ")
+}
+
+// quoteOrderByColumn quotes the column of an ORDER BY item,
+// keeping its ASC or DESC direction
+func quoteOrderByColumn(dbType string, item string) (string, error) {
+	column := strings.TrimSpace(item)
+	direction := ""
+
+	if i := strings.LastIndexByte(column, ' '); i > 0 {
+		switch strings.ToUpper(column[i+1:]) {
+		case "ASC", "DESC":
+			direction = " " + strings.ToUpper(column[i+1:])
+			column = strings.TrimSpace(column[:i])
+		}
+	}
+
+	quoted, err := quoteIdentifier(dbType, column)
+	if err != nil {
+		return "", err
+	}
+
+	return quoted + direction, nil
+}
@@ -0,0 +1,174 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	database "github.com/dracory/database"
+)
+
+func TestSelectBuilderToSQL(t *testing.T) {
+	builder := database.Select("users").
+		Columns("id", "name").
+		Where("active = ?", 1).
+		Where("name LIKE ? OR email = ?", "A%", "a@example.com").
+		OrderBy("name", "id desc").
+		Limit(10).
+		Offset(20)
+
+	tests := []struct {
+		dbType   string
+		expected string
+	}{
+		{database.DATABASE_TYPE_POSTGRES, `SELECT "id", "name" FROM "users" WHERE (active = $1) AND (name LIKE $2 OR email = $3) ORDER BY "name", "id" DESC LIMIT 10 OFFSET 20`},
+		{database.DATABASE_TYPE_MYSQL, "SELECT `id`, `name` FROM `users` WHERE (active = ?) AND (name LIKE ? OR email = ?) ORDER BY `name`, `id` DESC LIMIT 10 OFFSET 20"},
+		{database.DATABASE_TYPE_MSSQL, `SELECT [id], [name] FROM [users] WHERE (active = @p1) AND (name LIKE @p2 OR email = @p3) ORDER BY [name], [id] DESC OFFSET 20 ROWS FETCH NEXT 10 ROWS ONLY`},
+	}
+
+	for _, test := range tests {
+		sqlStr, args, err := builder.ToSQL(test.dbType)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", test.dbType, err)
+		}
+
+		if sqlStr != test.expected {
+			t.Errorf("%s: expected %q, got %q", test.dbType, test.expected, sqlStr)
+		}
+
+		if len(args) != 3 || args[0] != 1 || args[1] != "A%" || args[2] != "a@example.com" {
+			t.Errorf("%s: unexpected args %v", test.dbType, args)
+		}
+	}
+
+	sqlStr, args, err := database.Select("users").ToSQL(database.DATABASE_TYPE_SQLITE)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if sqlStr != `SELECT * FROM "users"` || len(args) != 0 {
+		t.Errorf("Expected a plain select, got %q %v", sqlStr, args)
+	}
+
+	sqlStr, _, err = database.Select("users").Limit(10).ToSQL(database.DATABASE_TYPE_MSSQL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if sqlStr != `SELECT * FROM [users] ORDER BY (SELECT NULL) OFFSET 0 ROWS FETCH NEXT 10 ROWS ONLY` {
+		t.Errorf("Expected an ORDER BY for the limit, got %q", sqlStr)
+	}
+}
+
+func TestSelectBuilderInvalid(t *testing.T) {
+	invalid := []*database.SelectBuilder{
+		database.Select(""),
+		database.Select("users").Columns("na\x00me"),
+		database.Select("users").OrderBy("users. DESC"),
+		database.Select("users").Offset(10),
+		database.Select("users").Limit(-1),
+	}
+
+	for i, builder := range invalid {
+		if _, _, err := builder.ToSQL(database.DATABASE_TYPE_SQLITE); err == nil {
+			t.Errorf("Builder %d: expected an error", i)
+		}
+	}
+}
+
+func TestSelectBuilderToMapAny(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	if err := createUserTableAndInserTesttData(db); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := database.Context(context.Background(), db)
+
+	rows, err := database.Select("users").
+		Columns("name").
+		Where("id > ?", 1).
+		OrderBy("name DESC").
+		Limit(1).
+		ToMapAny(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rows) != 1 || rows[0]["name"] != "Charlie" {
+		t.Errorf("Expected Charlie, got %v", rows)
+	}
+
+	if _, err := database.Select("users").ToMapAny(database.Context(context.Background(), nil)); err == nil {
+		t.Error("Expected an error for a nil querier")
+	}
+}
+
+func TestSelectBuilderSoftDelete(t *testing.T) {
+	sqlStr, _, err := database.Select("users").
+		Where("active = ?", 1).
+		IncludeDeleted(false).
+		ToSQL(database.DATABASE_TYPE_POSTGRES)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `SELECT * FROM "users" WHERE (active = $1) AND ("deleted_at" IS NULL)`
+	if sqlStr != expected {
+		t.Errorf("Expected %q, got %q", expected, sqlStr)
+	}
+
+	sqlStr, _, err = database.Select("users").
+		SoftDeleteColumn("removed_at").
+		IncludeDeleted(false).
+		ToSQL(database.DATABASE_TYPE_MYSQL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if sqlStr != "SELECT * FROM `users` WHERE `removed_at` IS NULL" {
+		t.Errorf("Unexpected SQL: %q", sqlStr)
+	}
+
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	ctx := database.Context(context.Background(), db)
+
+	if _, err := database.Execute(ctx, "CREATE TABLE posts (id INTEGER PRIMARY KEY, deleted_at DATETIME)"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := database.Execute(ctx, "INSERT INTO posts (deleted_at) VALUES (NULL), (CURRENT_TIMESTAMP), (NULL)"); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		ctx      database.QueryableContext
+		builder  *database.SelectBuilder
+		expected int
+	}{
+		{"default", ctx, database.Select("posts"), 3},
+		{"context", ctx.WithExcludeDeleted(true), database.Select("posts"), 2},
+		{"builder", ctx, database.Select("posts").IncludeDeleted(false), 2},
+		{"builder overrides context", ctx.WithExcludeDeleted(true), database.Select("posts").IncludeDeleted(true), 3},
+	}
+
+	for _, test := range tests {
+		rows, err := test.builder.ToMapAny(test.ctx)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", test.name, err)
+		}
+
+		if len(rows) != test.expected {
+			t.Errorf("%s: expected %d rows, got %d", test.name, test.expected, len(rows))
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"maps"
+	"strings"
+)
+
+// UpdateBuilder builds an UPDATE statement for a single table, with the
+// placeholders and identifier quoting of the dialect it is executed on.
+// The SET columns are sorted by name, and their arguments come before
+// the arguments of the conditions.
+//
+// To guard against accidental updates of all the rows, it refuses to build
+// a statement without a condition, unless AllowFullTableUpdate is called.
+//
+// Use Update to create it.
+//
+// Example:
+//
+//	result, err := database.Update("users").
+//		Set(map[string]any{"active": 0}).
+//		Where("last_login < ?", cutoff).
+//		Exec(ctx)
+type UpdateBuilder struct {
+	table      string
+	values     map[string]any
+	conditions []string
+	args       []any
+	allowAll   bool
+
+	softDeleteColumn string
+	includeDeleted   *bool
+}
+
+// Update returns a new UpdateBuilder for the table.
+//
+// Parameters:
+// - table (string): The name of the table.
+//
+// Returns:
+// - *UpdateBuilder: The builder.
+func Update(table string) *UpdateBuilder {
+	return &UpdateBuilder{table: table, values: map[string]any{}}
+}
+
+// Set adds the values to set, by column name.
+func (b *UpdateBuilder) Set(values map[string]any) *UpdateBuilder {
+	maps.Copy(b.values, values)
+	return b
+}
+
+// Where adds a condition with ? placeholders and its arguments,
+// like SelectBuilder.Where. The conditions are joined with AND.
+func (b *UpdateBuilder) Where(condition string, args ...any) *UpdateBuilder {
+	b.conditions = append(b.conditions, condition)
+	b.args = append(b.args, args...)
+	return b
+}
+
+// AllowFullTableUpdate allows building the statement without a condition,
+// which updates all the rows of the table.
+func (b *UpdateBuilder) AllowFullTableUpdate() *UpdateBuilder {
+	b.allowAll = true
+	return b
+}
+
+// IncludeDeleted sets whether the soft deleted rows, whose soft delete
+// column is not NULL, are updated, like SelectBuilder.IncludeDeleted.
+func (b *UpdateBuilder) IncludeDeleted(include bool) *UpdateBuilder {
+	b.includeDeleted = &include
+	return b
+}
+
+// SoftDeleteColumn sets the column marking the soft deleted rows,
+// "deleted_at" by default.
+func (b *UpdateBuilder) SoftDeleteColumn(column string) *UpdateBuilder {
+	b.softDeleteColumn = column
+	return b
+}
+
+// ToSQL builds the statement for the database type, and returns it with
+// its arguments.
+//
+// Parameters:
+// - dbType (string): The database type, i.e. DATABASE_TYPE_POSTGRES.
+//
+// Returns:
+// - string: The SQL statement.
+// - []any: The arguments of the statement, the SET values first.
+// - error: An error if a table or column name is invalid, there are no values, or no condition without AllowFullTableUpdate.
+func (b *UpdateBuilder) ToSQL(dbType string) (string, []any, error) {
+	return b.toSQL(dbType, false)
+}
+
+// toSQL builds the statement, excluding the soft deleted rows
+// if the context does, unless the builder option is set
+func (b *UpdateBuilder) toSQL(dbType string, excludeDeleted bool) (string, []any, error) {
+	if len(b.values) == 0 {
+		return "", nil, errors.New("no values to update")
+	}
+
+	if len(b.conditions) == 0 && !b.allowAll {
+		return "", nil, errors.New("update without a where condition, call AllowFullTableUpdate to update all the rows")
+	}
+
+	conditions, err := softDeleteFilter(dbType, b.conditions, b.softDeleteColumn, isExcludingDeleted(b.includeDeleted, excludeDeleted))
+	if err != nil {
+		return "", nil, err
+	}
+
+	quotedTable, err := quoteIdentifier(dbType, b.table)
+	if err != nil {
+		return "", nil, err
+	}
+
+	columns, args := sortedRowColumns(b.values)
+
+	quotedColumns, err := quoteIdentifiers(dbType, columns)
+	if err != nil {
+		return "", nil, err
+	}
+
+	assignments := make([]string, len(quotedColumns))
+	for i, quotedColumn := range quotedColumns {
+		assignments[i] = quotedColumn + " = ?"
+	}
+
+	sqlStr := "UPDATE " + quotedTable + " SET " + strings.Join(assignments, ", ")
+
+	if len(conditions) > 0 {
+		sqlStr += " WHERE " + joinConditions(conditions)
+	}
+
+	args = append(args, b.args...)
+
+	return Rebind(dbType, sqlStr), args, nil
+}
+
+// Exec builds the statement for the database of the context, and executes it.
+//
+// Parameters:
+// - ctx (QueryableContext): The context to use for the query execution.
+//
+// Returns:
+// - sql.Result: The result of the statement, i.e. for RowsAffected.
+// - error: An error if the statement is invalid, or failed.
+func (b *UpdateBuilder) Exec(ctx QueryableContext) (sql.Result, error) {
+	if ctx.queryable == nil {
+		return nil, ErrNoQueryable
+	}
+
+	sqlStr, args, err := b.toSQL(ctx.databaseType(), ctx.excludeDeleted)
+	if err != nil {
+		return nil, err
+	}
+
+	return ctx.execContext("Update", sqlStr, args...)
+}
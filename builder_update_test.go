@@ -0,0 +1,128 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	database "github.com/dracory/database"
+)
+
+func TestUpdateBuilderToSQL(t *testing.T) {
+	builder := database.Update("users").
+		Set(map[string]any{"name": "John", "email": "john@example.com"}).
+		Where("id = ?", 1).
+		Where("name <> ?", "John")
+
+	sqlStr, args, err := builder.ToSQL(database.DATABASE_TYPE_POSTGRES)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `UPDATE "users" SET "email" = $1, "name" = $2 WHERE (id = $3) AND (name <> $4)`
+	if sqlStr != expected {
+		t.Errorf("Expected %q, got %q", expected, sqlStr)
+	}
+
+	if len(args) != 4 || args[0] != "john@example.com" || args[1] != "John" || args[2] != 1 || args[3] != "John" {
+		t.Errorf("Expected the SET args before the WHERE args, got %v", args)
+	}
+
+	sqlStr, _, err = builder.ToSQL(database.DATABASE_TYPE_MSSQL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected = `UPDATE [users] SET [email] = @p1, [name] = @p2 WHERE (id = @p3) AND (name <> @p4)`
+	if sqlStr != expected {
+		t.Errorf("Expected %q, got %q", expected, sqlStr)
+	}
+}
+
+func TestUpdateBuilderRequiresWhere(t *testing.T) {
+	builder := database.Update("users").Set(map[string]any{"name": "John"})
+
+	if _, _, err := builder.ToSQL(database.DATABASE_TYPE_SQLITE); err == nil {
+		t.Fatal("Expected an error without a where condition")
+	}
+
+	sqlStr, _, err := builder.AllowFullTableUpdate().ToSQL(database.DATABASE_TYPE_SQLITE)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if sqlStr != `UPDATE "users" SET "name" = ?` {
+		t.Errorf("Unexpected SQL: %q", sqlStr)
+	}
+
+	if _, _, err := database.Update("users").Where("id = ?", 1).ToSQL(database.DATABASE_TYPE_SQLITE); err == nil {
+		t.Error("Expected an error without values")
+	}
+}
+
+func TestUpdateBuilderExec(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	if err := createUserTableAndInserTesttData(db); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := database.Context(context.Background(), db)
+
+	result, err := database.Update("users").
+		Set(map[string]any{"name": "Bobby"}).
+		Where("id = ?", 2).
+		Exec(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if affected, _ := result.RowsAffected(); affected != 1 {
+		t.Errorf("Expected 1 row affected, got %d", affected)
+	}
+
+	name, err := database.Scalar[string](ctx, "SELECT name FROM users WHERE id = 2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if name != "Bobby" {
+		t.Errorf("Expected Bobby, got %q", name)
+	}
+
+	if _, err := database.Update("users").Set(map[string]any{"name": "X"}).Exec(ctx); err == nil {
+		t.Error("Expected an error without a where condition")
+	}
+}
+
+func TestUpdateBuilderSoftDelete(t *testing.T) {
+	sqlStr, args, err := database.Update("users").
+		Set(map[string]any{"name": "John"}).
+		Where("id = ?", 1).
+		IncludeDeleted(false).
+		ToSQL(database.DATABASE_TYPE_POSTGRES)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `UPDATE "users" SET "name" = $1 WHERE (id = $2) AND ("deleted_at" IS NULL)`
+	if sqlStr != expected {
+		t.Errorf("Expected %q, got %q", expected, sqlStr)
+	}
+
+	if len(args) != 2 {
+		t.Errorf("Expected 2 args, got %v", args)
+	}
+
+	// The soft delete condition does not count as a where condition
+	_, _, err = database.Update("users").
+		Set(map[string]any{"name": "John"}).
+		IncludeDeleted(false).
+		ToSQL(database.DATABASE_TYPE_POSTGRES)
+	if err == nil {
+		t.Error("Expected an error without a where condition")
+	}
+}
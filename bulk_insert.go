@@ -0,0 +1,156 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// BulkInsert inserts the rows into the table in batches, using multi-row
+// INSERT INTO table (columns) VALUES (...), (...) statements, with the
+// placeholder style of the database type.
+//
+// The batch size is reduced if needed, so that the number of placeholders
+// in a statement stays within the limit of the database type (i.e. 65535 for
+// Postgres and MySQL, 32766 for SQLite, 2100 for MSSQL, which also allows
+// at most 1000 rows per statement). A batch size of zero
+// or less uses the largest batch size allowed.
+//
+// If the context carries a transaction (Tx), the rows are inserted in it.
+// If it carries a database (DB), the batches are inserted in a new transaction,
+// so either all or none of the rows are inserted.
+//
+// Example usage:
+//
+//	affected, err := BulkInsert(ctx, "users", []string{"name", "email"}, [][]any{
+//		{"Alice", "alice@example.com"},
+//		{"Bob", "bob@example.com"},
+//	}, 1000)
+//
+// Parameters:
+// - ctx (QueryableContext): The context to use for the query execution.
+// - table (string): The name of the table.
+// - columns ([]string): The names of the columns.
+// - rows ([][]any): The rows to insert, each with a value per column.
+// - batchSize (int): The maximum number of rows per statement.
+//
+// Returns:
+// - int64: The total number of affected rows.
+// - error: An error if the rows are invalid, or a statement failed.
+func BulkInsert(ctx QueryableContext, table string, columns []string, rows [][]any, batchSize int) (int64, error) {
+	if ctx.queryable == nil {
+		return 0, ErrNoQueryable
+	}
+
+	if table == "" {
+		return 0, errors.New("table name is required")
+	}
+
+	if len(columns) == 0 {
+		return 0, errors.New("at least one column is required")
+	}
+
+	for i, row := range rows {
+		if len(row) != len(columns) {
+			return 0, errors.New("row " + strconv.Itoa(i) + " has " + strconv.Itoa(len(row)) +
+				" values, expected " + strconv.Itoa(len(columns)))
+		}
+	}
+
+	if len(rows) == 0 {
+		return 0, nil
+	}
+
+	dbType := ctx.databaseType()
+
+	quotedTable, err := quoteIdentifier(dbType, table)
+	if err != nil {
+		return 0, err
+	}
+
+	quotedColumns, err := quoteIdentifiers(dbType, columns)
+	if err != nil {
+		return 0, err
+	}
+
+	maxRows := DialectFor(dbType).MaxPlaceholders() / len(columns)
+
+	// MSSQL also limits the row value expressions to 1000 per statement
+	if dbType == DATABASE_TYPE_MSSQL {
+		maxRows = min(maxRows, 1000)
+	}
+
+	if maxRows == 0 {
+		return 0, errors.New("too many columns for a single statement: " + strconv.Itoa(len(columns)))
+	}
+
+	if batchSize <= 0 || batchSize > maxRows {
+		batchSize = maxRows
+	}
+
+	insert := func(ctx QueryableContext) (int64, error) {
+		var total int64
+
+		for start := 0; start < len(rows); start += batchSize {
+			batch := rows[start:min(start+batchSize, len(rows))]
+
+			sqlStr, args := bulkInsertSQL(dbType, quotedTable, quotedColumns, batch)
+
+			result, err := ctx.execContext("BulkInsert", sqlStr, args...)
+			if err != nil {
+				return total, err
+			}
+
+			affected, err := result.RowsAffected()
+			if err != nil {
+				return total, err
+			}
+
+			total += affected
+		}
+
+		return total, nil
+	}
+
+	db, isDB := ctx.queryable.(*sql.DB)
+
+	if !isDB || len(rows) <= batchSize {
+		return insert(ctx)
+	}
+
+	var total int64
+
+	err = Transaction(ctx, db, func(txCtx QueryableContext) error {
+		var err error
+		total, err = insert(txCtx)
+		return err
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+// bulkInsertSQL builds a multi-row insert statement for the batch of rows
+func bulkInsertSQL(dbType string, quotedTable string, quotedColumns []string, batch [][]any) (string, []any) {
+	rowPlaceholders := "(" + strings.TrimSuffix(strings.Repeat("?, ", len(quotedColumns)), ", ") + ")"
+
+	var b strings.Builder
+	b.WriteString("INSERT INTO " + quotedTable + " (" + strings.Join(quotedColumns, ", ") + ") VALUES ")
+
+	args := make([]any, 0, len(batch)*len(quotedColumns))
+
+	for i, row := range batch {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+
+		b.WriteString(rowPlaceholders)
+		args = append(args, row...)
+	}
+
+	return Rebind(dbType, b.String()), args
+}
@@ -0,0 +1,140 @@
+package database
+
+import (
+	"errors"
+	"maps"
+	"reflect"
+	"slices"
+)
+
+// BulkInsertStructs inserts the structs into the table in batches, like
+// BulkInsert, with the columns derived from the fields of T, in sorted order.
+//
+// The columns are mapped like SelectToStructs: from the `db` struct tags,
+// or the snake_case of the field names, and the fields tagged `db:"-"` are
+// skipped. The "id" column is left out of the insert if it is zero in all
+// the rows, so the database generates it, see BulkInsertStructsWithOptions
+// to configure it.
+//
+// Example usage:
+//
+//	type User struct {
+//		ID    int64  `db:"id"`
+//		Name  string `db:"name"`
+//		Email string `db:"email"`
+//		Temp  string `db:"-"`
+//	}
+//
+//	affected, err := BulkInsertStructs(ctx, "users", []User{{Name: "Alice"}, {Name: "Bob"}}, 1000)
+//
+// Parameters:
+// - ctx (QueryableContext): The context to use for the query execution.
+// - table (string): The name of the table.
+// - rows ([]T): The structs to insert.
+// - batchSize (int): The maximum number of rows per statement.
+//
+// Returns:
+// - int64: The total number of affected rows.
+// - error: An error if T is not a struct, the rows are invalid, or a statement failed.
+func BulkInsertStructs[T any](ctx QueryableContext, table string, rows []T, batchSize int) (int64, error) {
+	return BulkInsertStructsWithOptions(ctx, BulkInsertStructsOptions{}, table, rows, batchSize)
+}
+
+// BulkInsertStructsOptions configures the auto increment column
+// of BulkInsertStructsWithOptions.
+type BulkInsertStructsOptions struct {
+	// AutoIncrementColumn is the column generated by the database, which is
+	// left out of the insert if it is zero in all the rows. Defaults to "id".
+	AutoIncrementColumn string
+
+	// InsertZeroAutoIncrement inserts the auto increment column even if it
+	// is zero, i.e. for MySQL with the NO_AUTO_VALUE_ON_ZERO mode.
+	InsertZeroAutoIncrement bool
+}
+
+// BulkInsertStructsWithOptions works like BulkInsertStructs, but allows
+// configuring the auto increment column.
+//
+// If the auto increment column is zero in some rows only, an error is
+// returned, as the rows of a statement must all have the same columns.
+//
+// Example usage:
+//
+//	affected, err := BulkInsertStructsWithOptions(ctx, BulkInsertStructsOptions{AutoIncrementColumn: "user_id"}, "users", users, 1000)
+//
+// Parameters:
+// - ctx (QueryableContext): The context to use for the query execution.
+// - options (BulkInsertStructsOptions): The auto increment column options.
+// - table (string): The name of the table.
+// - rows ([]T): The structs to insert.
+// - batchSize (int): The maximum number of rows per statement.
+//
+// Returns:
+// - int64: The total number of affected rows.
+// - error: An error if T is not a struct, the rows are invalid, or a statement failed.
+func BulkInsertStructsWithOptions[T any](ctx QueryableContext, options BulkInsertStructsOptions, table string, rows []T, batchSize int) (int64, error) {
+	if ctx.queryable == nil {
+		return 0, ErrNoQueryable
+	}
+
+	t := reflect.TypeFor[T]()
+
+	if !isMappableStruct(t) {
+		return 0, errors.New("type " + t.String() + " is not a struct")
+	}
+
+	if options.AutoIncrementColumn == "" {
+		options.AutoIncrementColumn = "id"
+	}
+
+	fields := structFields(t)
+	columns := slices.Sorted(maps.Keys(fields))
+
+	values := make([][]any, len(rows))
+
+	for i := range rows {
+		v := reflect.ValueOf(&rows[i]).Elem()
+		values[i] = make([]any, len(columns))
+
+		for j, column := range columns {
+			field, err := v.FieldByIndexErr(fields[column])
+			if err != nil {
+				// Nil embedded struct pointer
+				continue
+			}
+
+			values[i][j] = field.Interface()
+		}
+	}
+
+	autoIncrement := slices.Index(columns, options.AutoIncrementColumn)
+
+	if autoIncrement >= 0 && !options.InsertZeroAutoIncrement && len(rows) > 0 {
+		zeros := 0
+
+		for i := range values {
+			if isZeroValue(values[i][autoIncrement]) {
+				zeros++
+			}
+		}
+
+		if zeros > 0 && zeros < len(rows) {
+			return 0, errors.New("column " + options.AutoIncrementColumn + " is zero in some rows only")
+		}
+
+		if zeros == len(rows) {
+			columns = slices.Delete(columns, autoIncrement, autoIncrement+1)
+
+			for i := range values {
+				values[i] = slices.Delete(values[i], autoIncrement, autoIncrement+1)
+			}
+		}
+	}
+
+	return BulkInsert(ctx, table, columns, values, batchSize)
+}
+
+// isZeroValue checks if the value is nil or the zero value of its type
+func isZeroValue(value any) bool {
+	return value == nil || reflect.ValueOf(value).IsZero()
+}
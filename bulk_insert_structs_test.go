@@ -0,0 +1,111 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	database "github.com/dracory/database"
+)
+
+type testBulkUser struct {
+	ID      int64  `db:"id"`
+	Name    string `db:"name"`
+	Email   string // matched by snake_case
+	Ignored string `db:"-"`
+}
+
+func TestBulkInsertStructs(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	if _, err := database.BulkInsertStructs(database.Context(context.Background(), nil), "users", []testBulkUser{{Name: "Alice"}}, 0); !errors.Is(err, database.ErrNoQueryable) {
+		t.Errorf("Expected ErrNoQueryable, got %v", err)
+	}
+
+	ctx := database.Context(context.Background(), db)
+
+	if _, err := database.Execute(ctx, "CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT, email TEXT)"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := database.BulkInsertStructs(ctx, "users", []int{1, 2}, 0); err == nil {
+		t.Error("Expected an error for a non struct type")
+	}
+
+	// The zero ids are generated by the database
+	affected, err := database.BulkInsertStructs(ctx, "users", []testBulkUser{
+		{Name: "Alice", Email: "alice@example.com", Ignored: "x"},
+		{Name: "Bob", Email: "bob@example.com"},
+		{Name: "Charlie", Email: "charlie@example.com"},
+	}, 2)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if affected != 3 {
+		t.Errorf("Expected 3 rows affected, got %d", affected)
+	}
+
+	// The non zero ids are inserted
+	if _, err := database.BulkInsertStructs(ctx, "users", []testBulkUser{{ID: 10, Name: "Dave"}}, 0); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	users, err := database.SelectToStructs[testBulkUser](ctx, "SELECT * FROM users ORDER BY id")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(users) != 4 || users[0].ID != 1 || users[2].Email != "charlie@example.com" || users[3].ID != 10 {
+		t.Errorf("Unexpected users: %+v", users)
+	}
+
+	if _, err := database.BulkInsertStructs(ctx, "users", []testBulkUser{{ID: 20, Name: "Eve"}, {Name: "Frank"}}, 0); err == nil {
+		t.Error("Expected an error for ids zero in some rows only")
+	}
+}
+
+func TestBulkInsertStructsWithOptions(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	type account struct {
+		AccountID int64  `db:"account_id"`
+		Name      string `db:"name"`
+	}
+
+	ctx := database.Context(context.Background(), db)
+
+	if _, err := database.Execute(ctx, "CREATE TABLE accounts (account_id INTEGER PRIMARY KEY, name TEXT)"); err != nil {
+		t.Fatal(err)
+	}
+
+	options := database.BulkInsertStructsOptions{AutoIncrementColumn: "account_id"}
+
+	if _, err := database.BulkInsertStructsWithOptions(ctx, options, "accounts", []account{{Name: "Alice"}, {Name: "Bob"}}, 0); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// The zero id is inserted as it is
+	options.InsertZeroAutoIncrement = true
+
+	if _, err := database.BulkInsertStructsWithOptions(ctx, options, "accounts", []account{{Name: "Zero"}}, 0); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	names, err := database.SelectToMapString(ctx, "SELECT account_id, name FROM accounts ORDER BY account_id")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(names) != 3 || names[0]["account_id"] != "0" || names[0]["name"] != "Zero" || names[2]["account_id"] != "2" {
+		t.Errorf("Unexpected accounts: %v", names)
+	}
+}
@@ -0,0 +1,123 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	database "github.com/dracory/database"
+)
+
+func TestBulkInsert(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	err = createUserTableAndInserTesttData(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Test nil querier error
+	_, err = database.BulkInsert(database.Context(context.Background(), nil), "users", []string{"name"}, [][]any{{"Dave"}}, 10)
+	if err == nil || err.Error() != "querier (db/tx/conn) is nil" {
+		t.Errorf("Expected nil querier error, got %v", err)
+	}
+
+	ctx := database.Context(context.Background(), db)
+
+	// Test rows are inserted in batches
+	rows := [][]any{
+		{"Dave", "dave@example.com"},
+		{"Eve", "eve@example.com"},
+		{"Frank", "frank@example.com"},
+		{"Grace", "grace@example.com"},
+		{"Heidi", "heidi@example.com"},
+	}
+
+	affected, err := database.BulkInsert(ctx, "users", []string{"name", "email"}, rows, 2)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if affected != 5 {
+		t.Errorf("Expected 5 affected rows, got %d", affected)
+	}
+
+	count, err := database.Count(ctx, "SELECT COUNT(*) FROM users")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if count != 8 {
+		t.Errorf("Expected 8 users, got %d", count)
+	}
+
+	// Test no rows
+	affected, err = database.BulkInsert(ctx, "users", []string{"name"}, nil, 2)
+	if err != nil || affected != 0 {
+		t.Errorf("Expected 0 affected rows and no error, got %d and %v", affected, err)
+	}
+
+	// Test row with wrong number of values
+	_, err = database.BulkInsert(ctx, "users", []string{"name", "email"}, [][]any{{"Ivan"}}, 2)
+	if err == nil {
+		t.Error("Expected error for row with wrong number of values")
+	}
+
+	// Test a failing batch rolls back all the batches
+	_, err = database.BulkInsert(ctx, "users", []string{"id", "name"}, [][]any{
+		{100, "Judy"},
+		{101, "Mallory"},
+		{1, "Duplicate"},
+	}, 2)
+	if err == nil {
+		t.Fatal("Expected error for duplicate id")
+	}
+
+	exists, err := database.Exists(ctx, "SELECT 1 FROM users WHERE id = ?", 100)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if exists {
+		t.Error("Expected the first batch to be rolled back")
+	}
+}
+
+func TestBulkInsertInTransaction(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	err = createUserTableAndInserTesttData(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Test the rows are inserted in the caller's transaction
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	affected, err := database.BulkInsert(database.Context(context.Background(), tx), "users", []string{"name"}, [][]any{{"Dave"}, {"Eve"}, {"Frank"}}, 2)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if affected != 3 {
+		t.Errorf("Expected 3 affected rows, got %d", affected)
+	}
+
+	if err := tx.Rollback(); err != nil {
+		t.Fatal(err)
+	}
+
+	count, err := database.Count(database.Context(context.Background(), db), "SELECT COUNT(*) FROM users")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if count != 3 {
+		t.Errorf("Expected 3 users after rollback, got %d", count)
+	}
+}
@@ -0,0 +1,198 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync/atomic"
+	"time"
+)
+
+// Cluster routes queries between a primary database, which receives the
+// writes, and read replicas, which receive the reads in round-robin.
+//
+// Replicas marked as down by the health checks (see CheckHealth and
+// StartHealthChecks) are excluded from the routing, until they are up again.
+// If all the replicas are down, the reads are routed to the primary.
+//
+// The helpers (Query, SelectTo*, etc.) use whatever queryable the context
+// carries, so only the choice between ReadContext and WriteContext changes.
+//
+// Example:
+//
+//	cluster := database.NewCluster(primaryDB, replicaDB1, replicaDB2)
+//	cluster.StartHealthChecks(ctx, 10*time.Second)
+//
+//	users, err := database.SelectToMapAny(cluster.ReadContext(ctx), "SELECT * FROM users")
+//	_, err = database.Execute(cluster.WriteContext(ctx), "UPDATE users SET active = 1")
+type Cluster struct {
+	primary  *sql.DB
+	replicas []*sql.DB
+
+	// down marks the replicas which failed the last health check
+	down []atomic.Bool
+
+	// next is the round-robin counter of the reads
+	next atomic.Uint64
+}
+
+// NewCluster creates a new cluster with the primary database and
+// the read replicas. Without replicas, all queries go to the primary.
+//
+// Parameters:
+// - primary: The primary database, which receives the writes.
+// - replicas: The read replicas.
+//
+// Returns:
+// - *Cluster: The new cluster.
+func NewCluster(primary *sql.DB, replicas ...*sql.DB) *Cluster {
+	return &Cluster{
+		primary:  primary,
+		replicas: replicas,
+		down:     make([]atomic.Bool, len(replicas)),
+	}
+}
+
+// Primary returns the primary database.
+func (c *Cluster) Primary() *sql.DB {
+	return c.primary
+}
+
+// Replicas returns the read replicas.
+func (c *Cluster) Replicas() []*sql.DB {
+	return c.replicas
+}
+
+// WriteContext returns a QueryableContext carrying the primary database.
+//
+// If ctx already carries a transaction, it is returned as it is,
+// so that the statements stay in the transaction.
+//
+// Parameters:
+// - ctx: The parent context.
+//
+// Returns:
+// - QueryableContext: The context carrying the primary database.
+func (c *Cluster) WriteContext(ctx context.Context) QueryableContext {
+	return c.routeContext(ctx, c.primary)
+}
+
+// ReadContext returns a QueryableContext carrying the next healthy read
+// replica in round-robin, or the primary database if no replica is healthy.
+//
+// If ctx already carries a transaction, it is returned as it is,
+// so that the queries read the writes of the transaction.
+//
+// Parameters:
+// - ctx: The parent context.
+//
+// Returns:
+// - QueryableContext: The context carrying a replica or the primary database.
+func (c *Cluster) ReadContext(ctx context.Context) QueryableContext {
+	return c.routeContext(ctx, c.nextReplica())
+}
+
+// nextReplica returns the next healthy replica, or the primary if none
+func (c *Cluster) nextReplica() *sql.DB {
+	count := uint64(len(c.replicas))
+
+	if count == 0 {
+		return c.primary
+	}
+
+	start := c.next.Add(1) - 1
+
+	for i := range count {
+		index := (start + i) % count
+
+		if !c.down[index].Load() {
+			return c.replicas[index]
+		}
+	}
+
+	return c.primary
+}
+
+// routeContext returns a context carrying the database, keeping the options
+// of ctx, or ctx itself if it carries a transaction
+func (c *Cluster) routeContext(ctx context.Context, db *sql.DB) QueryableContext {
+	qCtx, ok := ctx.(QueryableContext)
+
+	if !ok {
+		if qCtx, ok = derivedQueryableContext(ctx); ok {
+			qCtx.Context = ctx
+		}
+	}
+
+	if !ok {
+		return NewQueryableContext(ctx, db)
+	}
+
+	if qCtx.IsTx() {
+		return qCtx
+	}
+
+	return qCtx.WithQueryable(db)
+}
+
+// CheckHealth pings all the replicas, and marks them as down or up
+// for the routing of ReadContext.
+//
+// Parameters:
+// - ctx: The context to use for the pings.
+//
+// Returns:
+// - error: The joined errors of the replicas which are down, or nil.
+func (c *Cluster) CheckHealth(ctx context.Context) error {
+	var errs []error
+
+	for i, replica := range c.replicas {
+		err := replica.PingContext(ctx)
+
+		c.down[i].Store(err != nil)
+
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// StartHealthChecks runs CheckHealth right away, and then at every interval
+// in a background goroutine, until the context is canceled.
+//
+// Parameters:
+// - ctx: The context, which stops the health checks when canceled.
+// - interval: The time between the health checks.
+func (c *Cluster) StartHealthChecks(ctx context.Context, interval time.Duration) {
+	_ = c.CheckHealth(ctx)
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				_ = c.CheckHealth(ctx)
+			}
+		}
+	}()
+}
+
+// Close closes the primary database and all the replicas.
+//
+// Returns:
+// - error: The joined errors of closing the databases, or nil.
+func (c *Cluster) Close() error {
+	errs := []error{c.primary.Close()}
+
+	for _, replica := range c.replicas {
+		errs = append(errs, replica.Close())
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,114 @@
+package database_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	database "github.com/dracory/database"
+)
+
+// initNamedSqliteDB creates an in memory database with a table
+// holding its name, to check where the queries are routed
+func initNamedSqliteDB(t *testing.T, name string) *sql.DB {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := database.Context(context.Background(), db)
+
+	err = database.ExecuteMany(ctx, []string{
+		"CREATE TABLE node (name TEXT)",
+		"INSERT INTO node (name) VALUES ('" + name + "')",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return db
+}
+
+func nodeName(t *testing.T, ctx database.QueryableContext) string {
+	name, err := database.Scalar[string](ctx, "SELECT name FROM node")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	return name
+}
+
+func TestCluster(t *testing.T) {
+	primary := initNamedSqliteDB(t, "primary")
+	replica1 := initNamedSqliteDB(t, "replica1")
+	replica2 := initNamedSqliteDB(t, "replica2")
+
+	cluster := database.NewCluster(primary, replica1, replica2)
+	defer cluster.Close()
+
+	ctx := context.Background()
+
+	// Test writes go to the primary
+	if name := nodeName(t, cluster.WriteContext(ctx)); name != "primary" {
+		t.Errorf("Expected write to primary, got %s", name)
+	}
+
+	// Test reads are distributed in round-robin
+	reads := []string{nodeName(t, cluster.ReadContext(ctx)), nodeName(t, cluster.ReadContext(ctx)), nodeName(t, cluster.ReadContext(ctx))}
+	if reads[0] != "replica1" || reads[1] != "replica2" || reads[2] != "replica1" {
+		t.Errorf("Expected round-robin reads, got %v", reads)
+	}
+
+	// Test down replicas are excluded
+	replica1.Close()
+
+	if err := cluster.CheckHealth(ctx); err == nil {
+		t.Error("Expected error for the closed replica")
+	}
+
+	for range 3 {
+		if name := nodeName(t, cluster.ReadContext(ctx)); name != "replica2" {
+			t.Errorf("Expected read from replica2, got %s", name)
+		}
+	}
+
+	// Test reads go to the primary when all replicas are down
+	replica2.Close()
+	_ = cluster.CheckHealth(ctx)
+
+	if name := nodeName(t, cluster.ReadContext(ctx)); name != "primary" {
+		t.Errorf("Expected read from primary, got %s", name)
+	}
+}
+
+func TestClusterKeepsTransaction(t *testing.T) {
+	primary := initNamedSqliteDB(t, "primary")
+	replica := initNamedSqliteDB(t, "replica")
+
+	cluster := database.NewCluster(primary, replica)
+	defer cluster.Close()
+
+	err := database.Transaction(context.Background(), primary, func(txCtx database.QueryableContext) error {
+		readCtx := cluster.ReadContext(txCtx)
+
+		if !readCtx.IsTx() {
+			t.Error("Expected the read context to keep the transaction")
+		}
+
+		if name := nodeName(t, readCtx); name != "primary" {
+			t.Errorf("Expected read in the transaction, got %s", name)
+		}
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Test the options of the context are kept
+	readCtx := cluster.ReadContext(database.Context(context.Background(), nil).WithRebind())
+
+	if readCtx.Queryable() != replica {
+		t.Error("Expected the read context to carry the replica")
+	}
+}
@@ -0,0 +1,61 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"sync"
+)
+
+// AcquireConn pins a single connection (*sql.Conn) of the database carried
+// by the context, and returns a context carrying it, so that session settings
+// (i.e. SET search_path) apply to all the statements executed with it.
+//
+// The release function returns the connection to the pool, and must be called
+// when done. Calling it more than once is safe. Note that the session settings
+// stay on the connection after it is released, unless they are reset.
+//
+// Example usage:
+//
+//	connCtx, release, err := AcquireConn(ctx)
+//	if err != nil {
+//		return err
+//	}
+//	defer release()
+//
+//	_, err = Execute(connCtx, "SET search_path TO tenant_1")
+//	rows, err := Query(connCtx, "SELECT * FROM users")
+//
+// Parameters:
+// - ctx (QueryableContext): The context carrying the database.
+//
+// Returns:
+// - QueryableContext: A new context carrying the connection, for which IsConn is true.
+// - func() error: The function releasing the connection.
+// - error: An error if the context does not carry a *sql.DB, or no connection could be acquired.
+func AcquireConn(ctx QueryableContext) (QueryableContext, func() error, error) {
+	if ctx.queryable == nil {
+		return QueryableContext{}, nil, ErrNoQueryable
+	}
+
+	if ctx.IsTx() {
+		return QueryableContext{}, nil, errors.New("cannot acquire connection, context carries a transaction")
+	}
+
+	if ctx.IsConn() {
+		return QueryableContext{}, nil, errors.New("cannot acquire connection, context already carries a connection")
+	}
+
+	db, ok := ctx.queryable.(*sql.DB)
+
+	if !ok {
+		return QueryableContext{}, nil, errors.New("cannot acquire connection, context does not carry a db")
+	}
+
+	conn, err := db.Conn(ctx.Context)
+
+	if err != nil {
+		return QueryableContext{}, nil, err
+	}
+
+	return ctx.WithQueryable(conn), sync.OnceValue(conn.Close), nil
+}
@@ -0,0 +1,85 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	database "github.com/dracory/database"
+)
+
+func TestAcquireConn(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	db.SetMaxOpenConns(2)
+
+	ctx := database.Context(context.Background(), db)
+
+	connCtx, release, err := database.AcquireConn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !connCtx.IsConn() {
+		t.Error("Expected the context to carry a connection")
+	}
+
+	// A temp table is only visible on the connection it was created on
+	if _, err := database.Execute(connCtx, "CREATE TEMP TABLE session_settings (name TEXT)"); err != nil {
+		t.Fatal(err)
+	}
+
+	for range 3 {
+		if _, err := database.Execute(connCtx, "INSERT INTO session_settings (name) VALUES ('pinned')"); err != nil {
+			t.Fatalf("Expected the statements to run on the same connection: %v", err)
+		}
+	}
+
+	count, err := database.Count(connCtx, "SELECT COUNT(*) FROM session_settings")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if count != 3 {
+		t.Errorf("Expected 3 rows, got %d", count)
+	}
+
+	if err := release(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Releasing twice is safe
+	if err := release(); err != nil {
+		t.Errorf("Expected a second release to be safe, got %v", err)
+	}
+
+	if _, err := database.Execute(connCtx, "SELECT 1"); err == nil {
+		t.Error("Expected an error after the connection was released")
+	}
+}
+
+func TestAcquireConnInvalid(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	if _, _, err := database.AcquireConn(database.Context(context.Background(), nil)); !errors.Is(err, database.ErrNoQueryable) {
+		t.Errorf("Expected ErrNoQueryable, got %v", err)
+	}
+
+	txCtx, err := database.Context(context.Background(), db).BeginTx(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer txCtx.Queryable().(interface{ Rollback() error }).Rollback()
+
+	if _, _, err := database.AcquireConn(txCtx); err == nil {
+		t.Error("Expected an error for a transaction context")
+	}
+}
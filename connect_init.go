@@ -0,0 +1,106 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+)
+
+// openWithInitStatements reopens the database opened with sql.Open,
+// so that the statements are executed on every new connection of the pool
+func openWithInitStatements(db *sql.DB, dsn string, statements []string) (*sql.DB, error) {
+	drv := db.Driver()
+
+	// No connections are opened yet, the database was only used to get the driver
+	_ = db.Close()
+
+	var connector driver.Connector = dsnConnector{dsn: dsn, driver: drv}
+
+	if driverCtx, ok := drv.(driver.DriverContext); ok {
+		var err error
+		if connector, err = driverCtx.OpenConnector(dsn); err != nil {
+			return nil, err
+		}
+	}
+
+	return sql.OpenDB(&initStatementsConnector{connector: connector, statements: statements}), nil
+}
+
+// dsnConnector is a driver.Connector for the drivers,
+// which do not implement driver.DriverContext
+type dsnConnector struct {
+	dsn    string
+	driver driver.Driver
+}
+
+func (c dsnConnector) Connect(context.Context) (driver.Conn, error) {
+	return c.driver.Open(c.dsn)
+}
+
+func (c dsnConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+// initStatementsConnector executes the statements on each connection
+// opened by the wrapped connector, before it is used by the pool
+type initStatementsConnector struct {
+	connector  driver.Connector
+	statements []string
+}
+
+func (c *initStatementsConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, statement := range c.statements {
+		if err := execDriverConn(ctx, conn, statement); err != nil {
+			_ = conn.Close()
+			return nil, errors.Join(errors.New("connect init statement failed: "+statement), err)
+		}
+	}
+
+	return conn, nil
+}
+
+func (c *initStatementsConnector) Driver() driver.Driver {
+	return c.connector.Driver()
+}
+
+// Close closes the wrapped connector, if it is closable, when the database is closed
+func (c *initStatementsConnector) Close() error {
+	if closer, ok := c.connector.(io.Closer); ok {
+		return closer.Close()
+	}
+
+	return nil
+}
+
+// execDriverConn executes the statement without arguments on the driver connection
+func execDriverConn(ctx context.Context, conn driver.Conn, statement string) error {
+	if execer, ok := conn.(driver.ExecerContext); ok {
+		_, err := execer.ExecContext(ctx, statement, nil)
+		if !errors.Is(err, driver.ErrSkip) {
+			return err
+		}
+	}
+
+	stmt, err := conn.Prepare(statement)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	if stmtCtx, ok := stmt.(driver.StmtExecContext); ok {
+		_, err = stmtCtx.ExecContext(ctx, nil)
+		return err
+	}
+
+	// Fallback for the drivers without StmtExecContext
+	_, err = stmt.Exec(nil)
+
+	return err
+}
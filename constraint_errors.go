@@ -0,0 +1,150 @@
+package database
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// constraintViolation describes how a constraint violation is reported
+// by each of the supported databases
+type constraintViolation struct {
+	// postgresSQLState is the SQLSTATE code returned by Postgres
+	postgresSQLState string
+
+	// mysqlNumbers are the error numbers returned by MySQL
+	mysqlNumbers []int
+
+	// sqliteCodes are the extended result codes returned by SQLite
+	sqliteCodes []int
+
+	// sqliteMessage is the error message prefix used by SQLite, for
+	// drivers which do not expose the extended result code
+	sqliteMessage string
+}
+
+var uniqueViolation = constraintViolation{
+	postgresSQLState: "23505",
+	mysqlNumbers:     []int{1062, 1586},
+	sqliteCodes:      []int{1555, 2067}, // SQLITE_CONSTRAINT_PRIMARYKEY, SQLITE_CONSTRAINT_UNIQUE
+	sqliteMessage:    "UNIQUE constraint failed",
+}
+
+var foreignKeyViolation = constraintViolation{
+	postgresSQLState: "23503",
+	mysqlNumbers:     []int{1216, 1217, 1451, 1452},
+	sqliteCodes:      []int{787}, // SQLITE_CONSTRAINT_FOREIGNKEY
+	sqliteMessage:    "FOREIGN KEY constraint failed",
+}
+
+var notNullViolation = constraintViolation{
+	postgresSQLState: "23502",
+	mysqlNumbers:     []int{1048, 1364},
+	sqliteCodes:      []int{1299}, // SQLITE_CONSTRAINT_NOTNULL
+	sqliteMessage:    "NOT NULL constraint failed",
+}
+
+// IsUniqueViolation checks if the error was returned because a statement
+// violated a unique (or primary key) constraint, i.e. a duplicate insert.
+//
+// The driver specific error codes of SQLite, MySQL and Postgres are
+// normalized, so that no driver package needs to be imported.
+//
+// Example usage:
+//
+//	_, err := database.Execute(ctx, "INSERT INTO users (email) VALUES (?)", email)
+//	if database.IsUniqueViolation(err, database.DatabaseType(db)) {
+//		return errors.New("user already exists")
+//	}
+//
+// Parameters:
+// - err (error): The error returned by the statement.
+// - dbType (string): The database type, i.e. DATABASE_TYPE_MYSQL.
+//
+// Returns:
+// - bool: True if the error is a unique constraint violation, false otherwise.
+func IsUniqueViolation(err error, dbType string) bool {
+	return uniqueViolation.matches(err, dbType)
+}
+
+// IsForeignKeyViolation checks if the error was returned because a statement
+// violated a foreign key constraint, i.e. inserting a row referencing
+// a missing parent, or deleting a parent which is still referenced.
+//
+// Parameters:
+// - err (error): The error returned by the statement.
+// - dbType (string): The database type, i.e. DATABASE_TYPE_MYSQL.
+//
+// Returns:
+// - bool: True if the error is a foreign key constraint violation, false otherwise.
+func IsForeignKeyViolation(err error, dbType string) bool {
+	return foreignKeyViolation.matches(err, dbType)
+}
+
+// IsNotNullViolation checks if the error was returned because a statement
+// set a NOT NULL column to NULL, or omitted it without a default.
+//
+// Parameters:
+// - err (error): The error returned by the statement.
+// - dbType (string): The database type, i.e. DATABASE_TYPE_MYSQL.
+//
+// Returns:
+// - bool: True if the error is a not null constraint violation, false otherwise.
+func IsNotNullViolation(err error, dbType string) bool {
+	return notNullViolation.matches(err, dbType)
+}
+
+// matches checks if the error is this constraint violation for the database type
+func (c constraintViolation) matches(err error, dbType string) bool {
+	if err == nil {
+		return false
+	}
+
+	switch dbType {
+	case DATABASE_TYPE_POSTGRES:
+		return errorSQLState(err) == c.postgresSQLState
+	case DATABASE_TYPE_MYSQL:
+		return slices.Contains(c.mysqlNumbers, errorNumber(err))
+	case DATABASE_TYPE_SQLITE:
+		if code := sqliteErrorCode(err); code != 0 {
+			return slices.Contains(c.sqliteCodes, code)
+		}
+		return strings.Contains(err.Error(), c.sqliteMessage)
+	}
+
+	return false
+}
+
+// sqliteErrorCode returns the extended result code of a SQLite error, or zero.
+//
+// The modernc.org/sqlite error exposes it through a Code() method, and the
+// mattn/go-sqlite3 error through a public ExtendedCode field. Reflection is
+// used for the latter so that the driver does not need to be imported.
+func sqliteErrorCode(err error) int {
+	var codeErr interface{ Code() int }
+
+	if errors.As(err, &codeErr) {
+		return codeErr.Code()
+	}
+
+	for ; err != nil; err = errors.Unwrap(err) {
+		v := reflect.ValueOf(err)
+
+		if v.Kind() == reflect.Pointer {
+			v = v.Elem()
+		}
+
+		if v.Kind() != reflect.Struct {
+			continue
+		}
+
+		extendedCode := v.FieldByName("ExtendedCode")
+
+		if extendedCode.IsValid() && extendedCode.CanInt() {
+			return int(extendedCode.Int())
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,126 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	database "github.com/dracory/database"
+)
+
+type postgresError struct {
+	code string
+}
+
+func (e *postgresError) Error() string    { return "pq: error " + e.code }
+func (e *postgresError) SQLState() string { return e.code }
+
+// mysqlError mimics the go-sql-driver/mysql error, which exposes
+// the error number through a public field
+type mysqlError struct {
+	Number  uint16
+	Message string
+}
+
+func (e *mysqlError) Error() string { return fmt.Sprintf("Error %d: %s", e.Number, e.Message) }
+
+func TestConstraintViolationsSqlite(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	ctx := database.Context(context.Background(), db)
+
+	_, err = database.Execute(ctx, "PRAGMA foreign_keys = ON")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = database.Execute(ctx, "CREATE TABLE users (id INTEGER PRIMARY KEY, email TEXT NOT NULL UNIQUE)")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = database.Execute(ctx, "CREATE TABLE posts (id INTEGER PRIMARY KEY, user_id INTEGER NOT NULL REFERENCES users(id))")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = database.Execute(ctx, "INSERT INTO users (id, email) VALUES (1, 'alice@example.com')")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dbType := database.DatabaseType(db)
+
+	_, err = database.Execute(ctx, "INSERT INTO users (email) VALUES ('alice@example.com')")
+	if !database.IsUniqueViolation(err, dbType) {
+		t.Errorf("Expected unique violation, got %v", err)
+	}
+
+	_, err = database.Execute(ctx, "INSERT INTO users (id, email) VALUES (1, 'bob@example.com')")
+	if !database.IsUniqueViolation(err, dbType) {
+		t.Errorf("Expected unique violation for duplicate primary key, got %v", err)
+	}
+
+	_, err = database.Execute(ctx, "INSERT INTO posts (user_id) VALUES (2)")
+	if !database.IsForeignKeyViolation(err, dbType) || database.IsUniqueViolation(err, dbType) {
+		t.Errorf("Expected foreign key violation only, got %v", err)
+	}
+
+	_, err = database.Execute(ctx, "INSERT INTO users (email) VALUES (NULL)")
+	if !database.IsNotNullViolation(err, dbType) || database.IsUniqueViolation(err, dbType) {
+		t.Errorf("Expected not null violation only, got %v", err)
+	}
+
+	if database.IsUniqueViolation(nil, dbType) {
+		t.Error("Expected nil error not to be a unique violation")
+	}
+}
+
+func TestConstraintViolationsPostgres(t *testing.T) {
+	err := fmt.Errorf("insert user: %w", &postgresError{code: "23505"})
+
+	if !database.IsUniqueViolation(err, database.DATABASE_TYPE_POSTGRES) {
+		t.Error("Expected wrapped 23505 to be a unique violation")
+	}
+
+	if database.IsUniqueViolation(err, database.DATABASE_TYPE_MYSQL) {
+		t.Error("Expected postgres error not to match for mysql")
+	}
+
+	if !database.IsForeignKeyViolation(&postgresError{code: "23503"}, database.DATABASE_TYPE_POSTGRES) {
+		t.Error("Expected 23503 to be a foreign key violation")
+	}
+
+	if !database.IsNotNullViolation(&postgresError{code: "23502"}, database.DATABASE_TYPE_POSTGRES) {
+		t.Error("Expected 23502 to be a not null violation")
+	}
+
+	if database.IsUniqueViolation(errors.New("duplicate key"), database.DATABASE_TYPE_POSTGRES) {
+		t.Error("Expected plain error not to be a unique violation")
+	}
+}
+
+func TestConstraintViolationsMysql(t *testing.T) {
+	err := fmt.Errorf("insert user: %w", &mysqlError{Number: 1062, Message: "Duplicate entry"})
+
+	if !database.IsUniqueViolation(err, database.DATABASE_TYPE_MYSQL) {
+		t.Error("Expected wrapped 1062 to be a unique violation")
+	}
+
+	if !database.IsForeignKeyViolation(&mysqlError{Number: 1452}, database.DATABASE_TYPE_MYSQL) {
+		t.Error("Expected 1452 to be a foreign key violation")
+	}
+
+	if !database.IsNotNullViolation(&mysqlError{Number: 1048}, database.DATABASE_TYPE_MYSQL) {
+		t.Error("Expected 1048 to be a not null violation")
+	}
+
+	if database.IsNotNullViolation(&mysqlError{Number: 1062}, database.DATABASE_TYPE_MYSQL) {
+		t.Error("Expected 1062 not to be a not null violation")
+	}
+}
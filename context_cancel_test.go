@@ -0,0 +1,96 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	database "github.com/dracory/database"
+)
+
+// longRunningSQL counts to a billion, which takes far longer than the tests
+const longRunningSQL = "WITH RECURSIVE c(x) AS (SELECT 1 UNION ALL SELECT x + 1 FROM c WHERE x < 1000000000) SELECT COUNT(*) FROM c"
+
+func TestCancelledContextShortCircuits(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	if err := createUserTableAndInserTesttData(db); err != nil {
+		t.Fatal(err)
+	}
+
+	cancelledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	ctx := database.Context(cancelledCtx, db)
+
+	if _, err := database.Execute(ctx, "DELETE FROM users"); !errors.Is(err, context.Canceled) {
+		t.Errorf("Execute: expected context.Canceled, got %v", err)
+	}
+
+	if _, err := database.Query(ctx, "SELECT * FROM users"); !errors.Is(err, context.Canceled) {
+		t.Errorf("Query: expected context.Canceled, got %v", err)
+	}
+
+	if _, err := database.SelectToMapString(ctx, "SELECT * FROM users"); !errors.Is(err, context.Canceled) {
+		t.Errorf("SelectToMapString: expected context.Canceled, got %v", err)
+	}
+
+	var name string
+	if err := database.QueryRow(ctx, "SELECT name FROM users WHERE id = 1").Scan(&name); !errors.Is(err, context.Canceled) {
+		t.Errorf("QueryRow: expected context.Canceled, got %v", err)
+	}
+
+	count, err := database.Count(database.Context(context.Background(), db), "SELECT COUNT(*) FROM users")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if count != 3 {
+		t.Errorf("Expected the users not to be deleted, got %d", count)
+	}
+}
+
+func TestCancelMidQuery(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	cancelCtx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	start := time.Now()
+
+	_, err = database.Count(database.Context(cancelCtx, db), longRunningSQL)
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected the query to be aborted, it took %v", elapsed)
+	}
+}
+
+func TestDeadlineMidExecute(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	deadlineCtx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err = database.Execute(database.Context(deadlineCtx, db), "CREATE TABLE numbers AS "+longRunningSQL)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+}
@@ -0,0 +1,74 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"strconv"
+)
+
+// Count executes a SQL query in the given context, which is expected to return
+// a single integer in a single row and column (i.e. SELECT COUNT(*) FROM users),
+// and returns it as int64.
+//
+// Example usage:
+//
+// count, err := Count(ctx, "SELECT COUNT(*) FROM users WHERE active = ?", 1)
+//
+// Parameters:
+// - ctx (QueryableContext): The context to use for the query execution.
+// - sqlStr (string): The SQL query to execute.
+// - args (any): Optional arguments to pass to the query.
+//
+// Returns:
+// - int64: The count.
+// - error: An error if the query failed, returned no rows, or more than one column.
+func Count(ctx QueryableContext, sqlStr string, args ...any) (int64, error) {
+	if ctx.queryable == nil {
+		return 0, ErrNoQueryable
+	}
+
+	rows, run, err := ctx.queryContext("Count", sqlStr, args...)
+
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	count, err := scanCount(rows)
+
+	if err != nil {
+		run.end(-1, err)
+		return 0, err
+	}
+
+	run.end(1, nil)
+
+	return count, nil
+}
+
+// scanCount scans the single integer of a count query
+func scanCount(rows *sql.Rows) (int64, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return 0, err
+	}
+
+	if len(columns) != 1 {
+		return 0, errors.New("count query must return 1 column, got " + strconv.Itoa(len(columns)))
+	}
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return 0, err
+		}
+		return 0, errors.New("count query returned no rows")
+	}
+
+	var count int64
+
+	if err := rows.Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, rows.Err()
+}
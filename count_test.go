@@ -0,0 +1,52 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	database "github.com/dracory/database"
+)
+
+func TestCount(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	err = createUserTableAndInserTesttData(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := database.Context(context.Background(), db)
+
+	// Test nil querier error
+	_, err = database.Count(database.Context(context.Background(), nil), "SELECT COUNT(*) FROM users")
+	if err == nil {
+		t.Error("Expected error for nil querier")
+	} else if err.Error() != "querier (db/tx/conn) is nil" {
+		t.Errorf("Unexpected error message: %v", err)
+	}
+
+	// Test successful count
+	count, err := database.Count(ctx, "SELECT COUNT(*) FROM users WHERE id > ?", 1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("Expected count 2, got %d", count)
+	}
+
+	// Test more than one column
+	_, err = database.Count(ctx, "SELECT COUNT(*), MAX(id) FROM users")
+	if err == nil {
+		t.Error("Expected error for more than one column")
+	}
+
+	// Test no rows
+	_, err = database.Count(ctx, "SELECT id FROM users WHERE id = ?", 100)
+	if err == nil {
+		t.Error("Expected error for no rows")
+	}
+}
@@ -2,6 +2,7 @@ package database
 
 import (
 	"database/sql"
+	"errors"
 	"reflect"
 	"strings"
 	"unsafe"
@@ -16,6 +17,8 @@ import (
 //   - "sqlite" for SQLite
 //   - "mssql" for Microsoft SQL Server
 //   - the full name of the driver otherwise
+//   - the result of its DatabaseType method for other queryables (i.e. MockQueryable),
+//     or an empty string if it has none
 //
 // The function is useful when you want to find the type of the database,
 // without knowing it during compilation.
@@ -31,6 +34,11 @@ import (
 //
 // #nosec G103 - we use unsafe deliberately to get private fields of sql.Tx and sql.Conn
 func DatabaseType(q QueryableInterface) string {
+	// Queryables other than DB, Tx and Conn (i.e. MockQueryable) report their type
+	if typed, ok := q.(interface{ DatabaseType() string }); ok {
+		return typed.DatabaseType()
+	}
+
 	var db *sql.DB
 
 	// check if q is sql.DB or sql.Tx or sql.Conn
@@ -56,6 +64,10 @@ func DatabaseType(q QueryableInterface) string {
 		db = dbAny.(*sql.DB)
 	}
 
+	if db == nil {
+		return ""
+	}
+
 	driverFullName := reflect.ValueOf(db.Driver()).Type().String()
 
 	if strings.Contains(driverFullName, DATABASE_TYPE_MYSQL) {
@@ -76,3 +88,41 @@ func DatabaseType(q QueryableInterface) string {
 
 	return driverFullName
 }
+
+// ParseDatabaseType normalizes a database type from a string,
+// i.e. read from a config file, to one of the DATABASE_TYPE_* constants.
+//
+// The comparison is case insensitive, and the following aliases are accepted:
+//
+//   - "postgres", "postgresql", "pg", "pq" for DATABASE_TYPE_POSTGRES
+//   - "pgx" for DATABASE_TYPE_PGX
+//   - "mysql", "mariadb" for DATABASE_TYPE_MYSQL
+//   - "sqlite", "sqlite3" for DATABASE_TYPE_SQLITE
+//   - "mssql", "sqlserver" for DATABASE_TYPE_MSSQL
+//
+// Example usage:
+//
+//	databaseType, err := database.ParseDatabaseType(config.DbDriver)
+//
+// Parameters:
+// - s string: the database type to parse
+//
+// Returns:
+// - string: the database type constant
+// - error: an error if the database type is unknown
+func ParseDatabaseType(s string) (string, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case DATABASE_TYPE_POSTGRES, "postgresql", "pg", "pq":
+		return DATABASE_TYPE_POSTGRES, nil
+	case DATABASE_TYPE_PGX:
+		return DATABASE_TYPE_PGX, nil
+	case DATABASE_TYPE_MYSQL, "mariadb":
+		return DATABASE_TYPE_MYSQL, nil
+	case DATABASE_TYPE_SQLITE, "sqlite3":
+		return DATABASE_TYPE_SQLITE, nil
+	case DATABASE_TYPE_MSSQL, "sqlserver":
+		return DATABASE_TYPE_MSSQL, nil
+	}
+
+	return "", errors.New(`unknown database type: ` + s)
+}
@@ -93,3 +93,32 @@ func TestDatabaseTypeFromConn(t *testing.T) {
 		t.Fatalf("Expected Debug [%v], received [%v]", "sqlite", dbType)
 	}
 }
+
+func TestParseDatabaseType(t *testing.T) {
+	tests := map[string]string{
+		"postgres":   database.DATABASE_TYPE_POSTGRES,
+		"PostgreSQL": database.DATABASE_TYPE_POSTGRES,
+		"pg":         database.DATABASE_TYPE_POSTGRES,
+		"pgx":        database.DATABASE_TYPE_PGX,
+		" mysql ":    database.DATABASE_TYPE_MYSQL,
+		"mariadb":    database.DATABASE_TYPE_MYSQL,
+		"sqlite3":    database.DATABASE_TYPE_SQLITE,
+		"sqlite":     database.DATABASE_TYPE_SQLITE,
+		"sqlserver":  database.DATABASE_TYPE_MSSQL,
+		"mssql":      database.DATABASE_TYPE_MSSQL,
+	}
+
+	for input, expected := range tests {
+		result, err := database.ParseDatabaseType(input)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", input, err)
+		}
+		if result != expected {
+			t.Errorf("%q: expected %q, got %q", input, expected, result)
+		}
+	}
+
+	if _, err := database.ParseDatabaseType("oracle"); err == nil {
+		t.Error("Expected an error for an unknown database type")
+	}
+}
@@ -0,0 +1,83 @@
+package database
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// debugEnabled enables capturing the last executed query per context
+var debugEnabled atomic.Bool
+
+// SetDebug enables or disables the debug mode, in which the contexts
+// created with NewQueryableContext (or Context) capture the SQL and arguments
+// of the last statement executed with them, so that it can be logged
+// with LastQuery, i.e. when a call fails.
+//
+// Only the contexts created while the debug mode is enabled capture
+// the statements. The contexts derived from them (i.e. with WithValue,
+// or the transaction contexts of BeginTx) share the captured statement.
+// It is safe for concurrent use.
+//
+// Example:
+//
+//	database.SetDebug(true)
+//
+//	qCtx := database.Context(ctx, db)
+//	if _, err := database.Execute(qCtx, "UPDATE users SET name = ? WHERE id = ?", name, id); err != nil {
+//		sqlStr, args := qCtx.LastQuery()
+//		slog.Error("update failed", "sql", sqlStr, "args", args, "error", err)
+//	}
+//
+// Parameters:
+// - enabled: True to enable the debug mode, false to disable it.
+func SetDebug(enabled bool) {
+	debugEnabled.Store(enabled)
+}
+
+// lastQuery holds the last statement executed with a context
+type lastQuery struct {
+	mu     sync.Mutex
+	sqlStr string
+	args   []any
+}
+
+// newLastQuery returns a holder for the last query if the debug mode
+// is enabled, or nil otherwise
+func newLastQuery() *lastQuery {
+	if !debugEnabled.Load() {
+		return nil
+	}
+
+	return &lastQuery{}
+}
+
+// LastQuery returns the SQL and the arguments of the last statement executed
+// with the context, as sent to the database (i.e. after rebinding).
+//
+// It returns an empty SQL and nil arguments if the context was created
+// while the debug mode was disabled (see SetDebug), or if no statement
+// has been executed with it yet.
+func (ctx QueryableContext) LastQuery() (string, []any) {
+	if ctx.lastQuery == nil {
+		return "", nil
+	}
+
+	ctx.lastQuery.mu.Lock()
+	defer ctx.lastQuery.mu.Unlock()
+
+	return ctx.lastQuery.sqlStr, ctx.lastQuery.args
+}
+
+// recordQuery captures the statement as the last query of the context,
+// if the debug mode is enabled
+func (ctx QueryableContext) recordQuery(sqlStr string, args []any) {
+	if ctx.lastQuery == nil || !debugEnabled.Load() {
+		return
+	}
+
+	ctx.lastQuery.mu.Lock()
+	defer ctx.lastQuery.mu.Unlock()
+
+	ctx.lastQuery.sqlStr = sqlStr
+	ctx.lastQuery.args = args
+}
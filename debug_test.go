@@ -0,0 +1,77 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	database "github.com/dracory/database"
+)
+
+func TestDebugLastQuery(t *testing.T) {
+	database.SetDebug(true)
+	defer database.SetDebug(false)
+
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	if err := createUserTableAndInserTesttData(db); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := database.Context(context.Background(), db)
+
+	if sqlStr, args := ctx.LastQuery(); sqlStr != "" || args != nil {
+		t.Errorf("Expected no last query yet, got %q %v", sqlStr, args)
+	}
+
+	if _, err := database.SelectToMapString(ctx, "SELECT * FROM users WHERE id = ?", 1); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = database.Execute(ctx, "UPDATE unknown_table SET name = ? WHERE id = ?", "Dave", 2)
+	if err == nil {
+		t.Fatal("Expected an error for an unknown table")
+	}
+
+	sqlStr, args := ctx.LastQuery()
+
+	if sqlStr != "UPDATE unknown_table SET name = ? WHERE id = ?" {
+		t.Errorf("Expected the failed statement, got %q", sqlStr)
+	}
+
+	if len(args) != 2 || args[0] != "Dave" || args[1] != 2 {
+		t.Errorf("Expected the arguments of the failed statement, got %v", args)
+	}
+
+	// Derived contexts share the last query
+	derived := ctx.WithValue(struct{}{}, "value")
+
+	if _, err := database.Count(derived, "SELECT COUNT(*) FROM users"); err != nil {
+		t.Fatal(err)
+	}
+
+	if sqlStr, _ := ctx.LastQuery(); sqlStr != "SELECT COUNT(*) FROM users" {
+		t.Errorf("Expected the statement of the derived context, got %q", sqlStr)
+	}
+}
+
+func TestDebugDisabled(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	ctx := database.Context(context.Background(), db)
+
+	if _, err := database.Execute(ctx, "SELECT 1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if sqlStr, args := ctx.LastQuery(); sqlStr != "" || args != nil {
+		t.Errorf("Expected no last query without debug mode, got %q %v", sqlStr, args)
+	}
+}
@@ -0,0 +1,49 @@
+package database
+
+// DeleteByPK deletes the row of the table with the given primary key,
+// and reports whether a row was deleted.
+//
+// The statement is DELETE FROM table WHERE pk = ?, with the table and
+// the primary key column quoted, and the placeholder of the database type.
+//
+// Example usage:
+//
+//	deleted, err := DeleteByPK(ctx, "users", "id", 1)
+//	if err != nil {
+//		return err
+//	}
+//	if !deleted {
+//		return errors.New("user not found")
+//	}
+//
+// Parameters:
+// - ctx (QueryableContext): The context to use for the query execution.
+// - table (string): The name of the table.
+// - pkColumn (string): The name of the primary key column.
+// - pk (any): The value of the primary key.
+//
+// Returns:
+// - bool: True if a row was deleted, false otherwise.
+// - error: An error if a name is invalid, or the statement failed.
+func DeleteByPK(ctx QueryableContext, table string, pkColumn string, pk any) (bool, error) {
+	if ctx.queryable == nil {
+		return false, ErrNoQueryable
+	}
+
+	quotedPK, err := quoteIdentifier(ctx.databaseType(), pkColumn)
+	if err != nil {
+		return false, err
+	}
+
+	result, err := DeleteFrom(table).Where(quotedPK+" = ?", pk).Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
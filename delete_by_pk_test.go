@@ -0,0 +1,74 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	database "github.com/dracory/database"
+)
+
+func TestDeleteByPK(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	if _, err := database.DeleteByPK(database.Context(context.Background(), nil), "users", "id", 1); !errors.Is(err, database.ErrNoQueryable) {
+		t.Errorf("Expected ErrNoQueryable, got %v", err)
+	}
+
+	if err := createUserTableAndInserTesttData(db); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := database.Context(context.Background(), db)
+
+	deleted, err := database.DeleteByPK(ctx, "users", "id", 2)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !deleted {
+		t.Error("Expected the row to be deleted")
+	}
+
+	deleted, err = database.DeleteByPK(ctx, "users", "id", 2)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if deleted {
+		t.Error("Expected no row to be deleted")
+	}
+
+	count, err := database.Count(ctx, "SELECT COUNT(*) FROM users")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if count != 2 {
+		t.Errorf("Expected 2 users left, got %d", count)
+	}
+
+	if _, err := database.DeleteByPK(ctx, "users", "", 1); err == nil {
+		t.Error("Expected an error for an empty primary key column")
+	}
+}
+
+func TestDeleteByPKQuoting(t *testing.T) {
+	mock := database.NewMockQueryable().SetDatabaseType(database.DATABASE_TYPE_MSSQL)
+	defer mock.Close()
+
+	mock.ExpectExec(`DELETE FROM [order] WHERE [key] = @p1`).
+		WithArgs("abc").
+		WillReturnResult(0, 1)
+
+	deleted, err := database.DeleteByPK(database.Context(context.Background(), mock), "order", "key", "abc")
+	if err != nil || !deleted {
+		t.Errorf("Expected the row to be deleted, got %v and %v", deleted, err)
+	}
+
+	mock.AssertExpectations(t)
+}
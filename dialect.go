@@ -0,0 +1,241 @@
+package database
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Dialect describes the SQL differences of a database type, so that
+// the helpers of the package (i.e. Rebind, Upsert, BulkInsert) can build
+// statements without checking the database type themselves.
+//
+// Use DialectFor to get the dialect of a database type.
+type Dialect interface {
+	// Name returns the database type of the dialect, i.e. DATABASE_TYPE_POSTGRES
+	Name() string
+
+	// Placeholder returns the n-th (starting from 1) placeholder of a statement,
+	// i.e. ? for MySQL and SQLite, $1 for Postgres, @p1 for MSSQL
+	Placeholder(n int) string
+
+	// QuoteIdentifier quotes a single table or column name, escaping the
+	// quote characters inside it, i.e. "name" for Postgres and SQLite,
+	// `name` for MySQL, [name] for MSSQL
+	QuoteIdentifier(name string) string
+
+	// Now returns the SQL expression for the current date and time,
+	// see NowExpr for its time zone on each database type
+	Now() string
+
+	// MaxPlaceholders returns the maximum number of placeholders
+	// allowed in a single statement
+	MaxPlaceholders() int
+
+	// LimitOffset returns the clause to append to a query to return
+	// at most limit rows, skipping the first offset rows,
+	// i.e. LIMIT 10 OFFSET 20, or OFFSET 20 ROWS FETCH NEXT 10 ROWS ONLY for MSSQL
+	LimitOffset(limit int, offset int) string
+}
+
+// DialectFor returns the dialect of the given database type,
+// as returned by DatabaseType or ParseDatabaseType.
+//
+// The pgx driver uses the Postgres dialect. For unknown database types
+// a generic dialect is returned, which uses ? placeholders and "name" quoting.
+//
+// Example usage:
+//
+//	dialect := database.DialectFor(database.DatabaseType(db))
+//	sqlStr := "SELECT * FROM " + dialect.QuoteIdentifier("user") + " WHERE id = " + dialect.Placeholder(1)
+//
+// Parameters:
+// - dbType (string): The database type, i.e. DATABASE_TYPE_POSTGRES.
+//
+// Returns:
+// - Dialect: The dialect of the database type.
+func DialectFor(dbType string) Dialect {
+	switch strings.ToLower(dbType) {
+	case DATABASE_TYPE_POSTGRES, DATABASE_TYPE_PGX:
+		return postgresDialect{}
+	case DATABASE_TYPE_MYSQL:
+		return mysqlDialect{}
+	case DATABASE_TYPE_SQLITE:
+		return sqliteDialect{}
+	case DATABASE_TYPE_MSSQL:
+		return mssqlDialect{}
+	}
+
+	return genericDialect{name: dbType}
+}
+
+// NowExpr returns the SQL expression for the current date and time
+// of the database type, the same as DialectFor(dbType).Now(), i.e. to set
+// a timestamp column in a statement built by hand.
+//
+// The time zone of the current time differs between the database types:
+//   - SQLite: CURRENT_TIMESTAMP, always in UTC, as "2006-01-02 15:04:05" text
+//   - Postgres: NOW(), a timestamptz (the start of the transaction), which
+//     is stored in UTC, and converted to the session TimeZone when read
+//   - MySQL: NOW(), in the session time_zone, which is the server time zone
+//     unless set (see SetTimeZone), use UTC_TIMESTAMP() for UTC
+//   - MSSQL: SYSDATETIME(), in the local time zone of the server,
+//     use SYSUTCDATETIME() for UTC
+//   - other database types: CURRENT_TIMESTAMP
+//
+// Example usage:
+//
+//	sqlStr := "UPDATE users SET updated_at = " + database.NowExpr(dbType) + " WHERE id = ?"
+//
+// Parameters:
+// - dbType (string): The database type, i.e. DATABASE_TYPE_POSTGRES.
+//
+// Returns:
+// - string: The SQL expression for the current date and time.
+func NowExpr(dbType string) string {
+	return DialectFor(dbType).Now()
+}
+
+// genericDialect is the dialect of unknown database types
+type genericDialect struct {
+	name string
+}
+
+func (d genericDialect) Name() string {
+	return d.name
+}
+
+func (genericDialect) Placeholder(n int) string {
+	return "?"
+}
+
+func (genericDialect) QuoteIdentifier(name string) string {
+	return quoteWith(name, `"`, `"`)
+}
+
+func (genericDialect) Now() string {
+	return "CURRENT_TIMESTAMP"
+}
+
+func (genericDialect) LimitOffset(limit int, offset int) string {
+	return limitOffset(limit, offset)
+}
+
+func (genericDialect) MaxPlaceholders() int {
+	return 999
+}
+
+type sqliteDialect struct{}
+
+func (sqliteDialect) Name() string {
+	return DATABASE_TYPE_SQLITE
+}
+
+func (sqliteDialect) Placeholder(n int) string {
+	return "?"
+}
+
+func (sqliteDialect) QuoteIdentifier(name string) string {
+	return quoteWith(name, `"`, `"`)
+}
+
+func (sqliteDialect) Now() string {
+	return "CURRENT_TIMESTAMP"
+}
+
+func (sqliteDialect) LimitOffset(limit int, offset int) string {
+	return limitOffset(limit, offset)
+}
+
+func (sqliteDialect) MaxPlaceholders() int {
+	return 32766
+}
+
+type mysqlDialect struct{}
+
+func (mysqlDialect) Name() string {
+	return DATABASE_TYPE_MYSQL
+}
+
+func (mysqlDialect) Placeholder(n int) string {
+	return "?"
+}
+
+func (mysqlDialect) QuoteIdentifier(name string) string {
+	return quoteWith(name, "`", "`")
+}
+
+func (mysqlDialect) Now() string {
+	return "NOW()"
+}
+
+func (mysqlDialect) LimitOffset(limit int, offset int) string {
+	return limitOffset(limit, offset)
+}
+
+func (mysqlDialect) MaxPlaceholders() int {
+	return 65535
+}
+
+type postgresDialect struct{}
+
+func (postgresDialect) Name() string {
+	return DATABASE_TYPE_POSTGRES
+}
+
+func (postgresDialect) Placeholder(n int) string {
+	return "$" + strconv.Itoa(n)
+}
+
+func (postgresDialect) QuoteIdentifier(name string) string {
+	return quoteWith(name, `"`, `"`)
+}
+
+func (postgresDialect) Now() string {
+	return "NOW()"
+}
+
+func (postgresDialect) LimitOffset(limit int, offset int) string {
+	return limitOffset(limit, offset)
+}
+
+func (postgresDialect) MaxPlaceholders() int {
+	return 65535
+}
+
+type mssqlDialect struct{}
+
+func (mssqlDialect) Name() string {
+	return DATABASE_TYPE_MSSQL
+}
+
+func (mssqlDialect) Placeholder(n int) string {
+	return "@p" + strconv.Itoa(n)
+}
+
+func (mssqlDialect) QuoteIdentifier(name string) string {
+	return quoteWith(name, "[", "]")
+}
+
+func (mssqlDialect) Now() string {
+	return "SYSDATETIME()"
+}
+
+func (mssqlDialect) LimitOffset(limit int, offset int) string {
+	// Requires an ORDER BY clause in the query
+	return "OFFSET " + strconv.Itoa(offset) + " ROWS FETCH NEXT " + strconv.Itoa(limit) + " ROWS ONLY"
+}
+
+func (mssqlDialect) MaxPlaceholders() int {
+	return 2100
+}
+
+// limitOffset returns the LIMIT ... OFFSET ... clause
+func limitOffset(limit int, offset int) string {
+	return "LIMIT " + strconv.Itoa(limit) + " OFFSET " + strconv.Itoa(offset)
+}
+
+// quoteWith wraps the name in the open and close quotes,
+// escaping the close quotes inside it by doubling them
+func quoteWith(name string, open string, close string) string {
+	return open + strings.ReplaceAll(name, close, close+close) + close
+}
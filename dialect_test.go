@@ -0,0 +1,52 @@
+package database_test
+
+import (
+	"testing"
+
+	database "github.com/dracory/database"
+)
+
+func TestDialectFor(t *testing.T) {
+	tests := []struct {
+		dbType      string
+		name        string
+		placeholder string
+		quoted      string
+		now         string
+	}{
+		{database.DATABASE_TYPE_POSTGRES, database.DATABASE_TYPE_POSTGRES, "$2", `"my""table"`, "NOW()"},
+		{database.DATABASE_TYPE_PGX, database.DATABASE_TYPE_POSTGRES, "$2", `"my""table"`, "NOW()"},
+		{database.DATABASE_TYPE_MYSQL, database.DATABASE_TYPE_MYSQL, "?", "`my\"table`", "NOW()"},
+		{database.DATABASE_TYPE_SQLITE, database.DATABASE_TYPE_SQLITE, "?", `"my""table"`, "CURRENT_TIMESTAMP"},
+		{database.DATABASE_TYPE_MSSQL, database.DATABASE_TYPE_MSSQL, "@p2", `[my"table]`, "SYSDATETIME()"},
+		{"oracle", "oracle", "?", `"my""table"`, "CURRENT_TIMESTAMP"},
+	}
+
+	for _, test := range tests {
+		dialect := database.DialectFor(test.dbType)
+
+		if dialect.Name() != test.name {
+			t.Errorf("%s: expected name %q, got %q", test.dbType, test.name, dialect.Name())
+		}
+
+		if dialect.Placeholder(2) != test.placeholder {
+			t.Errorf("%s: expected placeholder %q, got %q", test.dbType, test.placeholder, dialect.Placeholder(2))
+		}
+
+		if dialect.QuoteIdentifier(`my"table`) != test.quoted {
+			t.Errorf("%s: expected quoted %q, got %q", test.dbType, test.quoted, dialect.QuoteIdentifier(`my"table`))
+		}
+
+		if dialect.Now() != test.now {
+			t.Errorf("%s: expected now %q, got %q", test.dbType, test.now, dialect.Now())
+		}
+
+		if database.NowExpr(test.dbType) != test.now {
+			t.Errorf("%s: expected NowExpr %q, got %q", test.dbType, test.now, database.NowExpr(test.dbType))
+		}
+
+		if dialect.MaxPlaceholders() <= 0 {
+			t.Errorf("%s: expected a positive placeholder limit", test.dbType)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"slices"
+	"strings"
+)
+
+// DatabaseTypeInfo describes a database type supported by Open.
+type DatabaseTypeInfo struct {
+	// Type is the DATABASE_TYPE_* constant, i.e. "postgres"
+	Type string
+
+	// Name is the human readable name, i.e. "PostgreSQL (lib/pq)"
+	Name string
+
+	// DriverName is the name the driver is registered with in database/sql
+	DriverName string
+
+	// ImportPath is the package to import for registering the driver
+	ImportPath string
+}
+
+// IsRegistered checks if the driver of the database type is registered,
+// i.e. its package is imported, so it can be opened.
+func (info DatabaseTypeInfo) IsRegistered() bool {
+	return slices.Contains(sql.Drivers(), info.DriverName)
+}
+
+// supportedDatabaseTypes are the database types supported by Open
+var supportedDatabaseTypes = []DatabaseTypeInfo{
+	{Type: DATABASE_TYPE_SQLITE, Name: "SQLite", DriverName: DATABASE_TYPE_SQLITE, ImportPath: "modernc.org/sqlite"},
+	{Type: DATABASE_TYPE_MYSQL, Name: "MySQL", DriverName: DATABASE_TYPE_MYSQL, ImportPath: "github.com/go-sql-driver/mysql"},
+	{Type: DATABASE_TYPE_POSTGRES, Name: "PostgreSQL (lib/pq)", DriverName: DATABASE_TYPE_POSTGRES, ImportPath: "github.com/lib/pq"},
+	{Type: DATABASE_TYPE_PGX, Name: "PostgreSQL (pgx)", DriverName: DATABASE_TYPE_PGX, ImportPath: "github.com/jackc/pgx/v5/stdlib"},
+	{Type: DATABASE_TYPE_MSSQL, Name: "Microsoft SQL Server", DriverName: DATABASE_TYPE_MSSQL, ImportPath: "github.com/microsoft/go-mssqldb"},
+}
+
+// SupportedDatabaseTypes returns the database types supported by Open,
+// with the driver name and the import path of each, i.e. for presenting
+// the choices in an admin UI, or validating a config.
+//
+// Example:
+//
+//	for _, info := range database.SupportedDatabaseTypes() {
+//		fmt.Println(info.Name, info.Type, info.IsRegistered())
+//	}
+//
+// Returns:
+// - []DatabaseTypeInfo: The supported database types.
+func SupportedDatabaseTypes() []DatabaseTypeInfo {
+	return slices.Clone(supportedDatabaseTypes)
+}
+
+// findDatabaseTypeInfo returns the info of the database type,
+// compared case insensitively, and false if it is not supported
+func findDatabaseTypeInfo(databaseType string) (DatabaseTypeInfo, bool) {
+	for _, info := range supportedDatabaseTypes {
+		if strings.EqualFold(info.Type, databaseType) {
+			return info, true
+		}
+	}
+
+	return DatabaseTypeInfo{}, false
+}
+
+// checkDriverRegistered returns an actionable error naming the import
+// needed, if the driver of the database type is not registered,
+// instead of the "unknown driver" error of sql.Open
+func checkDriverRegistered(databaseType string) error {
+	if slices.Contains(sql.Drivers(), databaseType) {
+		return nil
+	}
+
+	msg := `driver ` + databaseType + ` is not registered.`
+
+	if info, ok := findDatabaseTypeInfo(databaseType); ok {
+		msg += ` Add the following import: _ "` + info.ImportPath + `"`
+	}
+
+	return errors.New(msg)
+}
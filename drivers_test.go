@@ -0,0 +1,47 @@
+package database_test
+
+import (
+	"testing"
+
+	database "github.com/dracory/database"
+)
+
+func TestSupportedDatabaseTypes(t *testing.T) {
+	infos := database.SupportedDatabaseTypes()
+
+	if len(infos) != 5 {
+		t.Fatalf("Expected 5 database types, got %d", len(infos))
+	}
+
+	for _, info := range infos {
+		if info.Type == "" || info.Name == "" || info.DriverName == "" || info.ImportPath == "" {
+			t.Errorf("Expected all the fields to be set, got %+v", info)
+		}
+
+		// Each supported type passes the options verification
+		err := database.Options().
+			SetDatabaseType(info.Type).
+			SetDatabaseHost("localhost").
+			SetDatabasePort("1234").
+			SetDatabaseName("test_db").
+			SetUserName("user").
+			SetPassword("secret").
+			Verify()
+
+		if err != nil {
+			t.Errorf("Expected %s to be supported, got %v", info.Type, err)
+		}
+
+		// Only the sqlite driver is imported by the tests
+		if info.IsRegistered() != (info.Type == database.DATABASE_TYPE_SQLITE) {
+			t.Errorf("Unexpected registration of %s: %v", info.Type, info.IsRegistered())
+		}
+	}
+
+	// The returned slice is a copy
+	infos[0].Name = "changed"
+
+	if database.SupportedDatabaseTypes()[0].Name == "changed" {
+		t.Error("Expected a copy of the database types")
+	}
+}
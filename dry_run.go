@@ -0,0 +1,47 @@
+package database
+
+// WithDryRun returns a copy of the context, in which the statements
+// that write (Execute, Insert, Upsert, the Insert, Update and Delete
+// builders, etc.) are not sent to the database, i.e. to review the writes
+// of a job in staging or for an audit.
+//
+// The skipped statements are still reported to the logger and the hooks
+// (see SetLogger), with QueryLog.DryRun set, and return a result with
+// zero rows affected and a zero id. The reads are executed normally.
+//
+// Example:
+//
+//	database.SetLogger(func(info database.QueryLog) {
+//		if info.DryRun {
+//			slog.Info("dry run", "sql", info.SQL, "args", info.Args)
+//		}
+//	})
+//
+//	qCtx := database.Context(ctx, db).WithDryRun(true)
+//	_, err := database.Execute(qCtx, "DELETE FROM sessions WHERE expires_at < ?", time.Now())
+//
+// Parameters:
+// - enabled: True to skip the writes, false to execute them.
+//
+// Returns:
+// - QueryableContext: A new context with the dry run mode.
+func (ctx QueryableContext) WithDryRun(enabled bool) QueryableContext {
+	ctx.dryRun = enabled
+	return ctx
+}
+
+// IsDryRun checks if the writes are skipped in the context (see WithDryRun).
+func (ctx QueryableContext) IsDryRun() bool {
+	return ctx.dryRun
+}
+
+// dryRunResult is the result of a statement skipped in dry run mode
+type dryRunResult struct{}
+
+func (dryRunResult) LastInsertId() (int64, error) {
+	return 0, nil
+}
+
+func (dryRunResult) RowsAffected() (int64, error) {
+	return 0, nil
+}
@@ -0,0 +1,99 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	database "github.com/dracory/database"
+)
+
+func TestWithDryRun(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	if err := createUserTableAndInserTesttData(db); err != nil {
+		t.Fatal(err)
+	}
+
+	logs := []database.QueryLog{}
+	database.SetLogger(func(info database.QueryLog) {
+		logs = append(logs, info)
+	})
+	defer database.SetLogger(nil)
+
+	ctx := database.Context(context.Background(), db)
+
+	if ctx.IsDryRun() {
+		t.Fatal("Expected the dry run mode to be disabled by default")
+	}
+
+	dryCtx := ctx.WithDryRun(true)
+
+	if !dryCtx.IsDryRun() {
+		t.Fatal("Expected the dry run mode to be enabled")
+	}
+
+	result, err := database.Execute(dryCtx, "UPDATE users SET name = ? WHERE id = ?", "Updated", 1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if affected, _ := result.RowsAffected(); affected != 0 {
+		t.Errorf("Expected zero rows affected, got %d", affected)
+	}
+
+	id, err := database.Insert(dryCtx, "users", map[string]any{"name": "Dave"})
+	if err != nil || id != 0 {
+		t.Errorf("Expected a zero id without error, got %d and %v", id, err)
+	}
+
+	if _, err := database.DeleteFrom("users").Where("id = ?", 2).Exec(dryCtx); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	rows, err := database.ExecuteReturning(dryCtx, "DELETE FROM users RETURNING id")
+	if err != nil || len(rows) != 0 {
+		t.Errorf("Expected no returned rows without error, got %d and %v", len(rows), err)
+	}
+
+	// The reads are executed
+	names, err := database.SelectToMapString(dryCtx, "SELECT name FROM users ORDER BY id")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(names) != 3 || names[0]["name"] != "Alice" {
+		t.Errorf("Expected the writes to be skipped, got %v", names)
+	}
+
+	if len(logs) != 5 {
+		t.Fatalf("Expected 5 logs, got %d", len(logs))
+	}
+
+	for _, log := range logs[:4] {
+		if !log.DryRun {
+			t.Errorf("Expected the write to be logged as a dry run: %+v", log)
+		}
+	}
+
+	if logs[0].SQL != "UPDATE users SET name = ? WHERE id = ?" || len(logs[0].Args) != 2 {
+		t.Errorf("Unexpected log of the skipped write: %+v", logs[0])
+	}
+
+	// Disabling the dry run mode executes the writes again
+	if _, err := database.Execute(dryCtx.WithDryRun(false), "UPDATE users SET name = ? WHERE id = ?", "Updated", 1); err != nil {
+		t.Fatal(err)
+	}
+
+	name, err := database.Scalar[string](ctx, "SELECT name FROM users WHERE id = 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if name != "Updated" {
+		t.Errorf("Expected the write to be executed, got %q", name)
+	}
+}
@@ -3,6 +3,9 @@ package database
 import (
 	"database/sql"
 	"errors"
+	"fmt"
+	"strconv"
+	"strings"
 )
 
 // Execute executes a SQL query in the given context and returns a sql.Result
@@ -27,11 +30,237 @@ import (
 func Execute(ctx QueryableContext, sqlStr string, args ...any) (sql.Result, error) {
 	// Check if the querier is nil
 	if ctx.queryable == nil {
-		return nil, errors.New("querier (db/tx/conn) is nil")
+		return nil, ErrNoQueryable
 	}
 
 	ctx = NewQueryableContextOr(ctx, ctx.queryable)
 
 	// Execute the query
-	return ctx.queryable.ExecContext(ctx, sqlStr, args...)
+	return ctx.execContext("Execute", sqlStr, args...)
+}
+
+// ExecuteReturning executes a statement which returns rows, i.e.
+// UPDATE ... RETURNING on Postgres and SQLite, or UPDATE ... OUTPUT on MSSQL,
+// and returns the rows like SelectToMapAny. This updates and fetches
+// the rows in a single round trip, without a separate SELECT.
+//
+// If the statement returns no rows, the function returns an empty slice.
+//
+// Example usage:
+//
+//	rows, err := ExecuteReturning(ctx, "UPDATE users SET active = 0 WHERE last_login < $1 RETURNING id, email", cutoff)
+//
+// Parameters:
+// - ctx (QueryableContext): The context to use for the statement execution.
+// - sqlStr (string): The SQL statement to execute.
+// - args (any): Optional arguments to pass to the statement.
+//
+// Returns:
+// - []map[string]any: A slice of maps containing the returned rows.
+// - error: An error if the statement failed.
+func ExecuteReturning(ctx QueryableContext, sqlStr string, args ...any) ([]map[string]any, error) {
+	// In dry run mode the statement is not executed, so no rows are returned
+	if ctx.queryable != nil && ctx.dryRun {
+		_, err := ctx.execContext("ExecuteReturning", sqlStr, args...)
+		return []map[string]any{}, err
+	}
+
+	return selectToMapAny(ctx, "ExecuteReturning", SelectToMapAnyOptions{}, sqlStr, args...)
+}
+
+// StatementError is returned by ExecuteMany when one of the statements fails.
+type StatementError struct {
+	// Index is the zero based index of the failed statement
+	Index int
+
+	// SQL is the failed statement
+	SQL string
+
+	// Err is the error returned by the database
+	Err error
+}
+
+// Error returns the message of the error, with the index and SQL of the statement.
+func (e *StatementError) Error() string {
+	return "statement " + strconv.Itoa(e.Index) + " failed: " + e.SQL + ": " + e.Err.Error()
+}
+
+// Unwrap returns the error returned by the database.
+func (e *StatementError) Unwrap() error {
+	return e.Err
+}
+
+// ExecuteMany executes the statements in order in the given context,
+// i.e. a schema setup script or a small migration. It stops at the first
+// failed statement, and returns a *StatementError with its index and SQL.
+// Blank statements are skipped.
+//
+// If the context carries a transaction (Tx), the statements are executed in it,
+// so the caller can roll back all of them. Note that some databases (i.e. MySQL)
+// commit the transaction implicitly on schema changes.
+//
+// Example usage:
+//
+//	err := ExecuteMany(ctx, []string{
+//		"CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT)",
+//		"CREATE INDEX idx_users_name ON users (name)",
+//	})
+//
+//	var statementErr *StatementError
+//	if errors.As(err, &statementErr) {
+//		log.Printf("statement %d failed: %s", statementErr.Index, statementErr.SQL)
+//	}
+//
+// Parameters:
+// - ctx (QueryableContext): The context to use for the query execution.
+// - statements ([]string): The SQL statements to execute.
+//
+// Returns:
+// - error: An error if a statement failed.
+func ExecuteMany(ctx QueryableContext, statements []string) error {
+	if ctx.queryable == nil {
+		return ErrNoQueryable
+	}
+
+	for i, statement := range statements {
+		if strings.TrimSpace(statement) == "" {
+			continue
+		}
+
+		if _, err := ctx.execContext("ExecuteMany", statement); err != nil {
+			return &StatementError{Index: i, SQL: statement, Err: err}
+		}
+	}
+
+	return nil
+}
+
+// Statement is a SQL statement with its arguments, executed by ExecuteBatch.
+type Statement struct {
+	// SQL is the statement to execute
+	SQL string
+
+	// Args are the arguments of the statement
+	Args []any
+}
+
+// ExecuteBatch executes the statements in order in the given context,
+// and returns the result of each statement, i.e. to inspect the affected rows.
+// It stops at the first failed statement, and returns a *StatementError with
+// its index and SQL.
+//
+// If the context carries a database (DB), the statements are executed in a new
+// transaction, on a single connection, so either all or none of them are applied.
+// If it carries a transaction (Tx) or a connection (Conn), they are executed in it.
+//
+// Note that database/sql has no API for pipelining, so the statements
+// are sent one after the other, for all the database types.
+//
+// Example usage:
+//
+//	results, err := ExecuteBatch(ctx, []Statement{
+//		{SQL: "UPDATE accounts SET balance = balance - ? WHERE id = ?", Args: []any{100, 1}},
+//		{SQL: "UPDATE accounts SET balance = balance + ? WHERE id = ?", Args: []any{100, 2}},
+//	})
+//
+// Parameters:
+// - ctx (QueryableContext): The context to use for the query execution.
+// - stmts ([]Statement): The statements to execute.
+//
+// Returns:
+// - []sql.Result: The results of the statements, in the same order.
+// - error: An error if a statement failed.
+func ExecuteBatch(ctx QueryableContext, stmts []Statement) ([]sql.Result, error) {
+	if ctx.queryable == nil {
+		return nil, ErrNoQueryable
+	}
+
+	execute := func(ctx QueryableContext) ([]sql.Result, error) {
+		results := make([]sql.Result, 0, len(stmts))
+
+		for i, stmt := range stmts {
+			result, err := ctx.execContext("ExecuteBatch", stmt.SQL, stmt.Args...)
+			if err != nil {
+				return nil, &StatementError{Index: i, SQL: stmt.SQL, Err: err}
+			}
+
+			results = append(results, result)
+		}
+
+		return results, nil
+	}
+
+	db, isDB := ctx.queryable.(*sql.DB)
+
+	if !isDB || len(stmts) <= 1 {
+		return execute(ctx)
+	}
+
+	var results []sql.Result
+
+	err := Transaction(ctx, db, func(txCtx QueryableContext) error {
+		var err error
+		results, err = execute(txCtx)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// ErrUnexpectedRowsAffected is returned by ExecuteExpect when the statement
+// affected a different number of rows than expected.
+var ErrUnexpectedRowsAffected = errors.New("unexpected number of rows affected")
+
+// ExecuteExpect executes a SQL statement in the given context, like Execute,
+// and checks that it affected exactly the expected number of rows, i.e. to
+// detect an optimistic locking failure on UPDATE ... WHERE version = ?.
+//
+// If the number of affected rows differs, an error wrapping
+// ErrUnexpectedRowsAffected is returned, together with the result.
+//
+// Example usage:
+//
+//	_, err := ExecuteExpect(ctx, 1, "UPDATE users SET name = ?, version = version + 1 WHERE id = ? AND version = ?", "John", 1, 3)
+//	if errors.Is(err, ErrUnexpectedRowsAffected) {
+//		return errors.New("user was modified by someone else")
+//	}
+//
+// Parameters:
+// - ctx (QueryableContext): The context to use for the query execution.
+// - expected (int64): The expected number of affected rows.
+// - sqlStr (string): The SQL statement to execute.
+// - args (any): Optional arguments to pass to the statement.
+//
+// Returns:
+// - sql.Result: The result of the statement.
+// - error: An error if the statement failed, or affected a different number of rows.
+func ExecuteExpect(ctx QueryableContext, expected int64, sqlStr string, args ...any) (sql.Result, error) {
+	if ctx.queryable == nil {
+		return nil, ErrNoQueryable
+	}
+
+	result, err := ctx.execContext("ExecuteExpect", sqlStr, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	// In dry run mode no rows are affected, so there is nothing to check
+	if ctx.dryRun {
+		return result, nil
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return result, err
+	}
+
+	if affected != expected {
+		return result, fmt.Errorf("%w: expected %d, got %d", ErrUnexpectedRowsAffected, expected, affected)
+	}
+
+	return result, nil
 }
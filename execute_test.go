@@ -2,6 +2,7 @@ package database_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	database "github.com/dracory/database"
@@ -34,3 +35,239 @@ func TestExecute(t *testing.T) {
 		t.Fatalf("Unexpected error: %v", err)
 	}
 }
+
+func TestExecuteReturning(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	if _, err := database.ExecuteReturning(database.Context(context.Background(), nil), "UPDATE users SET name = ? RETURNING id", "X"); !errors.Is(err, database.ErrNoQueryable) {
+		t.Errorf("Expected ErrNoQueryable, got %v", err)
+	}
+
+	if err := createUserTableAndInserTesttData(db); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := database.Context(context.Background(), db)
+
+	rows, err := database.ExecuteReturning(ctx, "UPDATE users SET name = ? WHERE id > ? RETURNING id, name", "Updated", 1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(rows) != 2 {
+		t.Fatalf("Expected 2 returned rows, got %d", len(rows))
+	}
+
+	for _, row := range rows {
+		if row["name"] != "Updated" {
+			t.Errorf("Expected the updated name, got %v", row["name"])
+		}
+	}
+
+	rows, err = database.ExecuteReturning(ctx, "DELETE FROM users WHERE id > ? RETURNING id", 10)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(rows) != 0 {
+		t.Errorf("Expected no returned rows, got %d", len(rows))
+	}
+}
+
+func TestExecuteMany(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	// Test nil querier error
+	err = database.ExecuteMany(database.Context(context.Background(), nil), []string{"SELECT 1"})
+	if err == nil || err.Error() != "querier (db/tx/conn) is nil" {
+		t.Errorf("Expected nil querier error, got %v", err)
+	}
+
+	ctx := database.Context(context.Background(), db)
+
+	// Test statements are executed in order, skipping blank ones
+	err = database.ExecuteMany(ctx, []string{
+		"CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT)",
+		"  ",
+		"INSERT INTO users (name) VALUES ('Alice')",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Test the failed statement is reported
+	err = database.ExecuteMany(ctx, []string{
+		"INSERT INTO users (name) VALUES ('Bob')",
+		"INSERT INTO missing (name) VALUES ('Charlie')",
+		"INSERT INTO users (name) VALUES ('Dave')",
+	})
+
+	var statementErr *database.StatementError
+	if !errors.As(err, &statementErr) {
+		t.Fatalf("Expected StatementError, got %v", err)
+	}
+	if statementErr.Index != 1 || statementErr.SQL != "INSERT INTO missing (name) VALUES ('Charlie')" {
+		t.Errorf("Unexpected failed statement: %d, %s", statementErr.Index, statementErr.SQL)
+	}
+
+	count, err := database.Count(ctx, "SELECT COUNT(*) FROM users")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("Expected the statements after the failed one not to be executed, got %d users", count)
+	}
+}
+
+func TestExecuteManyInTransaction(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	err = createUserTableAndInserTesttData(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Test the statements are rolled back with the caller's transaction
+	err = database.Transaction(context.Background(), db, func(txCtx database.QueryableContext) error {
+		return database.ExecuteMany(txCtx, []string{
+			"DELETE FROM users WHERE id = 1",
+			"INSERT INTO missing (name) VALUES ('Dave')",
+		})
+	})
+	if err == nil {
+		t.Fatal("Expected error for missing table")
+	}
+
+	count, err := database.Count(database.Context(context.Background(), db), "SELECT COUNT(*) FROM users")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if count != 3 {
+		t.Errorf("Expected the delete to be rolled back, got %d users", count)
+	}
+}
+
+func TestExecuteBatch(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	err = createUserTableAndInserTesttData(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Test nil querier error
+	_, err = database.ExecuteBatch(database.Context(context.Background(), nil), []database.Statement{{SQL: "SELECT 1"}})
+	if !errors.Is(err, database.ErrNoQueryable) {
+		t.Errorf("Expected nil querier error, got %v", err)
+	}
+
+	ctx := database.Context(context.Background(), db)
+
+	// Test the results are returned per statement
+	results, err := database.ExecuteBatch(ctx, []database.Statement{
+		{SQL: "UPDATE users SET name = ? WHERE id > ?", Args: []any{"Updated", 1}},
+		{SQL: "DELETE FROM users WHERE id = ?", Args: []any{1}},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(results))
+	}
+
+	if affected, _ := results[0].RowsAffected(); affected != 2 {
+		t.Errorf("Expected 2 updated rows, got %d", affected)
+	}
+
+	if affected, _ := results[1].RowsAffected(); affected != 1 {
+		t.Errorf("Expected 1 deleted row, got %d", affected)
+	}
+
+	// Test all the statements are rolled back if one fails
+	results, err = database.ExecuteBatch(ctx, []database.Statement{
+		{SQL: "DELETE FROM users"},
+		{SQL: "INSERT INTO missing (name) VALUES (?)", Args: []any{"Dave"}},
+	})
+
+	var statementErr *database.StatementError
+	if !errors.As(err, &statementErr) || statementErr.Index != 1 {
+		t.Fatalf("Expected StatementError for statement 1, got %v", err)
+	}
+
+	if results != nil {
+		t.Errorf("Expected no results on error, got %v", results)
+	}
+
+	count, err := database.Count(ctx, "SELECT COUNT(*) FROM users")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("Expected the delete to be rolled back, got %d users", count)
+	}
+}
+
+func TestExecuteExpect(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	err = createUserTableAndInserTesttData(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Test nil querier error
+	_, err = database.ExecuteExpect(database.Context(context.Background(), nil), 1, "DELETE FROM users")
+	if err == nil || err.Error() != "querier (db/tx/conn) is nil" {
+		t.Errorf("Expected nil querier error, got %v", err)
+	}
+
+	ctx := database.Context(context.Background(), db)
+
+	// Test expected number of rows
+	result, err := database.ExecuteExpect(ctx, 1, "UPDATE users SET name = ? WHERE id = ?", "Alicia", 1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result == nil {
+		t.Error("Expected result")
+	}
+
+	// Test unexpected number of rows
+	result, err = database.ExecuteExpect(ctx, 1, "UPDATE users SET name = ? WHERE id > ?", "Updated", 1)
+	if !errors.Is(err, database.ErrUnexpectedRowsAffected) {
+		t.Fatalf("Expected ErrUnexpectedRowsAffected, got %v", err)
+	}
+	if err.Error() != "unexpected number of rows affected: expected 1, got 2" {
+		t.Errorf("Unexpected error message: %v", err)
+	}
+	if result == nil {
+		t.Error("Expected result to be returned with the error")
+	}
+
+	// Test no rows affected
+	_, err = database.ExecuteExpect(ctx, 1, "DELETE FROM users WHERE id = ?", 999)
+	if !errors.Is(err, database.ErrUnexpectedRowsAffected) {
+		t.Errorf("Expected ErrUnexpectedRowsAffected, got %v", err)
+	}
+}
@@ -0,0 +1,65 @@
+package database
+
+import (
+	"strings"
+
+	"github.com/spf13/cast"
+)
+
+// Exists wraps the given SELECT query in the dialect's EXISTS form,
+// executes it in the given context, and returns whether the query
+// returns at least one row.
+//
+// The query is wrapped as follows, based on DatabaseType:
+//   - SQLite, MySQL, Postgres: SELECT EXISTS(<query>)
+//   - MSSQL: SELECT CASE WHEN EXISTS(<query>) THEN 1 ELSE 0 END
+//
+// Postgres returns the result as a boolean, while SQLite, MySQL and MSSQL
+// return it as an integer, both are converted to bool.
+//
+// Example usage:
+//
+// exists, err := Exists(ctx, "SELECT 1 FROM users WHERE email = ?", "john@example.com")
+//
+// Parameters:
+// - ctx (QueryableContext): The context to use for the query execution.
+// - sqlStr (string): The SELECT query to check.
+// - args (any): Optional arguments to pass to the query.
+//
+// Returns:
+// - bool: True if the query returns at least one row, false otherwise.
+// - error: An error if the query failed.
+func Exists(ctx QueryableContext, sqlStr string, args ...any) (bool, error) {
+	if ctx.queryable == nil {
+		return false, ErrNoQueryable
+	}
+
+	sqlStr = strings.TrimRight(strings.TrimSpace(sqlStr), ";")
+
+	var existsSQL string
+
+	if ctx.databaseType() == DATABASE_TYPE_MSSQL {
+		existsSQL = "SELECT CASE WHEN EXISTS(" + sqlStr + ") THEN 1 ELSE 0 END"
+	} else {
+		existsSQL = "SELECT EXISTS(" + sqlStr + ")"
+	}
+
+	var result any
+
+	row, run := ctx.queryRowContext("Exists", existsSQL, args...)
+	err := row.Scan(&result)
+
+	if err != nil {
+		run.end(-1, err)
+		return false, err
+	}
+
+	// Some drivers return the integer result as []byte
+	if b, ok := result.([]byte); ok {
+		result = string(b)
+	}
+
+	run.end(1, nil)
+
+	return cast.ToBoolE(result)
+}
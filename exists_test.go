@@ -0,0 +1,55 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	database "github.com/dracory/database"
+)
+
+func TestExists(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	err = createUserTableAndInserTesttData(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := database.Context(context.Background(), db)
+
+	// Test nil querier error
+	_, err = database.Exists(database.Context(context.Background(), nil), "SELECT 1 FROM users")
+	if err == nil {
+		t.Error("Expected error for nil querier")
+	} else if err.Error() != "querier (db/tx/conn) is nil" {
+		t.Errorf("Unexpected error message: %v", err)
+	}
+
+	// Test existing row
+	exists, err := database.Exists(ctx, "SELECT 1 FROM users WHERE email = ?;", "bob@example.com")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !exists {
+		t.Error("Expected row to exist")
+	}
+
+	// Test missing row
+	exists, err = database.Exists(ctx, "SELECT 1 FROM users WHERE email = ?", "nobody@example.com")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if exists {
+		t.Error("Expected row not to exist")
+	}
+
+	// Test query with error
+	_, err = database.Exists(ctx, "INVALID SQL")
+	if err == nil {
+		t.Error("Expected error for invalid SQL")
+	}
+}
@@ -0,0 +1,126 @@
+package database
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/spf13/cast"
+)
+
+// Explain returns the query plan of the SQL query as text, by prefixing it
+// with the EXPLAIN statement of the dialect:
+//   - SQLite: EXPLAIN QUERY PLAN, the steps are indented by their depth
+//   - MySQL: EXPLAIN, the columns and the rows are separated by tabs
+//   - Postgres: EXPLAIN (FORMAT TEXT)
+//
+// The query is planned, but not executed. MSSQL has no EXPLAIN statement,
+// so an error is returned for it.
+//
+// Example usage:
+//
+//	plan, err := Explain(ctx, "SELECT * FROM users WHERE email = ?", "john@example.com")
+//	// SEARCH users USING INDEX idx_users_email (email=?)
+//
+// Parameters:
+// - ctx (QueryableContext): The context to use for the query execution.
+// - sqlStr (string): The SQL query to explain.
+// - args (any): Optional arguments to pass to the query.
+//
+// Returns:
+// - string: The query plan, one line per step.
+// - error: An error if the database type is not supported, or the query failed.
+func Explain(ctx QueryableContext, sqlStr string, args ...any) (string, error) {
+	if ctx.queryable == nil {
+		return "", ErrNoQueryable
+	}
+
+	dbType := ctx.databaseType()
+
+	var prefix string
+
+	switch dbType {
+	case DATABASE_TYPE_SQLITE:
+		prefix = "EXPLAIN QUERY PLAN "
+	case DATABASE_TYPE_MYSQL:
+		prefix = "EXPLAIN "
+	case DATABASE_TYPE_POSTGRES:
+		prefix = "EXPLAIN (FORMAT TEXT) "
+	default:
+		return "", errors.New("explain is not supported for database type: " + dbType)
+	}
+
+	columns, rows, err := selectOrdered(ctx, "Explain", prefix+sqlStr, args...)
+	if err != nil {
+		return "", err
+	}
+
+	if dbType == DATABASE_TYPE_SQLITE {
+		return formatSqlitePlan(columns, rows), nil
+	}
+
+	return formatPlan(columns, rows), nil
+}
+
+// formatSqlitePlan formats the rows of EXPLAIN QUERY PLAN, indenting
+// the detail of each step by its depth in the tree of the steps
+func formatSqlitePlan(columns []string, rows [][]any) string {
+	idIndex, parentIndex, detailIndex := -1, -1, -1
+
+	for i, column := range columns {
+		switch strings.ToLower(column) {
+		case "id":
+			idIndex = i
+		case "parent":
+			parentIndex = i
+		case "detail":
+			detailIndex = i
+		}
+	}
+
+	if idIndex < 0 || parentIndex < 0 || detailIndex < 0 {
+		return formatPlan(columns, rows)
+	}
+
+	depths := map[string]int{}
+	lines := make([]string, 0, len(rows))
+
+	for _, row := range rows {
+		depth := 0
+		if parentDepth, ok := depths[cast.ToString(row[parentIndex])]; ok {
+			depth = parentDepth + 1
+		}
+
+		depths[cast.ToString(row[idIndex])] = depth
+
+		lines = append(lines, strings.Repeat("  ", depth)+cast.ToString(row[detailIndex]))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// formatPlan formats the rows of EXPLAIN, a single column as is
+// (i.e. Postgres), otherwise as a table separated by tabs (i.e. MySQL)
+func formatPlan(columns []string, rows [][]any) string {
+	lines := make([]string, 0, len(rows)+1)
+
+	if len(columns) > 1 {
+		lines = append(lines, strings.Join(columns, "\t"))
+	}
+
+	for _, row := range rows {
+		values := make([]string, len(row))
+
+		for i, value := range row {
+			if value == nil {
+				values[i] = "NULL"
+				continue
+			}
+
+			values[i] = cast.ToString(value)
+		}
+
+		lines = append(lines, strings.Join(values, "\t"))
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,84 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	database "github.com/dracory/database"
+)
+
+func TestExplainSqlite(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	if _, err := database.Explain(database.Context(context.Background(), nil), "SELECT * FROM users"); !errors.Is(err, database.ErrNoQueryable) {
+		t.Errorf("Expected ErrNoQueryable, got %v", err)
+	}
+
+	if err := createUserTableAndInserTesttData(db); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := database.Context(context.Background(), db)
+
+	plan, err := database.Explain(ctx, "SELECT * FROM users WHERE id = ?", 1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !strings.Contains(plan, "users") {
+		t.Errorf("Expected the plan to mention the users table, got %q", plan)
+	}
+
+	if _, err := database.Explain(ctx, "INVALID SQL"); err == nil {
+		t.Error("Expected error for invalid SQL")
+	}
+}
+
+func TestExplainDialects(t *testing.T) {
+	mock := database.NewMockQueryable().SetDatabaseType(database.DATABASE_TYPE_POSTGRES)
+	defer mock.Close()
+
+	mock.ExpectQuery("EXPLAIN (FORMAT TEXT) SELECT * FROM users").
+		WillReturnRows([]string{"QUERY PLAN"}, []any{"Seq Scan on users  (cost=0.00..1.03 rows=3 width=68)"}, []any{"  Filter: (id = 1)"})
+
+	plan, err := database.Explain(database.Context(context.Background(), mock), "SELECT * FROM users")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if plan != "Seq Scan on users  (cost=0.00..1.03 rows=3 width=68)\n  Filter: (id = 1)" {
+		t.Errorf("Unexpected Postgres plan: %q", plan)
+	}
+
+	mock.AssertExpectations(t)
+
+	mock = database.NewMockQueryable().SetDatabaseType(database.DATABASE_TYPE_MYSQL)
+	defer mock.Close()
+
+	mock.ExpectQuery("EXPLAIN SELECT * FROM users").
+		WillReturnRows([]string{"id", "table", "key"}, []any{1, "users", nil})
+
+	plan, err = database.Explain(database.Context(context.Background(), mock), "SELECT * FROM users")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if plan != "id\ttable\tkey\n1\tusers\tNULL" {
+		t.Errorf("Unexpected MySQL plan: %q", plan)
+	}
+
+	mock.AssertExpectations(t)
+
+	mock = database.NewMockQueryable().SetDatabaseType(database.DATABASE_TYPE_MSSQL)
+	defer mock.Close()
+
+	if _, err := database.Explain(database.Context(context.Background(), mock), "SELECT * FROM users"); err == nil {
+		t.Error("Expected error for MSSQL")
+	}
+}
@@ -0,0 +1,58 @@
+package database
+
+import (
+	"errors"
+	"reflect"
+)
+
+// FindByPK selects the row of the table with the given primary key,
+// and scans it into a struct of type T, the same way as SelectOne.
+//
+// The statement is SELECT * FROM table WHERE pk = ?, with the table and
+// the primary key column quoted, and the placeholder of the database type.
+//
+// Example usage:
+//
+//	user, found, err := FindByPK[User](ctx, "users", "id", 1)
+//	if err != nil {
+//		return err
+//	}
+//	if !found {
+//		return errors.New("user not found")
+//	}
+//
+// Parameters:
+// - ctx (QueryableContext): The context to use for the query execution.
+// - table (string): The name of the table.
+// - pkColumn (string): The name of the primary key column.
+// - pk (any): The value of the primary key.
+//
+// Returns:
+// - T: The scanned row, or the zero value if no row was found.
+// - bool: True if a row was found, false otherwise.
+// - error: An error if T is not a struct, a name is invalid, or the query failed.
+func FindByPK[T any](ctx QueryableContext, table string, pkColumn string, pk any) (T, bool, error) {
+	var zero T
+
+	if ctx.queryable == nil {
+		return zero, false, ErrNoQueryable
+	}
+
+	if !isMappableStruct(reflect.TypeFor[T]()) {
+		return zero, false, errors.New("type " + reflect.TypeFor[T]().String() + " is not a struct")
+	}
+
+	dbType := ctx.databaseType()
+
+	quotedPK, err := quoteIdentifier(dbType, pkColumn)
+	if err != nil {
+		return zero, false, err
+	}
+
+	sqlStr, args, err := Select(table).Where(quotedPK+" = ?", pk).ToSQL(dbType)
+	if err != nil {
+		return zero, false, err
+	}
+
+	return SelectOne[T](ctx, sqlStr, args...)
+}
@@ -0,0 +1,73 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	database "github.com/dracory/database"
+)
+
+func TestFindByPK(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	if _, _, err := database.FindByPK[testUser](database.Context(context.Background(), nil), "users", "id", 1); !errors.Is(err, database.ErrNoQueryable) {
+		t.Errorf("Expected ErrNoQueryable, got %v", err)
+	}
+
+	if err := createUserTableAndInserTesttData(db); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := database.Context(context.Background(), db)
+
+	user, found, err := database.FindByPK[testUser](ctx, "users", "id", 2)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !found || user.ID != 2 || user.FullName != "Bob" || user.Email == nil || *user.Email != "bob@example.com" {
+		t.Errorf("Unexpected user: %+v, found %v", user, found)
+	}
+
+	user, found, err = database.FindByPK[testUser](ctx, "users", "id", 42)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if found || user.ID != 0 {
+		t.Errorf("Expected no user, got %+v", user)
+	}
+
+	if _, _, err := database.FindByPK[string](ctx, "users", "id", 1); err == nil {
+		t.Error("Expected an error for a non struct type")
+	}
+
+	if _, _, err := database.FindByPK[testUser](ctx, "users", "", 1); err == nil {
+		t.Error("Expected an error for an empty primary key column")
+	}
+}
+
+func TestFindByPKQuoting(t *testing.T) {
+	mock := database.NewMockQueryable().SetDatabaseType(database.DATABASE_TYPE_POSTGRES)
+	defer mock.Close()
+
+	mock.ExpectQuery(`SELECT * FROM "order" WHERE "key" = $1`).
+		WithArgs("abc").
+		WillReturnRows([]string{"id", "name"}, []any{int64(1), "First"})
+
+	user, found, err := database.FindByPK[testUser](database.Context(context.Background(), mock), "order", "key", "abc")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !found || user.FullName != "First" {
+		t.Errorf("Unexpected user: %+v, found %v", user, found)
+	}
+
+	mock.AssertExpectations(t)
+}
@@ -1,9 +1,16 @@
 package database
 
-import "context"
+import (
+	"context"
+	"database/sql"
+)
 
 // IsQueryableContext checks if the given context is a QueryableContext.
 //
+// It checks the type of the context only, so it returns false for a context
+// derived from a QueryableContext with the standard library (i.e. context.WithValue),
+// use From or ContextOr to find the queryable of such a context.
+//
 // Parameters:
 // - ctx: The context to check.
 //
@@ -17,6 +24,64 @@ func IsQueryableContext(ctx context.Context) bool {
 	return false
 }
 
+// From returns the queryable (DB, Tx or Conn) carried by the given context.
+//
+// It is useful for middleware, which receives a plain context.Context,
+// to get the queryable without type asserting to QueryableContext.
+// The queryable is also found if the context was derived from a
+// QueryableContext with the standard library (i.e. context.WithValue).
+// It does not panic for a plain or nil context.
+//
+// Example:
+// 	if q, ok := database.From(ctx); ok {
+// 		// use q
+// 	}
+//
+// Parameters:
+// - ctx: The context to get the queryable from.
+//
+// Returns:
+// - QueryableInterface: The queryable, or nil if there is none.
+// - bool: True if the context carries a non-nil queryable, false otherwise.
+func From(ctx context.Context) (QueryableInterface, bool) {
+	qCtx, ok := ctx.(QueryableContext)
+
+	if !ok {
+		qCtx, ok = derivedQueryableContext(ctx)
+	}
+
+	if !ok || qCtx.queryable == nil {
+		return nil, false
+	}
+
+	return qCtx.queryable, true
+}
+
+// InTransaction checks if the given context carries a transaction (Tx),
+// i.e. for middleware and logging to annotate transactional work.
+//
+// Like From, the transaction is also found if the context was derived from
+// a QueryableContext with the standard library (i.e. context.WithValue).
+// It does not panic for a plain or nil context.
+//
+// Example:
+// 	slog.InfoContext(ctx, "saving order", "in_transaction", database.InTransaction(ctx))
+//
+// Parameters:
+// - ctx: The context to check.
+//
+// Returns:
+// - bool: True if the context carries a transaction, false otherwise.
+func InTransaction(ctx context.Context) bool {
+	qCtx, ok := ctx.(QueryableContext)
+
+	if !ok {
+		qCtx, ok = derivedQueryableContext(ctx)
+	}
+
+	return ok && qCtx.IsTx()
+}
+
 // Context returns a new context with the given QueryableInterface.
 // This is a direct alias/shortcut for NewQueryableContext.
 //
@@ -55,3 +120,62 @@ func Context(ctx context.Context, queryable QueryableInterface) QueryableContext
 func ContextOr(ctx context.Context, queryable QueryableInterface) QueryableContext {
 	return NewQueryableContextOr(ctx, queryable)
 }
+
+// EnsureQueryable returns the QueryableContext carried by the given context,
+// if it has a queryable, or otherwise obtains a database from the fallback
+// and returns a new QueryableContext with it. The fallback is only called
+// when needed, so handlers can be agnostic about whether the caller supplied
+// the database, i.e. in middleware receiving a plain context.
+//
+// Like ContextOr, the QueryableContext is also found if the context was derived
+// from it with the standard library (i.e. context.WithValue). If it has no
+// queryable, its options are kept, and the database of the fallback is set on it.
+//
+// Example:
+// 	qCtx, err := database.EnsureQueryable(r.Context(), func() (*sql.DB, error) {
+// 		return app.DB(), nil
+// 	})
+// 	if err != nil {
+// 		return err
+// 	}
+//
+// Parameters:
+// - ctx: The context, which may or may not carry a QueryableContext.
+// - fallback: The function returning the database, if the context carries none.
+//
+// Returns:
+// - QueryableContext: The existing QueryableContext, or a new one with the database of the fallback.
+// - error: The error of the fallback, or ErrNoQueryable if it returned no database.
+func EnsureQueryable(ctx context.Context, fallback func() (*sql.DB, error)) (QueryableContext, error) {
+	qCtx, ok := ctx.(QueryableContext)
+
+	if !ok {
+		if qCtx, ok = derivedQueryableContext(ctx); ok {
+			qCtx.Context = ctx
+		}
+	}
+
+	if ok && qCtx.queryable != nil {
+		return qCtx, nil
+	}
+
+	if fallback == nil {
+		return QueryableContext{}, ErrNoQueryable
+	}
+
+	db, err := fallback()
+
+	if err != nil {
+		return QueryableContext{}, err
+	}
+
+	if db == nil {
+		return QueryableContext{}, ErrNoQueryable
+	}
+
+	if ok {
+		return qCtx.WithQueryable(db), nil
+	}
+
+	return NewQueryableContext(ctx, db), nil
+}
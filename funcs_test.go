@@ -3,6 +3,7 @@ package database_test
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"reflect"
 	"testing"
 
@@ -38,6 +39,86 @@ func Test_IsQueryableContext(t *testing.T) {
 	}
 }
 
+func TestFrom(t *testing.T) {
+	db := &sql.DB{}
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		want   database.QueryableInterface
+		wantOk bool
+	}{
+		{
+			name:   "nil context",
+			ctx:    nil,
+			want:   nil,
+			wantOk: false,
+		},
+		{
+			name:   "regular context",
+			ctx:    context.Background(),
+			want:   nil,
+			wantOk: false,
+		},
+		{
+			name:   "queryable context without queryable",
+			ctx:    database.Context(context.Background(), nil),
+			want:   nil,
+			wantOk: false,
+		},
+		{
+			name:   "queryable context",
+			ctx:    database.Context(context.Background(), db),
+			want:   db,
+			wantOk: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := database.From(tt.ctx)
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("From() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestInTransaction(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+
+	type key struct{}
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want bool
+	}{
+		{"nil context", nil, false},
+		{"regular context", context.Background(), false},
+		{"queryable context without queryable", database.Context(context.Background(), nil), false},
+		{"db context", database.Context(context.Background(), db), false},
+		{"tx context", database.Context(context.Background(), tx), true},
+		{"derived tx context", context.WithValue(database.Context(context.Background(), tx), key{}, "value"), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := database.InTransaction(tt.ctx); got != tt.want {
+				t.Errorf("InTransaction() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_Context(t *testing.T) {
 	ctxBackground := context.Background()
 	ctx := database.Context(ctxBackground, nil)
@@ -124,3 +205,72 @@ func TestContextOr(t *testing.T) {
 		t.Error("ContextOr with existing QueryableContext did not return the same context")
 	}
 }
+
+func TestEnsureQueryable(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	fallbackDB, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fallbackDB.Close()
+
+	type key struct{}
+
+	calls := 0
+	fallback := func() (*sql.DB, error) {
+		calls++
+		return fallbackDB, nil
+	}
+
+	// The existing queryable is returned, without calling the fallback
+	qCtx, err := database.EnsureQueryable(database.Context(context.Background(), db), fallback)
+	if err != nil || qCtx.Queryable() != db {
+		t.Errorf("Expected the existing db, got %v, %v", qCtx.Queryable(), err)
+	}
+
+	derived := context.WithValue(database.Context(context.Background(), db).WithRebind(), key{}, "value")
+
+	qCtx, err = database.EnsureQueryable(derived, fallback)
+	if err != nil || qCtx.Queryable() != db || qCtx.Value(key{}) != "value" {
+		t.Errorf("Expected the db of the derived context, got %v, %v", qCtx.Queryable(), err)
+	}
+
+	if calls != 0 {
+		t.Errorf("Expected the fallback not to be called, got %d calls", calls)
+	}
+
+	// The fallback is used for a plain context, or a context without a queryable
+	qCtx, err = database.EnsureQueryable(context.WithValue(context.Background(), key{}, "value"), fallback)
+	if err != nil || qCtx.Queryable() != fallbackDB || qCtx.Value(key{}) != "value" {
+		t.Errorf("Expected the fallback db, got %v, %v", qCtx.Queryable(), err)
+	}
+
+	qCtx, err = database.EnsureQueryable(database.Context(context.Background(), nil), fallback)
+	if err != nil || qCtx.Queryable() != fallbackDB {
+		t.Errorf("Expected the fallback db, got %v, %v", qCtx.Queryable(), err)
+	}
+
+	if calls != 2 {
+		t.Errorf("Expected 2 calls of the fallback, got %d", calls)
+	}
+
+	// The errors of the fallback are returned
+	fallbackErr := errors.New("no database configured")
+
+	if _, err := database.EnsureQueryable(context.Background(), func() (*sql.DB, error) { return nil, fallbackErr }); !errors.Is(err, fallbackErr) {
+		t.Errorf("Expected the fallback error, got %v", err)
+	}
+
+	if _, err := database.EnsureQueryable(context.Background(), func() (*sql.DB, error) { return nil, nil }); !errors.Is(err, database.ErrNoQueryable) {
+		t.Errorf("Expected ErrNoQueryable for a nil db, got %v", err)
+	}
+
+	if _, err := database.EnsureQueryable(context.Background(), nil); !errors.Is(err, database.ErrNoQueryable) {
+		t.Errorf("Expected ErrNoQueryable for a nil fallback, got %v", err)
+	}
+}
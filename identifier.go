@@ -0,0 +1,84 @@
+package database
+
+import (
+	"errors"
+	"strings"
+)
+
+// QuoteIdentifier quotes a table or column name in the identifier quoting
+// style of the database of the context (see DialectFor), escaping the quote
+// characters inside it. It allows building dynamic SQL, i.e. an ORDER BY from
+// a user selected sort field, without the risk of SQL injection.
+//
+// A qualified name (i.e. users.name) is quoted part by part.
+// Empty names, empty parts and names containing null bytes are rejected.
+//
+// Example usage:
+//
+//	column, err := QuoteIdentifier(ctx, sortField)
+//	if err != nil {
+//		return err
+//	}
+//	rows, err := Query(ctx, "SELECT * FROM users ORDER BY "+column)
+//
+// Parameters:
+// - ctx (QueryableContext): The context of the database.
+// - name (string): The table or column name.
+//
+// Returns:
+// - string: The quoted name, i.e. "name" for Postgres and SQLite, `name` for MySQL.
+// - error: An error if the querier is nil, or the name is invalid.
+func QuoteIdentifier(ctx QueryableContext, name string) (string, error) {
+	if ctx.queryable == nil {
+		return "", ErrNoQueryable
+	}
+
+	return quoteIdentifier(ctx.databaseType(), name)
+}
+
+// quoteIdentifier quotes a table or column name in the identifier quoting
+// style of the dialect of the given database type, so that reserved words
+// and mixed case names can be used.
+//
+// Quoting styles:
+//   - MySQL: `name`
+//   - MSSQL: [name]
+//   - Postgres, SQLite and others: "name"
+//
+// A qualified name (i.e. schema.table) is quoted part by part.
+// Quote characters inside a name are escaped by doubling them.
+func quoteIdentifier(dbType string, name string) (string, error) {
+	if strings.ContainsRune(name, 0) {
+		return "", errors.New("invalid identifier, contains a null byte")
+	}
+
+	dialect := DialectFor(dbType)
+
+	parts := strings.Split(name, ".")
+
+	for i, part := range parts {
+		if strings.TrimSpace(part) == "" {
+			return "", errors.New("invalid identifier: " + name)
+		}
+
+		parts[i] = dialect.QuoteIdentifier(part)
+	}
+
+	return strings.Join(parts, "."), nil
+}
+
+// quoteIdentifiers quotes each of the names, see quoteIdentifier
+func quoteIdentifiers(dbType string, names []string) ([]string, error) {
+	quoted := make([]string, len(names))
+
+	for i, name := range names {
+		q, err := quoteIdentifier(dbType, name)
+		if err != nil {
+			return nil, err
+		}
+
+		quoted[i] = q
+	}
+
+	return quoted, nil
+}
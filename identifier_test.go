@@ -0,0 +1,73 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	database "github.com/dracory/database"
+)
+
+func TestQuoteIdentifier(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	ctx := database.Context(context.Background(), db)
+
+	tests := map[string]string{
+		"name":           `"name"`,
+		"users.name":     `"users"."name"`,
+		`name" DESC; --`: `"name"" DESC; --"`,
+		"Created At":     `"Created At"`,
+	}
+
+	for name, expected := range tests {
+		quoted, err := database.QuoteIdentifier(ctx, name)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", name, err)
+		}
+		if quoted != expected {
+			t.Errorf("%q: expected %q, got %q", name, expected, quoted)
+		}
+	}
+
+	for _, name := range []string{"", "users.", "na\x00me"} {
+		if _, err := database.QuoteIdentifier(ctx, name); err == nil {
+			t.Errorf("%q: expected an error", name)
+		}
+	}
+
+	if _, err := database.QuoteIdentifier(database.Context(context.Background(), nil), "name"); err == nil {
+		t.Error("Expected an error for a nil querier")
+	}
+}
+
+func TestQuoteIdentifierOrderBy(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	if err := createUserTableAndInserTesttData(db); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := database.Context(context.Background(), db)
+
+	column, err := database.QuoteIdentifier(ctx, "name")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first, err := database.Scalar[string](ctx, "SELECT name FROM users ORDER BY "+column+" DESC")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if first != "Charlie" {
+		t.Errorf("Expected %q, got %q", "Charlie", first)
+	}
+}
@@ -1,9 +1,151 @@
 package database
 
-// func InsertOne(ctx QueryableContext, tableName string, data map[string]any) (int64, error) {
-// 	return 0, errors.New("not implemented")
-// }
+import (
+	"errors"
+	"strings"
+)
 
-// func InsertMany(ctx QueryableContext, tableName string, data []map[string]any) (int64, error) {
-// 	return 0, errors.New("not implemented")
-// }
+// Insert inserts the row into the table, and returns the id generated
+// for it by the database (i.e. an auto increment or serial column).
+//
+// The id is returned as follows, based on DatabaseType:
+//   - Postgres: INSERT ... RETURNING id
+//   - MSSQL: INSERT ... OUTPUT INSERTED.id
+//   - MySQL, SQLite: LastInsertId() of the result
+//
+// The id column is "id", use InsertWithOptions to use another column.
+//
+// Example usage:
+//
+//	id, err := Insert(ctx, "users", map[string]any{
+//		"name":  "John Doe",
+//		"email": "john@example.com",
+//	})
+//
+// Parameters:
+// - ctx (QueryableContext): The context to use for the query execution.
+// - table (string): The name of the table.
+// - row (map[string]any): The values to insert, by column name.
+//
+// Returns:
+// - int64: The id of the inserted row.
+// - error: An error if the row is invalid, or the statement failed.
+func Insert(ctx QueryableContext, table string, row map[string]any) (int64, error) {
+	return InsertWithOptions(ctx, InsertOptions{}, table, row)
+}
+
+// InsertOptions configures InsertWithOptions.
+type InsertOptions struct {
+	// IDColumn is the name of the column the id is generated for.
+	// Defaults to "id". It is not used for MySQL and SQLite,
+	// which return the id of the auto increment column.
+	IDColumn string
+}
+
+// InsertWithOptions works like Insert, but allows configuring
+// the name of the id column.
+//
+// Example usage:
+//
+//	id, err := InsertWithOptions(ctx, InsertOptions{IDColumn: "user_id"}, "users", map[string]any{"name": "John"})
+//
+// Parameters:
+// - ctx (QueryableContext): The context to use for the query execution.
+// - options (InsertOptions): The id column options.
+// - table (string): The name of the table.
+// - row (map[string]any): The values to insert, by column name.
+//
+// Returns:
+// - int64: The id of the inserted row.
+// - error: An error if the row is invalid, or the statement failed.
+func InsertWithOptions(ctx QueryableContext, options InsertOptions, table string, row map[string]any) (int64, error) {
+	if ctx.queryable == nil {
+		return 0, ErrNoQueryable
+	}
+
+	if options.IDColumn == "" {
+		options.IDColumn = "id"
+	}
+
+	dbType := ctx.databaseType()
+
+	sqlStr, args, err := insertSQL(dbType, table, options.IDColumn, row)
+	if err != nil {
+		return 0, err
+	}
+
+	// In dry run mode the statement is not executed, so no id is returned
+	if (dbType != DATABASE_TYPE_POSTGRES && dbType != DATABASE_TYPE_MSSQL) || ctx.dryRun {
+		result, err := ctx.execContext("Insert", sqlStr, args...)
+		if err != nil {
+			return 0, err
+		}
+
+		return result.LastInsertId()
+	}
+
+	var id int64
+
+	sqlRow, run := ctx.queryRowContext("Insert", sqlStr, args...)
+
+	if err := sqlRow.Scan(&id); err != nil {
+		run.end(-1, err)
+		return 0, err
+	}
+
+	run.end(1, nil)
+
+	return id, nil
+}
+
+// insertSQL builds the insert statement for the database type,
+// returning the id column for Postgres and MSSQL, unless it is empty,
+// or all the columns if it is *
+func insertSQL(dbType string, table string, idColumn string, row map[string]any) (string, []any, error) {
+	if table == "" {
+		return "", nil, errors.New("table name is required")
+	}
+
+	if len(row) == 0 {
+		return "", nil, errors.New("row must have at least one column")
+	}
+
+	columns, args := sortedRowColumns(row)
+
+	quotedTable, err := quoteIdentifier(dbType, table)
+	if err != nil {
+		return "", nil, err
+	}
+
+	quotedColumns, err := quoteIdentifiers(dbType, columns)
+	if err != nil {
+		return "", nil, err
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", ")
+
+	sqlStr := "INSERT INTO " + quotedTable + " (" + strings.Join(quotedColumns, ", ") + ")"
+
+	if idColumn == "" {
+		return Rebind(dbType, sqlStr+" VALUES ("+placeholders+")"), args, nil
+	}
+
+	quotedIDColumn := "*"
+
+	if idColumn != "*" {
+		if quotedIDColumn, err = quoteIdentifier(dbType, idColumn); err != nil {
+			return "", nil, err
+		}
+	}
+
+	switch dbType {
+	case DATABASE_TYPE_POSTGRES:
+		sqlStr += " VALUES (" + placeholders + ") RETURNING " + quotedIDColumn
+	case DATABASE_TYPE_MSSQL:
+		sqlStr += " OUTPUT INSERTED." + quotedIDColumn + " VALUES (" + placeholders + ")"
+	default:
+		sqlStr += " VALUES (" + placeholders + ")"
+	}
+
+	return Rebind(dbType, sqlStr), args, nil
+}
@@ -0,0 +1,36 @@
+package database
+
+import "testing"
+
+func TestInsertSQL(t *testing.T) {
+	row := map[string]any{"name": "John", "email": "john@example.com"}
+
+	tests := []struct {
+		dbType   string
+		expected string
+	}{
+		{DATABASE_TYPE_POSTGRES, `INSERT INTO "users" ("email", "name") VALUES ($1, $2) RETURNING "user_id"`},
+		{DATABASE_TYPE_MSSQL, `INSERT INTO [users] ([email], [name]) OUTPUT INSERTED.[user_id] VALUES (@p1, @p2)`},
+		{DATABASE_TYPE_MYSQL, "INSERT INTO `users` (`email`, `name`) VALUES (?, ?)"},
+		{DATABASE_TYPE_SQLITE, `INSERT INTO "users" ("email", "name") VALUES (?, ?)`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dbType, func(t *testing.T) {
+			sqlStr, args, err := insertSQL(tt.dbType, "users", "user_id", row)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if sqlStr != tt.expected {
+				t.Errorf("Expected:\n%s\ngot:\n%s", tt.expected, sqlStr)
+			}
+			if len(args) != 2 || args[0] != "john@example.com" {
+				t.Errorf("Unexpected args: %v", args)
+			}
+		})
+	}
+
+	if _, _, err := insertSQL(DATABASE_TYPE_SQLITE, "users", "id", map[string]any{}); err == nil {
+		t.Error("Expected error for empty row")
+	}
+}
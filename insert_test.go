@@ -0,0 +1,51 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	database "github.com/dracory/database"
+)
+
+func TestInsert(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	err = createUserTableAndInserTesttData(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Test nil querier error
+	_, err = database.Insert(database.Context(context.Background(), nil), "users", map[string]any{"name": "Dave"})
+	if err == nil || err.Error() != "querier (db/tx/conn) is nil" {
+		t.Errorf("Expected nil querier error, got %v", err)
+	}
+
+	ctx := database.Context(context.Background(), db)
+
+	id, err := database.Insert(ctx, "users", map[string]any{"name": "Dave", "email": "dave@example.com"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if id != 4 {
+		t.Errorf("Expected id 4, got %d", id)
+	}
+
+	name, _, err := database.SelectOne[string](ctx, "SELECT name FROM users WHERE id = ?", id)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if name != "Dave" {
+		t.Errorf("Expected name 'Dave', got %q", name)
+	}
+
+	// Test invalid row
+	_, err = database.Insert(ctx, "users", map[string]any{})
+	if err == nil {
+		t.Error("Expected error for empty row")
+	}
+}
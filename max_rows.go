@@ -0,0 +1,47 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrMaxRowsExceeded is returned when a query returns more rows
+// than the limit of the context (see WithMaxRows).
+var ErrMaxRowsExceeded = errors.New("max rows exceeded")
+
+// WithMaxRows returns a copy of the context, in which SelectToMapAny,
+// SelectToMapString (and their variants) and SelectOrdered return an error
+// wrapping ErrMaxRowsExceeded once the query returns more than n rows,
+// instead of reading all of them into memory. This is a guardrail for
+// queries supplied by the users, i.e. in admin tools.
+//
+// The rows are not returned partially. To read a part of a large result,
+// add a LIMIT to the query, or use SelectPage or SelectKeyset.
+//
+// Example:
+//
+//	qCtx := database.Context(ctx, db).WithMaxRows(10000)
+//	rows, err := database.SelectToMapAny(qCtx, userSQL)
+//	if errors.Is(err, database.ErrMaxRowsExceeded) {
+//		return errors.New("the query returns too many rows, add a LIMIT")
+//	}
+//
+// Parameters:
+// - n: The maximum number of rows, zero or less for no limit.
+//
+// Returns:
+// - QueryableContext: A new context with the limit.
+func (ctx QueryableContext) WithMaxRows(n int) QueryableContext {
+	ctx.maxRows = max(n, 0)
+	return ctx
+}
+
+// checkMaxRows returns an error if another row can not be read,
+// as count rows were already read and the limit is maxRows
+func checkMaxRows(count int, maxRows int) error {
+	if maxRows > 0 && count >= maxRows {
+		return fmt.Errorf("%w: the limit is %d rows", ErrMaxRowsExceeded, maxRows)
+	}
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	database "github.com/dracory/database"
+)
+
+func TestWithMaxRows(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	if err := createUserTableAndInserTesttData(db); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := database.Context(context.Background(), db).WithMaxRows(2)
+
+	if _, err := database.SelectToMapAny(ctx, "SELECT * FROM users"); !errors.Is(err, database.ErrMaxRowsExceeded) {
+		t.Errorf("Expected ErrMaxRowsExceeded, got %v", err)
+	}
+
+	if _, err := database.SelectToMapString(ctx, "SELECT * FROM users"); !errors.Is(err, database.ErrMaxRowsExceeded) {
+		t.Errorf("Expected ErrMaxRowsExceeded, got %v", err)
+	}
+
+	if _, _, err := database.SelectOrdered(ctx, "SELECT * FROM users"); !errors.Is(err, database.ErrMaxRowsExceeded) {
+		t.Errorf("Expected ErrMaxRowsExceeded, got %v", err)
+	}
+
+	rows, err := database.SelectToMapAny(ctx, "SELECT * FROM users WHERE id <= 2")
+	if err != nil {
+		t.Fatalf("Unexpected error for rows within the limit: %v", err)
+	}
+
+	if len(rows) != 2 {
+		t.Errorf("Expected 2 rows, got %d", len(rows))
+	}
+
+	rows, err = database.SelectToMapAny(ctx.WithMaxRows(0), "SELECT * FROM users")
+	if err != nil {
+		t.Fatalf("Unexpected error without a limit: %v", err)
+	}
+
+	if len(rows) != 3 {
+		t.Errorf("Expected 3 rows, got %d", len(rows))
+	}
+}
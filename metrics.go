@@ -0,0 +1,57 @@
+package database
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Metrics collects metrics about the SQL statements executed through the
+// package helpers, i.e. total queries, errors and durations, labeled by
+// operation and dialect.
+//
+// It allows plugging in any collector (i.e. Prometheus) without the package
+// depending on it.
+type Metrics interface {
+	// ObserveQuery is called after each statement, and for the Select helpers
+	// after the rows are read.
+	//
+	// Parameters:
+	// - op: The helper which executed the statement, i.e. "Query", "Execute".
+	// - dialect: The database type, i.e. "mysql", "postgres", "sqlite".
+	// - d: The duration of the statement.
+	// - err: The error of the statement, or nil.
+	ObserveQuery(op string, dialect string, d time.Duration, err error)
+}
+
+// queryMetrics is the package level metrics collector, nil if not set
+var queryMetrics atomic.Pointer[Metrics]
+
+// SetMetrics sets a package level metrics collector, which is called after
+// each statement executed through the package helpers.
+//
+// Setting it to nil removes the collector. It is safe for concurrent use.
+//
+// Example:
+//
+//	type promMetrics struct{}
+//
+//	func (promMetrics) ObserveQuery(op string, dialect string, d time.Duration, err error) {
+//		queriesTotal.WithLabelValues(op, dialect).Inc()
+//		if err != nil {
+//			queryErrorsTotal.WithLabelValues(op, dialect).Inc()
+//		}
+//		queryDuration.WithLabelValues(op, dialect).Observe(d.Seconds())
+//	}
+//
+//	database.SetMetrics(promMetrics{})
+//
+// Parameters:
+// - metrics: The metrics collector, or nil.
+func SetMetrics(metrics Metrics) {
+	if metrics == nil {
+		queryMetrics.Store(nil)
+		return
+	}
+
+	queryMetrics.Store(&metrics)
+}
@@ -0,0 +1,77 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	database "github.com/dracory/database"
+)
+
+type observedQuery struct {
+	op      string
+	dialect string
+	d       time.Duration
+	err     error
+}
+
+type testMetrics struct {
+	observed []observedQuery
+}
+
+func (m *testMetrics) ObserveQuery(op string, dialect string, d time.Duration, err error) {
+	m.observed = append(m.observed, observedQuery{op: op, dialect: dialect, d: d, err: err})
+}
+
+func TestSetMetrics(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	err = createUserTableAndInserTesttData(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	metrics := &testMetrics{}
+	database.SetMetrics(metrics)
+	defer database.SetMetrics(nil)
+
+	ctx := database.Context(context.Background(), db)
+
+	_, err = database.Execute(ctx, "UPDATE users SET name = ? WHERE id = ?", "Updated", 1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	_, err = database.SelectToMapAny(ctx, "INVALID SQL")
+	if err == nil {
+		t.Fatal("Expected error for invalid SQL")
+	}
+
+	if len(metrics.observed) != 2 {
+		t.Fatalf("Expected 2 observations, got %d", len(metrics.observed))
+	}
+
+	if metrics.observed[0].op != "Execute" || metrics.observed[0].dialect != database.DATABASE_TYPE_SQLITE || metrics.observed[0].err != nil {
+		t.Errorf("Unexpected execute observation: %+v", metrics.observed[0])
+	}
+
+	if metrics.observed[1].op != "SelectToMapAny" || metrics.observed[1].err == nil {
+		t.Errorf("Expected failed SelectToMapAny observation, got %+v", metrics.observed[1])
+	}
+
+	// Test the collector can be removed
+	database.SetMetrics(nil)
+
+	_, err = database.Execute(ctx, "DELETE FROM users")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(metrics.observed) != 2 {
+		t.Errorf("Expected no more observations after removing the collector, got %d", len(metrics.observed))
+	}
+}
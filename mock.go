@@ -0,0 +1,407 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// MockQueryable is a QueryableInterface for unit tests, which returns canned
+// rows, results and errors for the expected statements, instead of executing
+// them on a database. It allows testing code using the package helpers
+// without a database.
+//
+// The statements are expected in the order of the expectations, the SQL is
+// compared ignoring differences in whitespace, and the arguments only if set
+// with WithArgs. An unexpected statement returns an error.
+//
+// The mock is neither a DB nor a Tx, so Transaction and BeginTx cannot be
+// used with it. DatabaseType returns the type set with SetDatabaseType,
+// SQLite by default, which determines the SQL built by the helpers.
+//
+// Example usage:
+//
+//	mock := database.NewMockQueryable()
+//	defer mock.Close()
+//
+//	mock.ExpectQuery("SELECT id, name FROM users WHERE id = ?").
+//		WithArgs(1).
+//		WillReturnRows([]string{"id", "name"}, []any{1, "Alice"})
+//	mock.ExpectExec("DELETE FROM users WHERE id = ?").
+//		WithArgs(1).
+//		WillReturnResult(0, 1)
+//
+//	ctx := database.Context(context.Background(), mock)
+//	// call the code under test with ctx
+//
+//	if err := mock.ExpectationsWereMet(); err != nil {
+//		t.Error(err)
+//	}
+type MockQueryable struct {
+	mu           sync.Mutex
+	dbType       string
+	expectations []*MockExpectation
+	unexpected   []string
+
+	// db is backed by a mock driver returning the canned rows,
+	// as *sql.Rows cannot be created otherwise
+	db          *sql.DB
+	pendingRows map[string]*MockExpectation
+	rowsCounter int
+}
+
+// Verify that MockQueryable implements the QueryableInterface
+var _ QueryableInterface = &MockQueryable{}
+
+// MockExpectation is an expected statement of a MockQueryable,
+// with the rows, result or error to return for it.
+type MockExpectation struct {
+	isQuery bool
+	sqlStr  string
+	args    []any
+	hasArgs bool
+	columns []string
+	rows    [][]any
+	result  sql.Result
+	err     error
+}
+
+// NewMockQueryable returns a new MockQueryable without expectations.
+//
+// Returns:
+// - *MockQueryable: The mock queryable.
+func NewMockQueryable() *MockQueryable {
+	m := &MockQueryable{
+		dbType:      DATABASE_TYPE_SQLITE,
+		pendingRows: map[string]*MockExpectation{},
+	}
+
+	m.db = sql.OpenDB(mockConnector{mock: m})
+
+	return m
+}
+
+// SetDatabaseType sets the database type returned by DatabaseType for the mock,
+// i.e. to test the SQL built by the helpers for Postgres.
+func (m *MockQueryable) SetDatabaseType(dbType string) *MockQueryable {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.dbType = dbType
+
+	return m
+}
+
+// DatabaseType returns the database type of the mock, see SetDatabaseType
+func (m *MockQueryable) DatabaseType() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.dbType
+}
+
+// ExpectQuery adds an expected query, executed with QueryContext
+// or QueryRowContext. It returns no rows, unless set with WillReturnRows.
+func (m *MockQueryable) ExpectQuery(sqlStr string) *MockExpectation {
+	return m.expect(&MockExpectation{isQuery: true, sqlStr: sqlStr})
+}
+
+// ExpectExec adds an expected statement, executed with ExecContext.
+// It returns a result with no affected rows, unless set with WillReturnResult.
+func (m *MockQueryable) ExpectExec(sqlStr string) *MockExpectation {
+	return m.expect(&MockExpectation{sqlStr: sqlStr, result: mockResult{}})
+}
+
+func (m *MockQueryable) expect(expectation *MockExpectation) *MockExpectation {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.expectations = append(m.expectations, expectation)
+
+	return expectation
+}
+
+// ExpectationsWereMet returns an error if there are expected statements which
+// were not executed, or statements were executed which were not expected.
+func (m *MockQueryable) ExpectationsWereMet() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	problems := append([]string{}, m.unexpected...)
+
+	for _, expectation := range m.expectations {
+		problems = append(problems, "expected statement was not executed: "+expectation.sqlStr)
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+
+	return errors.New("mock: " + strings.Join(problems, "; "))
+}
+
+// AssertExpectations reports an error on t (i.e. *testing.T) if the
+// expectations were not met, see ExpectationsWereMet.
+func (m *MockQueryable) AssertExpectations(t interface {
+	Helper()
+	Errorf(format string, args ...any)
+}) {
+	t.Helper()
+
+	if err := m.ExpectationsWereMet(); err != nil {
+		t.Errorf("%v", err)
+	}
+}
+
+// Close releases the resources of the mock.
+func (m *MockQueryable) Close() error {
+	return m.db.Close()
+}
+
+// WithArgs sets the arguments the statement is expected to be executed with.
+func (e *MockExpectation) WithArgs(args ...any) *MockExpectation {
+	e.args = args
+	e.hasArgs = true
+	return e
+}
+
+// WillReturnRows sets the columns and the rows to return for the query.
+func (e *MockExpectation) WillReturnRows(columns []string, rows ...[]any) *MockExpectation {
+	e.columns = columns
+	e.rows = rows
+	return e
+}
+
+// WillReturnResult sets the last insert id and the number of affected rows
+// to return for the statement.
+func (e *MockExpectation) WillReturnResult(lastInsertID int64, rowsAffected int64) *MockExpectation {
+	e.result = mockResult{lastInsertID: lastInsertID, rowsAffected: rowsAffected}
+	return e
+}
+
+// WillReturnError sets the error to return for the statement.
+func (e *MockExpectation) WillReturnError(err error) *MockExpectation {
+	e.err = err
+	return e
+}
+
+// ExecContext returns the result or the error of the next expectation,
+// which must be an ExpectExec matching the statement.
+func (m *MockQueryable) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	expectation, err := m.next(false, query, args)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return expectation.result, nil
+}
+
+// PrepareContext is not supported by the mock, and returns an error.
+func (m *MockQueryable) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return nil, errors.New("mock: prepared statements are not supported")
+}
+
+// QueryContext returns the rows or the error of the next expectation,
+// which must be an ExpectQuery matching the query.
+func (m *MockQueryable) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	expectation, err := m.next(true, query, args)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return m.db.QueryContext(ctx, m.pending(expectation))
+}
+
+// QueryRowContext works like QueryContext, returning the first row.
+func (m *MockQueryable) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	expectation, err := m.next(true, query, args)
+
+	if err != nil {
+		return errorRow(err)
+	}
+
+	return m.db.QueryRowContext(ctx, m.pending(expectation))
+}
+
+// next removes and returns the next expectation, checking that it matches
+// the statement, and returns the error of the expectation if set
+func (m *MockQueryable) next(isQuery bool, query string, args []any) (*MockExpectation, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.expectations) == 0 {
+		return nil, m.unexpectedf("unexpected statement, no more expected: %s", query)
+	}
+
+	expectation := m.expectations[0]
+
+	if expectation.isQuery != isQuery || normalizeMockSQL(expectation.sqlStr) != normalizeMockSQL(query) {
+		return nil, m.unexpectedf("unexpected statement: %s, expected: %s", query, expectation.sqlStr)
+	}
+
+	if expectation.hasArgs && !mockArgsEqual(expectation.args, args) {
+		return nil, m.unexpectedf("unexpected arguments for statement: %s, got %v, expected %v", query, args, expectation.args)
+	}
+
+	m.expectations = m.expectations[1:]
+
+	if expectation.err != nil {
+		return nil, expectation.err
+	}
+
+	return expectation, nil
+}
+
+// unexpectedf records the unexpected statement, and returns it as an error
+func (m *MockQueryable) unexpectedf(format string, args ...any) error {
+	problem := fmt.Sprintf(format, args...)
+	m.unexpected = append(m.unexpected, problem)
+	return errors.New("mock: " + problem)
+}
+
+// pending registers the rows of the expectation with the mock driver,
+// and returns the query to select them with
+func (m *MockQueryable) pending(expectation *MockExpectation) string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.rowsCounter++
+	key := "mock rows " + strconv.Itoa(m.rowsCounter)
+	m.pendingRows[key] = expectation
+
+	return key
+}
+
+// normalizeMockSQL collapses the whitespace of the SQL, so that
+// formatting differences do not fail the comparison
+func normalizeMockSQL(sqlStr string) string {
+	return strings.Join(strings.Fields(sqlStr), " ")
+}
+
+func mockArgsEqual(expected []any, actual []any) bool {
+	if len(expected) != len(actual) {
+		return false
+	}
+
+	for i := range expected {
+		if !reflect.DeepEqual(expected[i], actual[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// mockResult is the sql.Result of the mock
+type mockResult struct {
+	lastInsertID int64
+	rowsAffected int64
+}
+
+func (r mockResult) LastInsertId() (int64, error) {
+	return r.lastInsertID, nil
+}
+
+func (r mockResult) RowsAffected() (int64, error) {
+	return r.rowsAffected, nil
+}
+
+// mockConnector connects the *sql.DB of the mock to the mock driver
+type mockConnector struct {
+	mock *MockQueryable
+}
+
+func (c mockConnector) Connect(context.Context) (driver.Conn, error) {
+	return &mockConn{mock: c.mock}, nil
+}
+
+func (c mockConnector) Driver() driver.Driver {
+	return mockDriver{}
+}
+
+type mockDriver struct{}
+
+func (mockDriver) Open(name string) (driver.Conn, error) {
+	return nil, errors.New("mock: use NewMockQueryable")
+}
+
+// mockConn returns the rows registered with the mock for a query
+type mockConn struct {
+	mock *MockQueryable
+}
+
+func (c *mockConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("mock: prepared statements are not supported")
+}
+
+func (c *mockConn) Close() error {
+	return nil
+}
+
+func (c *mockConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("mock: transactions are not supported")
+}
+
+func (c *mockConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.mock.mu.Lock()
+	defer c.mock.mu.Unlock()
+
+	expectation, ok := c.mock.pendingRows[query]
+
+	if !ok {
+		return nil, errors.New("mock: no rows registered for: " + query)
+	}
+
+	delete(c.mock.pendingRows, query)
+
+	return &mockRows{columns: expectation.columns, rows: expectation.rows}, nil
+}
+
+// mockRows iterates the canned rows of an expectation
+type mockRows struct {
+	columns []string
+	rows    [][]any
+	index   int
+}
+
+func (r *mockRows) Columns() []string {
+	return r.columns
+}
+
+func (r *mockRows) Close() error {
+	return nil
+}
+
+func (r *mockRows) Next(dest []driver.Value) error {
+	if r.index >= len(r.rows) {
+		return io.EOF
+	}
+
+	row := r.rows[r.index]
+	r.index++
+
+	if len(row) != len(r.columns) {
+		return errors.New("mock: row " + strconv.Itoa(r.index-1) + " has " + strconv.Itoa(len(row)) +
+			" values, expected " + strconv.Itoa(len(r.columns)))
+	}
+
+	for i, value := range row {
+		converted, err := driver.DefaultParameterConverter.ConvertValue(value)
+		if err != nil {
+			return err
+		}
+
+		dest[i] = converted
+	}
+
+	return nil
+}
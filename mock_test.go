@@ -0,0 +1,141 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	database "github.com/dracory/database"
+)
+
+func TestMockQueryable(t *testing.T) {
+	mock := database.NewMockQueryable()
+	defer mock.Close()
+
+	mock.ExpectQuery("SELECT id, name FROM users WHERE id > ?").
+		WithArgs(0).
+		WillReturnRows([]string{"id", "name"}, []any{1, "Alice"}, []any{2, "Bob"})
+	mock.ExpectQuery("SELECT COUNT(*) FROM users").
+		WillReturnRows([]string{"count"}, []any{2})
+	mock.ExpectExec("DELETE FROM users WHERE id = ?").
+		WithArgs(1).
+		WillReturnResult(0, 1)
+
+	ctx := database.Context(context.Background(), mock)
+
+	users, err := database.SelectToStructs[testUser](ctx, "SELECT id, name\n  FROM users WHERE id > ?", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(users) != 2 || users[0].FullName != "Alice" || users[1].ID != 2 {
+		t.Errorf("Expected the canned users, got %+v", users)
+	}
+
+	count, err := database.Count(ctx, "SELECT COUNT(*) FROM users")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if count != 2 {
+		t.Errorf("Expected count 2, got %d", count)
+	}
+
+	result, err := database.Execute(ctx, "DELETE FROM users WHERE id = ?", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if affected, _ := result.RowsAffected(); affected != 1 {
+		t.Errorf("Expected 1 affected row, got %d", affected)
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestMockQueryableQueryRowAndInsert(t *testing.T) {
+	mock := database.NewMockQueryable()
+	defer mock.Close()
+
+	mock.ExpectQuery("SELECT name FROM users WHERE id = ?").
+		WillReturnRows([]string{"name"}, []any{"Alice"})
+	mock.ExpectExec(`INSERT INTO "users" ("name") VALUES (?)`).
+		WithArgs("Bob").
+		WillReturnResult(7, 1)
+
+	ctx := database.Context(context.Background(), mock)
+
+	var name string
+	if err := database.QueryRow(ctx, "SELECT name FROM users WHERE id = ?", 1).Scan(&name); err != nil {
+		t.Fatal(err)
+	}
+
+	if name != "Alice" {
+		t.Errorf("Expected Alice, got %q", name)
+	}
+
+	id, err := database.Insert(ctx, "users", map[string]any{"name": "Bob"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if id != 7 {
+		t.Errorf("Expected id 7, got %d", id)
+	}
+
+	mock.AssertExpectations(t)
+}
+
+func TestMockQueryableErrors(t *testing.T) {
+	mock := database.NewMockQueryable()
+	defer mock.Close()
+
+	errFailed := errors.New("connection lost")
+
+	mock.ExpectExec("UPDATE users SET name = ?").WillReturnError(errFailed)
+	mock.ExpectQuery("SELECT * FROM users")
+
+	ctx := database.Context(context.Background(), mock)
+
+	if _, err := database.Execute(ctx, "UPDATE users SET name = ?", "Alice"); !errors.Is(err, errFailed) {
+		t.Errorf("Expected the canned error, got %v", err)
+	}
+
+	if _, err := database.Execute(ctx, "DELETE FROM users"); err == nil {
+		t.Error("Expected an error for an unexpected statement")
+	}
+
+	err := mock.ExpectationsWereMet()
+
+	if err == nil {
+		t.Fatal("Expected unmet expectations")
+	}
+
+	if !strings.Contains(err.Error(), "DELETE FROM users") || !strings.Contains(err.Error(), "SELECT * FROM users") {
+		t.Errorf("Expected the unexpected and the missing statements in the error, got %v", err)
+	}
+}
+
+func TestMockQueryableDatabaseType(t *testing.T) {
+	mock := database.NewMockQueryable().SetDatabaseType(database.DATABASE_TYPE_POSTGRES)
+	defer mock.Close()
+
+	if database.DatabaseType(mock) != database.DATABASE_TYPE_POSTGRES {
+		t.Errorf("Expected %q, got %q", database.DATABASE_TYPE_POSTGRES, database.DatabaseType(mock))
+	}
+
+	mock.ExpectQuery(`INSERT INTO "users" ("name") VALUES ($1) RETURNING "id"`).
+		WillReturnRows([]string{"id"}, []any{int64(3)})
+
+	id, err := database.Insert(database.Context(context.Background(), mock), "users", map[string]any{"name": "Bob"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if id != 3 {
+		t.Errorf("Expected id 3, got %d", id)
+	}
+
+	mock.AssertExpectations(t)
+}
@@ -0,0 +1,171 @@
+package database
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+)
+
+// BindNamed converts the :name placeholders in the SQL query into positional
+// ? placeholders, and returns the arguments in the matching order.
+//
+// The params can be a map[string]any, or a struct (or a pointer to a struct),
+// whose fields are matched the same way as in SelectToStructs, i.e. by the
+// `db` struct tag or the snake_case of the field name.
+//
+// Repeated parameter names are supported, the value is added once per
+// occurrence. Postgres casts (::type), string literals, quoted identifiers
+// and comments are left untouched. Use Rebind to convert the positional
+// placeholders to the placeholder style of the database type.
+//
+// Example usage:
+//
+//	sqlStr, args, err := BindNamed("SELECT * FROM users WHERE name = :name AND age > :age", map[string]any{
+//		"name": "John",
+//		"age":  18,
+//	})
+//	// SELECT * FROM users WHERE name = ? AND age > ?
+//	// []any{"John", 18}
+//
+// Parameters:
+// - sqlStr (string): The SQL query with :name placeholders.
+// - params (any): A map[string]any or a struct with the parameter values.
+//
+// Returns:
+// - string: The SQL query with ? placeholders.
+// - []any: The arguments in the order of the placeholders.
+// - error: An error if the params are not supported, or a parameter is missing.
+func BindNamed(sqlStr string, params any) (string, []any, error) {
+	lookup, err := namedParamsLookup(params)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var b strings.Builder
+	b.Grow(len(sqlStr))
+
+	args := []any{}
+	missing := []string{}
+
+	for i := 0; i < len(sqlStr); i++ {
+		if end := skipLiteralOrComment(sqlStr, i, false); end > i {
+			b.WriteString(sqlStr[i:end])
+			i = end - 1
+			continue
+		}
+
+		c := sqlStr[i]
+
+		// Postgres cast, i.e. value::text
+		if c == ':' && i+1 < len(sqlStr) && sqlStr[i+1] == ':' {
+			b.WriteString("::")
+			i++
+			continue
+		}
+
+		if c != ':' || i+1 >= len(sqlStr) || !isNamedParamChar(sqlStr[i+1]) {
+			b.WriteByte(c)
+			continue
+		}
+
+		end := i + 1
+		for end < len(sqlStr) && isNamedParamChar(sqlStr[end]) {
+			end++
+		}
+
+		name := sqlStr[i+1 : end]
+		value, ok := lookup(name)
+
+		if !ok {
+			missing = append(missing, name)
+		}
+
+		args = append(args, value)
+		b.WriteByte('?')
+		i = end - 1
+	}
+
+	if len(missing) > 0 {
+		return "", nil, errors.New("missing named parameters: " + strings.Join(missing, ", "))
+	}
+
+	return b.String(), args, nil
+}
+
+// SelectToMapAnyNamed works like SelectToMapAny, but the SQL query uses :name
+// placeholders, which are bound from the params (see BindNamed) and converted
+// to the placeholder style of the database type (see Rebind).
+//
+// Example usage:
+//
+//	listMap, err := SelectToMapAnyNamed(ctx, "SELECT * FROM users WHERE name = :name", map[string]any{"name": "John"})
+//
+// Parameters:
+// - ctx (QueryableContext): The context to use for the query execution.
+// - sqlStr (string): The SQL query with :name placeholders.
+// - params (any): A map[string]any or a struct with the parameter values.
+//
+// Returns:
+// - []map[string]any: A slice of maps containing the query results.
+// - error: An error if the query failed, or a parameter is missing.
+func SelectToMapAnyNamed(ctx QueryableContext, sqlStr string, params any) ([]map[string]any, error) {
+	if ctx.queryable == nil {
+		return []map[string]any{}, ErrNoQueryable
+	}
+
+	boundSQL, args, err := BindNamed(sqlStr, params)
+	if err != nil {
+		return []map[string]any{}, err
+	}
+
+	return selectToMapAny(ctx, "SelectToMapAnyNamed", SelectToMapAnyOptions{}, Rebind(ctx.databaseType(), boundSQL), args...)
+}
+
+// namedParamsLookup returns a function to look up the named parameters
+// in a map[string]any or a struct.
+func namedParamsLookup(params any) (func(name string) (any, bool), error) {
+	if params == nil {
+		return func(name string) (any, bool) { return nil, false }, nil
+	}
+
+	if m, ok := params.(map[string]any); ok {
+		return func(name string) (any, bool) {
+			value, ok := m[name]
+			return value, ok
+		}, nil
+	}
+
+	v := reflect.ValueOf(params)
+
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil, errors.New("named parameters must not be a nil pointer")
+		}
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		return nil, errors.New("named parameters must be a map[string]any or a struct, got " + v.Type().String())
+	}
+
+	fields := structFields(v.Type())
+
+	return func(name string) (any, bool) {
+		index, ok := fields[name]
+		if !ok {
+			return nil, false
+		}
+
+		field, err := v.FieldByIndexErr(index)
+		if err != nil {
+			// Nil embedded struct pointer
+			return nil, true
+		}
+
+		return field.Interface(), true
+	}, nil
+}
+
+func isNamedParamChar(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
@@ -0,0 +1,109 @@
+package database_test
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	database "github.com/dracory/database"
+)
+
+func TestBindNamed(t *testing.T) {
+	// Test map params with a repeated name
+	sqlStr, args, err := database.BindNamed("SELECT * FROM users WHERE name = :name OR nick = :name AND age > :age", map[string]any{
+		"name": "John",
+		"age":  18,
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if sqlStr != "SELECT * FROM users WHERE name = ? OR nick = ? AND age > ?" {
+		t.Errorf("Unexpected SQL: %s", sqlStr)
+	}
+	if !reflect.DeepEqual(args, []any{"John", "John", 18}) {
+		t.Errorf("Unexpected args: %v", args)
+	}
+
+	// Test struct params
+	type params struct {
+		Name   string `db:"name"`
+		MinAge int
+	}
+	sqlStr, args, err = database.BindNamed("SELECT * FROM users WHERE name = :name AND age > :min_age", params{Name: "Jane", MinAge: 21})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if sqlStr != "SELECT * FROM users WHERE name = ? AND age > ?" {
+		t.Errorf("Unexpected SQL: %s", sqlStr)
+	}
+	if !reflect.DeepEqual(args, []any{"Jane", 21}) {
+		t.Errorf("Unexpected args: %v", args)
+	}
+
+	// Test casts, literals and comments are left untouched
+	sqlStr, args, err = database.BindNamed("SELECT id::text, ':skip' FROM users /* :skip */ WHERE id = :id", map[string]any{"id": 1})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if sqlStr != "SELECT id::text, ':skip' FROM users /* :skip */ WHERE id = ?" {
+		t.Errorf("Unexpected SQL: %s", sqlStr)
+	}
+	if len(args) != 1 {
+		t.Errorf("Expected 1 arg, got %d", len(args))
+	}
+
+	// Test unsupported params
+	_, _, err = database.BindNamed("SELECT * FROM users WHERE id = :id", 1)
+	if err == nil {
+		t.Error("Expected error for unsupported params")
+	}
+
+	// Test missing parameters
+	_, _, err = database.BindNamed("SELECT * FROM users WHERE name = :name AND age > :age", map[string]any{})
+	if err == nil {
+		t.Fatal("Expected error for missing parameters")
+	}
+	if !strings.Contains(err.Error(), "name") || !strings.Contains(err.Error(), "age") {
+		t.Errorf("Expected error to list missing parameters, got: %v", err)
+	}
+}
+
+func TestSelectToMapAnyNamed(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	err = createUserTableAndInserTesttData(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	operations := []string{}
+	database.SetLogger(func(info database.QueryLog) {
+		operations = append(operations, info.Operation)
+	})
+	defer database.SetLogger(nil)
+
+	result, err := database.SelectToMapAnyNamed(database.Context(context.Background(), db), "SELECT * FROM users WHERE name = :name OR email = :email", map[string]any{
+		"name":  "Bob",
+		"email": "alice@example.com",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(result) != 2 {
+		t.Errorf("Expected 2 rows, got %d", len(result))
+	}
+	if len(operations) != 1 || operations[0] != "SelectToMapAnyNamed" {
+		t.Errorf("Expected the query to be logged as SelectToMapAnyNamed, got %v", operations)
+	}
+
+	// Test nil querier error
+	_, err = database.SelectToMapAnyNamed(database.Context(context.Background(), nil), "SELECT * FROM users", nil)
+	if err == nil {
+		t.Error("Expected error for nil querier")
+	}
+}
@@ -0,0 +1,53 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// ErrNoQueryable is returned by the helpers when the context carries no
+// queryable (DB, Tx or Conn), and by all the methods of NullQueryable.
+//
+// It distinguishes a missing database from a failed query:
+//
+//	if errors.Is(err, database.ErrNoQueryable) {
+//		// no database is configured
+//	}
+var ErrNoQueryable = errors.New("querier (db/tx/conn) is nil")
+
+// NullQueryable is a QueryableInterface without a database, all its methods
+// return ErrNoQueryable. It is the queryable of a context created without one,
+// i.e. QueryableContext.Queryable returns it instead of nil, so that calling it
+// returns an error instead of panicking.
+//
+// Passing a NullQueryable to Context is the same as passing nil.
+type NullQueryable struct{}
+
+// Verify that NullQueryable implements the QueryableInterface
+var _ QueryableInterface = NullQueryable{}
+
+// ExecContext returns ErrNoQueryable.
+func (NullQueryable) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return nil, ErrNoQueryable
+}
+
+// PrepareContext returns ErrNoQueryable.
+func (NullQueryable) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return nil, ErrNoQueryable
+}
+
+// QueryContext returns ErrNoQueryable.
+func (NullQueryable) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return nil, ErrNoQueryable
+}
+
+// QueryRowContext returns a row, which returns ErrNoQueryable from Scan.
+func (NullQueryable) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	return errorRow(ErrNoQueryable)
+}
+
+// DatabaseType returns an empty string, as there is no database.
+func (NullQueryable) DatabaseType() string {
+	return ""
+}
@@ -0,0 +1,58 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	database "github.com/dracory/database"
+)
+
+func TestNullQueryable(t *testing.T) {
+	q := database.NullQueryable{}
+	ctx := context.Background()
+
+	if _, err := q.ExecContext(ctx, "DELETE FROM users"); !errors.Is(err, database.ErrNoQueryable) {
+		t.Errorf("ExecContext: expected ErrNoQueryable, got %v", err)
+	}
+
+	if _, err := q.QueryContext(ctx, "SELECT 1"); !errors.Is(err, database.ErrNoQueryable) {
+		t.Errorf("QueryContext: expected ErrNoQueryable, got %v", err)
+	}
+
+	if _, err := q.PrepareContext(ctx, "SELECT 1"); !errors.Is(err, database.ErrNoQueryable) {
+		t.Errorf("PrepareContext: expected ErrNoQueryable, got %v", err)
+	}
+
+	var one int
+	if err := q.QueryRowContext(ctx, "SELECT 1").Scan(&one); !errors.Is(err, database.ErrNoQueryable) {
+		t.Errorf("QueryRowContext: expected ErrNoQueryable, got %v", err)
+	}
+}
+
+func TestContextWithoutQueryable(t *testing.T) {
+	for _, qCtx := range []database.QueryableContext{
+		database.Context(context.Background(), nil),
+		database.Context(context.Background(), database.NullQueryable{}),
+	} {
+		if _, ok := qCtx.Queryable().(database.NullQueryable); !ok {
+			t.Errorf("Expected a NullQueryable, got %T", qCtx.Queryable())
+		}
+
+		if qCtx.IsDB() || qCtx.IsTx() || qCtx.IsConn() {
+			t.Error("Expected the context to carry no DB, Tx or Conn")
+		}
+
+		if _, err := database.Execute(qCtx, "DELETE FROM users"); !errors.Is(err, database.ErrNoQueryable) {
+			t.Errorf("Execute: expected ErrNoQueryable, got %v", err)
+		}
+
+		if _, err := database.SelectToMapString(qCtx, "SELECT * FROM users"); !errors.Is(err, database.ErrNoQueryable) {
+			t.Errorf("SelectToMapString: expected ErrNoQueryable, got %v", err)
+		}
+
+		if _, err := qCtx.Queryable().QueryContext(qCtx, "SELECT 1"); !errors.Is(err, database.ErrNoQueryable) {
+			t.Errorf("Queryable().QueryContext: expected ErrNoQueryable, got %v", err)
+		}
+	}
+}
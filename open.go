@@ -1,8 +1,13 @@
 package database
 
 import (
+	"context"
+	"crypto/tls"
 	"database/sql"
 	"errors"
+	"net/url"
+	"os"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -30,10 +35,21 @@ import (
 // ```
 // _ "github.com/jackc/pgx/v5/stdlib"
 // ```
+// - mssql add the following includes:
+// ```
+// _ "github.com/microsoft/go-mssqldb"
+// ```
 //
 // Business logic:
+//   - checks that the driver is registered, the error names the import needed
 //   - opens the database based on the driver name
+//   - runs the SQLite pragmas of the options and the connect init statements,
+//     if any, on every new connection
 //   - each driver has its own set of parameters
+//   - for MySQL, Postgres and MSSQL the pool defaults to 5 open and 5 idle
+//     connections, 5s idle time and 30s lifetime, for SQLite the database/sql
+//     defaults are used
+//   - pool settings set in the options (non-zero) override the defaults
 //
 // Parameters:
 // - options openOptionsInterface
@@ -42,26 +58,44 @@ import (
 // - *sql.DB: the database connection
 // - error: the error if any
 func Open(options openOptionsInterface) (*sql.DB, error) {
+	return OpenContext(context.Background(), options)
+}
+
+// OpenContext opens the database like Open, but verifies the connectivity
+// with PingContext using the given context, so that an unreachable database
+// fails within the context deadline.
+//
+// If a connect timeout is set in the options, the ping is bounded by it,
+// and it is also passed to the driver via the DSN, so new connections
+// are bounded as well.
+//
+// Example:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+//	defer cancel()
+//
+//	db, err := database.OpenContext(ctx, database.Options().
+//		SetDatabaseType(database.DATABASE_TYPE_POSTGRES).
+//		// ...
+//		SetConnectTimeout(5*time.Second))
+//
+// Parameters:
+// - ctx context.Context: the context used to verify the connectivity
+// - options openOptionsInterface
+//
+// Returns:
+// - *sql.DB: the database connection
+// - error: the error if any
+func OpenContext(ctx context.Context, options openOptionsInterface) (*sql.DB, error) {
 	var db *sql.DB
-	var err error
 
-	err = options.Verify()
+	dsn, err := openDSN(options)
 
 	if err != nil {
 		return nil, err
 	}
 
 	databaseType := options.DatabaseType()
-	host := options.DatabaseHost()
-	port := options.DatabasePort()
-	databaseName := options.DatabaseName()
-	user := options.UserName()
-	pass := options.Password()
-	timezone := options.TimeZone()
-	charset := options.Charset()
-	sslMode := options.SSLMode()
-
-	dsn := dsn(databaseType, databaseName, user, pass, host, port, timezone, charset, sslMode)
 
 	db, err = sql.Open(databaseType, dsn)
 
@@ -73,7 +107,17 @@ func Open(options openOptionsInterface) (*sql.DB, error) {
 		return nil, errors.New("database for driver " + databaseType + " could not be intialized")
 	}
 
-	if databaseType == DATABASE_TYPE_MYSQL || databaseType == DATABASE_TYPE_POSTGRES || databaseType == DATABASE_TYPE_PGX {
+	initStatements := append(sqlitePragmaStatements(databaseType, options), options.ConnectInitStatements()...)
+
+	if len(initStatements) > 0 {
+		db, err = openWithInitStatements(db, dsn, initStatements)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if databaseType == DATABASE_TYPE_MYSQL || databaseType == DATABASE_TYPE_POSTGRES || databaseType == DATABASE_TYPE_PGX || databaseType == DATABASE_TYPE_MSSQL {
 		// Maximum Idle Connections
 		db.SetMaxIdleConns(5)
 		// Maximum Open Connections
@@ -84,15 +128,85 @@ func Open(options openOptionsInterface) (*sql.DB, error) {
 		db.SetConnMaxLifetime(30 * time.Second)
 	}
 
-	err = db.Ping()
+	// Pool settings explicitly set in the options take precedence
+	if options.MaxOpenConns() > 0 {
+		db.SetMaxOpenConns(options.MaxOpenConns())
+	}
+
+	if options.MaxIdleConns() > 0 {
+		db.SetMaxIdleConns(options.MaxIdleConns())
+	}
+
+	if options.ConnMaxLifetime() > 0 {
+		db.SetConnMaxLifetime(options.ConnMaxLifetime())
+	}
+
+	if options.ConnMaxIdleTime() > 0 {
+		db.SetConnMaxIdleTime(options.ConnMaxIdleTime())
+	}
+
+	if options.ConnectTimeout() > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, options.ConnectTimeout())
+		defer cancel()
+	}
+
+	err = db.PingContext(ctx)
 
 	if err != nil {
+		_ = db.Close()
 		return nil, errors.Join(errors.New("database for driver "+databaseType+" could not be pinged"), err)
 	}
 
 	return db, nil
 }
 
+// openDSN verifies the options, and returns the DSN to open the database
+// with. It fails before connecting, so its errors are not transient
+// (i.e. invalid options, TLS options or a driver which is not registered).
+func openDSN(options openOptionsInterface) (string, error) {
+	if err := options.Verify(); err != nil {
+		return "", err
+	}
+
+	databaseType := options.DatabaseType()
+	host := options.DatabaseHost()
+	port := options.DatabasePort()
+	databaseName := options.DatabaseName()
+	user := options.UserName()
+	pass := options.Password()
+	timezone := options.TimeZone()
+	charset := options.Charset()
+	sslMode := options.SSLMode()
+	socketPath := options.SocketPath()
+
+	// MySQL has no schemas apart from databases, the schema is the default database
+	if options.Schema() != "" && strings.EqualFold(databaseType, DATABASE_TYPE_MYSQL) {
+		databaseName = options.Schema()
+	}
+
+	dsn := dsn(databaseType, databaseName, user, pass, host, port, timezone, charset, sslMode, socketPath)
+
+	tlsParams, err := tlsDSNParams(options)
+
+	if err != nil {
+		return "", err
+	}
+
+	dsn += tlsParams
+	dsn += mysqlDSNParams(databaseType, options.Collation(), options.ParseTime())
+	dsn += connectTimeoutDSNParam(databaseType, options.ConnectTimeout())
+	dsn += applicationNameDSNParam(databaseType, options.ApplicationName())
+	dsn += schemaDSNParam(databaseType, options.Schema())
+	dsn += statementTimeoutDSNParam(databaseType, options.StatementTimeout())
+
+	if err := checkDriverRegistered(databaseType); err != nil {
+		return "", err
+	}
+
+	return dsn, nil
+}
+
 func dsn(
 	driver string,
 	databaseName string,
@@ -103,6 +217,7 @@ func dsn(
 	timezone string,
 	charset string,
 	sslMode string,
+	socketPath string,
 ) string {
 	if strings.EqualFold(driver, DATABASE_TYPE_SQLITE) {
 		return databaseName
@@ -110,10 +225,13 @@ func dsn(
 
 	if strings.EqualFold(driver, DATABASE_TYPE_MYSQL) {
 		dsn := user + `:` + pass
-		dsn += `@tcp(` + host + `:` + port + `)/` + databaseName
+		if socketPath != "" {
+			dsn += `@unix(` + socketPath + `)/` + databaseName
+		} else {
+			dsn += `@tcp(` + host + `:` + port + `)/` + databaseName
+		}
 		dsn += `?charset=` + charset
-		dsn += `&parseTime=True`
-		dsn += `&loc=` + timezone
+		dsn += `&loc=` + url.QueryEscape(timezone)
 		return dsn
 	}
 
@@ -121,20 +239,179 @@ func dsn(
 		if sslMode == "" {
 			sslMode = `disable`
 		}
-		dsn := `host=` + host
+		dsn := ``
+		if socketPath != "" {
+			// The directory of the socket, i.e. /var/run/postgresql
+			dsn += `host=` + socketPath
+		} else {
+			dsn += `host=` + host
+		}
 		dsn += ` user=` + user
 		dsn += ` password=` + pass
 		dsn += ` dbname=` + databaseName
-		dsn += ` port=` + port
+		if socketPath == "" {
+			dsn += ` port=` + port
+		}
 		dsn += ` sslmode=` + sslMode
 		dsn += ` binary_parameters=yes`
 		dsn += ` TimeZone=` + timezone
 		return dsn
 	}
 
+	if strings.EqualFold(driver, DATABASE_TYPE_MSSQL) {
+		query := url.Values{}
+		query.Set("database", databaseName)
+		dsnURL := url.URL{
+			Scheme:   "sqlserver",
+			User:     url.UserPassword(user, pass),
+			Host:     host + `:` + port,
+			RawQuery: query.Encode(),
+		}
+		return dsnURL.String()
+	}
+
+	return ""
+}
+
+// mysqlDSNParams returns the collation and parseTime parameters
+// to append to the DSN, only for MySQL
+func mysqlDSNParams(driver string, collation string, parseTime bool) string {
+	if !strings.EqualFold(driver, DATABASE_TYPE_MYSQL) {
+		return ""
+	}
+
+	params := `&parseTime=True`
+
+	if !parseTime {
+		params = `&parseTime=False`
+	}
+
+	if collation != "" {
+		params += `&collation=` + collation
+	}
+
+	return params
+}
+
+// sqlitePragmaStatements returns the PRAGMA statements of the SQLite options,
+// to run on every new connection, only for SQLite. The busy timeout is set
+// first, so switching to WAL waits for the other connections
+func sqlitePragmaStatements(driver string, options openOptionsInterface) []string {
+	if !strings.EqualFold(driver, DATABASE_TYPE_SQLITE) {
+		return []string{}
+	}
+
+	statements := []string{}
+
+	if options.SQLiteBusyTimeout() > 0 {
+		statements = append(statements, `PRAGMA busy_timeout = `+strconv.FormatInt(options.SQLiteBusyTimeout().Milliseconds(), 10))
+	}
+
+	if options.HasSQLiteForeignKeys() {
+		if options.SQLiteForeignKeys() {
+			statements = append(statements, `PRAGMA foreign_keys = ON`)
+		} else {
+			statements = append(statements, `PRAGMA foreign_keys = OFF`)
+		}
+	}
+
+	if options.HasSQLiteWAL() {
+		if options.SQLiteWAL() {
+			statements = append(statements, `PRAGMA journal_mode = WAL`)
+		} else {
+			statements = append(statements, `PRAGMA journal_mode = DELETE`)
+		}
+	}
+
+	return statements
+}
+
+// connectTimeoutDSNParam returns the connect timeout parameter
+// to append to the DSN of the database type
+func connectTimeoutDSNParam(driver string, timeout time.Duration) string {
+	if timeout <= 0 {
+		return ""
+	}
+
+	// Postgres and MSSQL expect whole seconds, round up
+	seconds := strconv.Itoa(int((timeout + time.Second - 1) / time.Second))
+
+	switch strings.ToLower(driver) {
+	case DATABASE_TYPE_MYSQL:
+		return `&timeout=` + timeout.String()
+	case DATABASE_TYPE_POSTGRES, DATABASE_TYPE_PGX:
+		return ` connect_timeout=` + seconds
+	case DATABASE_TYPE_MSSQL:
+		return `&dial+timeout=` + seconds
+	}
+
 	return ""
 }
 
+// statementTimeoutDSNParam returns the statement timeout parameter
+// to append to the DSN of the database type, in milliseconds
+func statementTimeoutDSNParam(driver string, timeout time.Duration) string {
+	if timeout <= 0 {
+		return ""
+	}
+
+	// Round up, so a sub-millisecond timeout is not disabled as zero
+	milliseconds := strconv.FormatInt(int64((timeout+time.Millisecond-1)/time.Millisecond), 10)
+
+	switch strings.ToLower(driver) {
+	case DATABASE_TYPE_MYSQL:
+		// Unknown DSN parameters are set as session system variables
+		return `&max_execution_time=` + milliseconds
+	case DATABASE_TYPE_POSTGRES, DATABASE_TYPE_PGX:
+		// Unknown DSN keys are sent as run-time parameters
+		return ` statement_timeout=` + milliseconds
+	}
+
+	return ""
+}
+
+// applicationNameDSNParam returns the application name parameter
+// to append to the DSN of the database type
+func applicationNameDSNParam(driver string, applicationName string) string {
+	if applicationName == "" {
+		return ""
+	}
+
+	switch strings.ToLower(driver) {
+	case DATABASE_TYPE_MYSQL:
+		// Shown in performance_schema.session_connect_attrs
+		return `&connectionAttributes=program_name:` + url.QueryEscape(applicationName)
+	case DATABASE_TYPE_POSTGRES, DATABASE_TYPE_PGX:
+		// Shown in pg_stat_activity
+		return ` application_name=` + quotePostgresDSNValue(applicationName)
+	case DATABASE_TYPE_MSSQL:
+		return `&app+name=` + url.QueryEscape(applicationName)
+	}
+
+	return ""
+}
+
+// schemaDSNParam returns the search_path parameter to append to the DSN,
+// only for Postgres. It is set by the server on every new connection,
+// so all the connections of the pool get it
+func schemaDSNParam(driver string, schema string) string {
+	if schema == "" {
+		return ""
+	}
+
+	if !strings.EqualFold(driver, DATABASE_TYPE_POSTGRES) && !strings.EqualFold(driver, DATABASE_TYPE_PGX) {
+		return ""
+	}
+
+	return ` options=` + quotePostgresDSNValue("-c search_path="+schema)
+}
+
+// quotePostgresDSNValue quotes a value of a key=value Postgres DSN,
+// escaping the backslashes and single quotes inside it
+func quotePostgresDSNValue(value string) string {
+	return `'` + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value) + `'`
+}
+
 func Options() openOptionsInterface {
 	return &openOptions{
 		properties: make(map[string]interface{}),
@@ -157,12 +434,12 @@ func (o *openOptions) Verify() error {
 		return errors.New(`database type cannot be empty`)
 	}
 
-	supportedDrivers := []string{DATABASE_TYPE_SQLITE, DATABASE_TYPE_MYSQL, DATABASE_TYPE_POSTGRES, DATABASE_TYPE_PGX}
+	if _, ok := findDatabaseTypeInfo(o.DatabaseType()); !ok {
+		supportedDrivers := []string{}
+		for _, info := range supportedDatabaseTypes {
+			supportedDrivers = append(supportedDrivers, info.Type)
+		}
 
-	if !strings.EqualFold(o.DatabaseType(), DATABASE_TYPE_SQLITE) &&
-		!strings.EqualFold(o.DatabaseType(), DATABASE_TYPE_MYSQL) &&
-		!strings.EqualFold(o.DatabaseType(), DATABASE_TYPE_POSTGRES) &&
-		!strings.EqualFold(o.DatabaseType(), DATABASE_TYPE_PGX) {
 		msg := `driver ` + o.DatabaseType() + ` is not supported.`
 		msg += ` Supported drivers: ` + strings.Join(supportedDrivers, ", ")
 		return errors.New(msg)
@@ -180,15 +457,27 @@ func (o *openOptions) Verify() error {
 		o.SetDatabaseHost("")
 	}
 
-	if o.DatabaseHost() == "" && o.DatabaseType() != DATABASE_TYPE_SQLITE {
-		return errors.New(`database host is required`)
-	}
-
 	if !o.HasDatabasePort() {
 		o.SetDatabasePort("")
 	}
 
-	if o.DatabasePort() == "" && o.DatabaseType() != DATABASE_TYPE_SQLITE {
+	if o.SocketPath() != "" {
+		if !strings.EqualFold(o.DatabaseType(), DATABASE_TYPE_MYSQL) &&
+			!strings.EqualFold(o.DatabaseType(), DATABASE_TYPE_POSTGRES) &&
+			!strings.EqualFold(o.DatabaseType(), DATABASE_TYPE_PGX) {
+			return errors.New(`socket path is only supported for MySQL and Postgres`)
+		}
+
+		if o.DatabaseHost() != "" {
+			return errors.New(`database host and socket path cannot both be set`)
+		}
+	}
+
+	if o.DatabaseHost() == "" && o.SocketPath() == "" && o.DatabaseType() != DATABASE_TYPE_SQLITE {
+		return errors.New(`database host is required`)
+	}
+
+	if o.DatabasePort() == "" && o.SocketPath() == "" && o.DatabaseType() != DATABASE_TYPE_SQLITE {
 		return errors.New(`database port is required`)
 	}
 
@@ -204,6 +493,10 @@ func (o *openOptions) Verify() error {
 		o.SetTimeZone("UTC")
 	}
 
+	if err, ok := o.get("time_zone_error").(error); ok {
+		return err
+	}
+
 	if !o.HasCharset() {
 		if o.DatabaseType() == DATABASE_TYPE_MYSQL {
 			o.SetCharset("utf8mb4")
@@ -212,7 +505,7 @@ func (o *openOptions) Verify() error {
 		}
 	}
 
-	return nil
+	return o.verifyTLS()
 }
 
 func (o *openOptions) DatabaseType() string {
@@ -306,6 +599,38 @@ func (o *openOptions) SetCharset(charset string) openOptionsInterface {
 	return o
 }
 
+func (o *openOptions) Collation() string {
+	if !o.has("collation") {
+		return ""
+	}
+	return o.get("collation").(string)
+}
+
+func (o *openOptions) HasCollation() bool {
+	return o.has("collation")
+}
+
+func (o *openOptions) SetCollation(collation string) openOptionsInterface {
+	o.set("collation", collation)
+	return o
+}
+
+func (o *openOptions) ParseTime() bool {
+	if !o.has("parse_time") {
+		return true
+	}
+	return o.get("parse_time").(bool)
+}
+
+func (o *openOptions) HasParseTime() bool {
+	return o.has("parse_time")
+}
+
+func (o *openOptions) SetParseTime(parseTime bool) openOptionsInterface {
+	o.set("parse_time", parseTime)
+	return o
+}
+
 func (o *openOptions) SSLMode() string {
 	return o.sslMode
 }
@@ -330,6 +655,298 @@ func (o *openOptions) HasTimeZone() bool {
 
 func (o *openOptions) SetTimeZone(timeZone string) openOptionsInterface {
 	o.set("time_zone", timeZone)
+	delete(o.properties, "time_zone_error")
+	return o
+}
+
+func (o *openOptions) SetTimezone(loc *time.Location) openOptionsInterface {
+	name, err := timeZoneName(loc)
+
+	if err != nil {
+		// Reported by Verify, as the setters cannot fail
+		o.SetTimeZone(loc.String())
+		o.set("time_zone_error", err)
+		return o
+	}
+
+	return o.SetTimeZone(name)
+}
+
+// timeZoneName returns the IANA name of the location, which the drivers
+// pass to the database. time.Local is resolved to the name of the local
+// time zone, and a zone without offset from UTC is UTC. A nil location
+// means UTC.
+func timeZoneName(loc *time.Location) (string, error) {
+	if loc == nil {
+		return "UTC", nil
+	}
+
+	if loc == time.Local {
+		if name := localTimeZoneName(); name != "" {
+			return name, nil
+		}
+
+		return "", errors.New(`the name of the local time zone cannot be determined, set it with SetTimeZone`)
+	}
+
+	name := loc.String()
+
+	if name != "" && name != "Local" {
+		if _, err := time.LoadLocation(name); err == nil {
+			return name, nil
+		}
+	}
+
+	// i.e. time.FixedZone("", 0)
+	if _, offset := time.Now().In(loc).Zone(); offset == 0 {
+		return "UTC", nil
+	}
+
+	return "", errors.New(`time zone ` + strconv.Quote(name) + ` is not an IANA time zone name, i.e. a fixed zone`)
+}
+
+// localTimeZoneName returns the IANA name of the local time zone, from the
+// TZ environment variable or the /etc/localtime link, or "" if unknown
+func localTimeZoneName() string {
+	if tz, ok := os.LookupEnv("TZ"); ok {
+		// An empty TZ means UTC, a leading colon is allowed by POSIX
+		tz = strings.TrimPrefix(tz, ":")
+
+		if tz == "" {
+			return "UTC"
+		}
+
+		if _, err := time.LoadLocation(tz); err == nil {
+			return tz
+		}
+
+		return ""
+	}
+
+	target, err := os.Readlink("/etc/localtime")
+
+	if err != nil {
+		return ""
+	}
+
+	_, name, found := strings.Cut(target, "zoneinfo/")
+
+	if !found {
+		return ""
+	}
+
+	if _, err := time.LoadLocation(name); err != nil {
+		return ""
+	}
+
+	return name
+}
+
+func (o *openOptions) MaxOpenConns() int {
+	if !o.has("max_open_conns") {
+		return 0
+	}
+	return o.get("max_open_conns").(int)
+}
+
+func (o *openOptions) HasMaxOpenConns() bool {
+	return o.has("max_open_conns")
+}
+
+func (o *openOptions) SetMaxOpenConns(maxOpenConns int) openOptionsInterface {
+	o.set("max_open_conns", maxOpenConns)
+	return o
+}
+
+func (o *openOptions) MaxIdleConns() int {
+	if !o.has("max_idle_conns") {
+		return 0
+	}
+	return o.get("max_idle_conns").(int)
+}
+
+func (o *openOptions) HasMaxIdleConns() bool {
+	return o.has("max_idle_conns")
+}
+
+func (o *openOptions) SetMaxIdleConns(maxIdleConns int) openOptionsInterface {
+	o.set("max_idle_conns", maxIdleConns)
+	return o
+}
+
+func (o *openOptions) ConnMaxLifetime() time.Duration {
+	if !o.has("conn_max_lifetime") {
+		return 0
+	}
+	return o.get("conn_max_lifetime").(time.Duration)
+}
+
+func (o *openOptions) HasConnMaxLifetime() bool {
+	return o.has("conn_max_lifetime")
+}
+
+func (o *openOptions) SetConnMaxLifetime(connMaxLifetime time.Duration) openOptionsInterface {
+	o.set("conn_max_lifetime", connMaxLifetime)
+	return o
+}
+
+func (o *openOptions) ConnMaxIdleTime() time.Duration {
+	if !o.has("conn_max_idle_time") {
+		return 0
+	}
+	return o.get("conn_max_idle_time").(time.Duration)
+}
+
+func (o *openOptions) HasConnMaxIdleTime() bool {
+	return o.has("conn_max_idle_time")
+}
+
+func (o *openOptions) SetConnMaxIdleTime(connMaxIdleTime time.Duration) openOptionsInterface {
+	o.set("conn_max_idle_time", connMaxIdleTime)
+	return o
+}
+
+func (o *openOptions) ConnectTimeout() time.Duration {
+	if !o.has("connect_timeout") {
+		return 0
+	}
+	return o.get("connect_timeout").(time.Duration)
+}
+
+func (o *openOptions) HasConnectTimeout() bool {
+	return o.has("connect_timeout")
+}
+
+func (o *openOptions) SetConnectTimeout(connectTimeout time.Duration) openOptionsInterface {
+	o.set("connect_timeout", connectTimeout)
+	return o
+}
+
+func (o *openOptions) SocketPath() string {
+	if !o.has("socket_path") {
+		return ""
+	}
+	return o.get("socket_path").(string)
+}
+
+func (o *openOptions) HasSocketPath() bool {
+	return o.has("socket_path")
+}
+
+func (o *openOptions) SetSocketPath(socketPath string) openOptionsInterface {
+	o.set("socket_path", socketPath)
+	return o
+}
+
+func (o *openOptions) Schema() string {
+	if !o.has("schema") {
+		return ""
+	}
+	return o.get("schema").(string)
+}
+
+func (o *openOptions) HasSchema() bool {
+	return o.has("schema")
+}
+
+func (o *openOptions) SetSchema(schema string) openOptionsInterface {
+	o.set("schema", schema)
+	return o
+}
+
+func (o *openOptions) ApplicationName() string {
+	if !o.has("application_name") {
+		return ""
+	}
+	return o.get("application_name").(string)
+}
+
+func (o *openOptions) HasApplicationName() bool {
+	return o.has("application_name")
+}
+
+func (o *openOptions) SetApplicationName(applicationName string) openOptionsInterface {
+	o.set("application_name", applicationName)
+	return o
+}
+
+func (o *openOptions) ConnectInitStatements() []string {
+	if !o.has("connect_init_statements") {
+		return []string{}
+	}
+	return o.get("connect_init_statements").([]string)
+}
+
+func (o *openOptions) HasConnectInitStatements() bool {
+	return o.has("connect_init_statements")
+}
+
+func (o *openOptions) SetConnectInitStatements(statements []string) openOptionsInterface {
+	o.set("connect_init_statements", statements)
+	return o
+}
+
+func (o *openOptions) SQLiteWAL() bool {
+	if !o.has("sqlite_wal") {
+		return false
+	}
+	return o.get("sqlite_wal").(bool)
+}
+
+func (o *openOptions) HasSQLiteWAL() bool {
+	return o.has("sqlite_wal")
+}
+
+func (o *openOptions) SetSQLiteWAL(enabled bool) openOptionsInterface {
+	o.set("sqlite_wal", enabled)
+	return o
+}
+
+func (o *openOptions) SQLiteForeignKeys() bool {
+	if !o.has("sqlite_foreign_keys") {
+		return false
+	}
+	return o.get("sqlite_foreign_keys").(bool)
+}
+
+func (o *openOptions) HasSQLiteForeignKeys() bool {
+	return o.has("sqlite_foreign_keys")
+}
+
+func (o *openOptions) SetSQLiteForeignKeys(enabled bool) openOptionsInterface {
+	o.set("sqlite_foreign_keys", enabled)
+	return o
+}
+
+func (o *openOptions) SQLiteBusyTimeout() time.Duration {
+	if !o.has("sqlite_busy_timeout") {
+		return 0
+	}
+	return o.get("sqlite_busy_timeout").(time.Duration)
+}
+
+func (o *openOptions) HasSQLiteBusyTimeout() bool {
+	return o.has("sqlite_busy_timeout")
+}
+
+func (o *openOptions) SetSQLiteBusyTimeout(busyTimeout time.Duration) openOptionsInterface {
+	o.set("sqlite_busy_timeout", busyTimeout)
+	return o
+}
+
+func (o *openOptions) StatementTimeout() time.Duration {
+	if !o.has("statement_timeout") {
+		return 0
+	}
+	return o.get("statement_timeout").(time.Duration)
+}
+
+func (o *openOptions) HasStatementTimeout() bool {
+	return o.has("statement_timeout")
+}
+
+func (o *openOptions) SetStatementTimeout(statementTimeout time.Duration) openOptionsInterface {
+	o.set("statement_timeout", statementTimeout)
 	return o
 }
 
@@ -378,6 +995,18 @@ type openOptionsInterface interface {
 	HasPassword() bool
 	SetPassword(string) openOptionsInterface
 
+	// SocketPath specifies the Unix socket to connect through, instead of TCP.
+	// For MySQL it is the socket file (i.e. /var/run/mysqld/mysqld.sock),
+	// for Postgres the socket directory (i.e. /var/run/postgresql).
+	// When set, the host and port are not used, and the host must not be set
+	SocketPath() string
+
+	// HasSocketPath returns true if the SocketPath property is set.
+	HasSocketPath() bool
+
+	// SetSocketPath sets the SocketPath property. It is only used for MySQL and Postgres
+	SetSocketPath(string) openOptionsInterface
+
 	// Charset specifies the character set to use when connecting to the database. It is only used for MySQL
 	Charset() string
 
@@ -387,23 +1016,241 @@ type openOptionsInterface interface {
 	// SetCharset sets the Charset property. It is only used for MySQL
 	SetCharset(string) openOptionsInterface
 
+	// Collation specifies the collation to use for the connection, i.e. utf8mb4_unicode_ci.
+	// Empty means the server default is used. It is only used for MySQL
+	Collation() string
+
+	// HasCollation returns true if the Collation property is set.
+	HasCollation() bool
+
+	// SetCollation sets the Collation property. It is only used for MySQL
+	SetCollation(string) openOptionsInterface
+
+	// ParseTime specifies if DATE and DATETIME columns are scanned into time.Time,
+	// instead of []byte. Defaults to true. It is only used for MySQL
+	ParseTime() bool
+
+	// HasParseTime returns true if the ParseTime property is set.
+	HasParseTime() bool
+
+	// SetParseTime sets the ParseTime property. It is only used for MySQL
+	SetParseTime(bool) openOptionsInterface
+
 	// SSLMode specifies the SSL mode to use when connecting to the database. It is only used for Postgres
 	SSLMode() string
 
 	// HasSSLMode returns true if the SSLMode property is set. It is only used for Postgres
 	HasSSLMode() bool
 
-	// SetSSLMode sets the SSLMode property. It is only used for Postgres
+	// SetSSLMode sets the SSLMode property. It is only used for Postgres.
+	// Supported modes: disable, allow, prefer, require, verify-ca, verify-full
 	SetSSLMode(string) openOptionsInterface
 
-	// TimeZone specifies the time zone to use when connecting to the database.
+	// SSLRootCert specifies the path to the CA certificate file used to verify the server.
+	// It is used for MySQL and Postgres
+	SSLRootCert() string
+
+	// HasSSLRootCert returns true if the SSLRootCert property is set.
+	HasSSLRootCert() bool
+
+	// SetSSLRootCert sets the SSLRootCert property.
+	SetSSLRootCert(string) openOptionsInterface
+
+	// SSLCert specifies the path to the client certificate file. It must be set together with SSLKey.
+	// It is used for MySQL and Postgres
+	SSLCert() string
+
+	// HasSSLCert returns true if the SSLCert property is set.
+	HasSSLCert() bool
+
+	// SetSSLCert sets the SSLCert property.
+	SetSSLCert(string) openOptionsInterface
+
+	// SSLKey specifies the path to the client private key file. It must be set together with SSLCert.
+	// It is used for MySQL and Postgres
+	SSLKey() string
+
+	// HasSSLKey returns true if the SSLKey property is set.
+	HasSSLKey() bool
+
+	// SetSSLKey sets the SSLKey property.
+	SetSSLKey(string) openOptionsInterface
+
+	// TLSConfig specifies a custom TLS config. It is only used for MySQL,
+	// and requires RegisterMySQLTLSConfig to be set
+	TLSConfig() *tls.Config
+
+	// HasTLSConfig returns true if the TLSConfig property is set.
+	HasTLSConfig() bool
+
+	// SetTLSConfig sets the TLSConfig property.
+	SetTLSConfig(*tls.Config) openOptionsInterface
+
+	// TLSConfigName specifies the name of a TLS config already registered with the driver,
+	// or one of "true", "skip-verify", "preferred". It is only used for MySQL
+	TLSConfigName() string
+
+	// HasTLSConfigName returns true if the TLSConfigName property is set.
+	HasTLSConfigName() bool
+
+	// SetTLSConfigName sets the TLSConfigName property.
+	SetTLSConfigName(string) openOptionsInterface
+
+	// TimeZone specifies the time zone to use when connecting to the database. Defaults to UTC.
+	//   - MySQL: passed as the loc parameter, time.Time values are scanned in this zone
+	//     (requires ParseTime)
+	//   - Postgres: passed as the TimeZone runtime parameter, timestamptz values
+	//     are returned in this zone
+	//   - SQLite: ignored, SQLite stores no time zone
+	//   - MSSQL: ignored, the zone is part of the datetimeoffset values
 	TimeZone() string
 
 	// HasTimeZone returns true if the TimeZone property is set.
 	HasTimeZone() bool
 
-	// SetTimeZone sets the TimeZone property.
+	// SetTimeZone sets the TimeZone property, i.e. "UTC" or "Europe/London".
 	SetTimeZone(string) openOptionsInterface
 
+	// SetTimezone sets the TimeZone property from a location, a nil location
+	// means UTC. time.Local is resolved to the name of the local time zone.
+	// A location without an IANA name (i.e. a fixed zone with an offset)
+	// is reported as an error by Verify.
+	SetTimezone(*time.Location) openOptionsInterface
+
+	// MaxOpenConns specifies the maximum number of open connections to the database.
+	// Zero means the default is used.
+	MaxOpenConns() int
+
+	// HasMaxOpenConns returns true if the MaxOpenConns property is set.
+	HasMaxOpenConns() bool
+
+	// SetMaxOpenConns sets the MaxOpenConns property.
+	SetMaxOpenConns(int) openOptionsInterface
+
+	// MaxIdleConns specifies the maximum number of idle connections in the pool.
+	// Zero means the default is used.
+	MaxIdleConns() int
+
+	// HasMaxIdleConns returns true if the MaxIdleConns property is set.
+	HasMaxIdleConns() bool
+
+	// SetMaxIdleConns sets the MaxIdleConns property.
+	SetMaxIdleConns(int) openOptionsInterface
+
+	// ConnMaxLifetime specifies the maximum amount of time a connection may be reused.
+	// Zero means the default is used.
+	ConnMaxLifetime() time.Duration
+
+	// HasConnMaxLifetime returns true if the ConnMaxLifetime property is set.
+	HasConnMaxLifetime() bool
+
+	// SetConnMaxLifetime sets the ConnMaxLifetime property.
+	SetConnMaxLifetime(time.Duration) openOptionsInterface
+
+	// ConnMaxIdleTime specifies the maximum amount of time a connection may be idle.
+	// Zero means the default is used.
+	ConnMaxIdleTime() time.Duration
+
+	// HasConnMaxIdleTime returns true if the ConnMaxIdleTime property is set.
+	HasConnMaxIdleTime() bool
+
+	// SetConnMaxIdleTime sets the ConnMaxIdleTime property.
+	SetConnMaxIdleTime(time.Duration) openOptionsInterface
+
+	// ConnectTimeout specifies the maximum amount of time to wait for a connection
+	// to be established. Zero means no timeout.
+	ConnectTimeout() time.Duration
+
+	// HasConnectTimeout returns true if the ConnectTimeout property is set.
+	HasConnectTimeout() bool
+
+	// SetConnectTimeout sets the ConnectTimeout property.
+	SetConnectTimeout(time.Duration) openOptionsInterface
+
+	// Schema specifies the default schema of the connections, i.e. for tenants
+	// isolated by schema. For Postgres it is set as the search_path of every
+	// connection of the pool. For MySQL, which has no schemas apart from databases,
+	// it is used as the default database instead of DatabaseName.
+	// It is ignored for SQLite and MSSQL
+	Schema() string
+
+	// HasSchema returns true if the Schema property is set.
+	HasSchema() bool
+
+	// SetSchema sets the Schema property.
+	SetSchema(string) openOptionsInterface
+
+	// ApplicationName specifies the name the connections are tagged with on the
+	// database side. Postgres shows it in pg_stat_activity, MySQL in the connection
+	// attributes (program_name) and MSSQL as the app name. It is ignored for SQLite
+	ApplicationName() string
+
+	// HasApplicationName returns true if the ApplicationName property is set.
+	HasApplicationName() bool
+
+	// SetApplicationName sets the ApplicationName property.
+	SetApplicationName(string) openOptionsInterface
+
+	// ConnectInitStatements specifies the statements executed on every new
+	// connection of the pool, not just the first one, i.e. the SQLite pragmas
+	// PRAGMA foreign_keys = ON and PRAGMA journal_mode = WAL, or
+	// SET statement_timeout = 5000 for Postgres
+	ConnectInitStatements() []string
+
+	// HasConnectInitStatements returns true if the ConnectInitStatements property is set.
+	HasConnectInitStatements() bool
+
+	// SetConnectInitStatements sets the ConnectInitStatements property.
+	SetConnectInitStatements([]string) openOptionsInterface
+
+	// StatementTimeout specifies the maximum execution time of a statement
+	// on the database side, as a safety net against runaway queries, which
+	// applies even if the context of the query has no deadline. For Postgres
+	// it is set as statement_timeout, for MySQL as max_execution_time, which
+	// applies only to SELECT statements. Zero means no timeout.
+	// It is ignored for SQLite and MSSQL
+	StatementTimeout() time.Duration
+
+	// HasStatementTimeout returns true if the StatementTimeout property is set.
+	HasStatementTimeout() bool
+
+	// SetStatementTimeout sets the StatementTimeout property.
+	SetStatementTimeout(time.Duration) openOptionsInterface
+
+	// SQLiteWAL specifies if the SQLite connections use the write-ahead log
+	// (PRAGMA journal_mode = WAL), so the readers do not block the writer.
+	// When set to false, the default rollback journal is used. It is ignored
+	// for the other database types
+	SQLiteWAL() bool
+
+	// HasSQLiteWAL returns true if the SQLiteWAL property is set.
+	HasSQLiteWAL() bool
+
+	// SetSQLiteWAL sets the SQLiteWAL property.
+	SetSQLiteWAL(bool) openOptionsInterface
+
+	// SQLiteForeignKeys specifies if the SQLite connections enforce the
+	// foreign keys (PRAGMA foreign_keys = ON), which SQLite does not by default.
+	// It is ignored for the other database types
+	SQLiteForeignKeys() bool
+
+	// HasSQLiteForeignKeys returns true if the SQLiteForeignKeys property is set.
+	HasSQLiteForeignKeys() bool
+
+	// SetSQLiteForeignKeys sets the SQLiteForeignKeys property.
+	SetSQLiteForeignKeys(bool) openOptionsInterface
+
+	// SQLiteBusyTimeout specifies how long the SQLite connections wait for
+	// a locked database (PRAGMA busy_timeout), instead of failing with
+	// SQLITE_BUSY right away. Zero means the default is used.
+	// It is ignored for the other database types
+	SQLiteBusyTimeout() time.Duration
+
+	// HasSQLiteBusyTimeout returns true if the SQLiteBusyTimeout property is set.
+	HasSQLiteBusyTimeout() bool
+
+	// SetSQLiteBusyTimeout sets the SQLiteBusyTimeout property.
+	SetSQLiteBusyTimeout(time.Duration) openOptionsInterface
+
 	Verify() error
 }
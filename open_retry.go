@@ -0,0 +1,52 @@
+package database
+
+import (
+	"database/sql"
+	"time"
+)
+
+// OpenWithRetry opens the database like Open, retrying on failure with an
+// increasing backoff, i.e. while the database is starting up together with
+// the application in a container environment.
+//
+// The backoff is doubled after each failed attempt. Errors which occur
+// before connecting (invalid options, TLS options, or a driver which is
+// not registered) are not retried. If all the attempts fail, the error of the last attempt is returned.
+//
+// Example:
+//
+//	db, err := database.OpenWithRetry(database.Options().
+//		SetDatabaseType(database.DATABASE_TYPE_POSTGRES).
+//		// ...
+//		SetConnectTimeout(5*time.Second), 5, time.Second)
+//
+// Parameters:
+// - options openOptionsInterface
+// - attempts int: the maximum number of attempts, at least 1
+// - backoff time.Duration: the time to wait after the first failed attempt
+//
+// Returns:
+// - *sql.DB: the database connection
+// - error: the error of the last attempt if all attempts failed
+func OpenWithRetry(options openOptionsInterface, attempts int, backoff time.Duration) (*sql.DB, error) {
+	if _, err := openDSN(options); err != nil {
+		return nil, err
+	}
+
+	attempts = max(attempts, 1)
+
+	var err error
+
+	for attempt := 1; ; attempt++ {
+		var db *sql.DB
+
+		db, err = Open(options)
+
+		if err == nil || attempt >= attempts {
+			return db, err
+		}
+
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
@@ -0,0 +1,78 @@
+package database_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	database "github.com/dracory/database"
+)
+
+func TestOpenWithRetry(t *testing.T) {
+	db, err := database.OpenWithRetry(database.Options().
+		SetDatabaseType(database.DATABASE_TYPE_SQLITE).
+		SetDatabaseName(":memory:"), 3, time.Millisecond)
+
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer db.Close()
+
+	if err := db.Ping(); err != nil {
+		t.Errorf("Expected database to be usable, got %v", err)
+	}
+}
+
+func TestOpenWithRetryFails(t *testing.T) {
+	start := time.Now()
+
+	// The directory does not exist, so the ping fails on every attempt
+	_, err := database.OpenWithRetry(database.Options().
+		SetDatabaseType(database.DATABASE_TYPE_SQLITE).
+		SetDatabaseName(t.TempDir()+"/missing/test.db"), 3, 10*time.Millisecond)
+
+	if err == nil {
+		t.Fatal("Expected error for unreachable database")
+	}
+
+	// 2 waits between 3 attempts, 10ms and 20ms
+	if elapsed := time.Since(start); elapsed < 30*time.Millisecond {
+		t.Errorf("Expected the attempts to back off, took %v", elapsed)
+	}
+}
+
+func TestOpenWithRetryInvalidOptions(t *testing.T) {
+	start := time.Now()
+
+	_, err := database.OpenWithRetry(database.Options().
+		SetDatabaseType(database.DATABASE_TYPE_MYSQL), 3, time.Second)
+
+	if err == nil {
+		t.Fatal("Expected error for invalid options")
+	}
+
+	if elapsed := time.Since(start); elapsed >= time.Second {
+		t.Errorf("Expected invalid options not to be retried, took %v", elapsed)
+	}
+}
+
+func TestOpenWithRetryUnregisteredDriver(t *testing.T) {
+	start := time.Now()
+
+	// The MySQL driver is not imported by the tests
+	_, err := database.OpenWithRetry(database.Options().
+		SetDatabaseType(database.DATABASE_TYPE_MYSQL).
+		SetDatabaseHost("localhost").
+		SetDatabasePort("3306").
+		SetDatabaseName("test").
+		SetUserName("test").
+		SetPassword("test"), 3, time.Second)
+
+	if err == nil || !strings.Contains(err.Error(), "is not registered") {
+		t.Fatalf("Expected error for an unregistered driver, got %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed >= time.Second {
+		t.Errorf("Expected an unregistered driver not to be retried, took %v", elapsed)
+	}
+}
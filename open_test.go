@@ -1,8 +1,12 @@
 package database_test
 
 import (
+	"context"
+	"database/sql"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	database "github.com/dracory/database"
 
@@ -115,3 +119,201 @@ func TestOpenPortIsRequired(t *testing.T) {
 		t.Fatal(`db MUST be nil`)
 	}
 }
+
+func TestOptionsVerifyMSSQL(t *testing.T) {
+	err := database.Options().
+		SetDatabaseType(database.DATABASE_TYPE_MSSQL).
+		SetDatabaseHost("localhost").
+		SetDatabasePort("1433").
+		SetDatabaseName("test_db").
+		SetUserName("sa").
+		SetPassword("secret").
+		Verify()
+
+	if err != nil {
+		t.Fatal(`err MUST be nil, found: `, err.Error())
+	}
+}
+
+func TestOpenWithPoolConfig(t *testing.T) {
+	db, err := database.Open(database.Options().
+		SetDatabaseType(database.DATABASE_TYPE_SQLITE).
+		SetDatabaseName(":memory:").
+		SetMaxOpenConns(3).
+		SetMaxIdleConns(2).
+		SetConnMaxLifetime(time.Minute).
+		SetConnMaxIdleTime(10 * time.Second))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer db.Close()
+
+	if db.Stats().MaxOpenConnections != 3 {
+		t.Fatal(`MaxOpenConnections MUST be 3, found: `, db.Stats().MaxOpenConnections)
+	}
+}
+
+func TestOptionsPoolConfigDefaults(t *testing.T) {
+	options := database.Options()
+
+	if options.HasMaxOpenConns() || options.MaxOpenConns() != 0 {
+		t.Fatal(`MaxOpenConns MUST NOT be set`)
+	}
+
+	if options.HasConnMaxLifetime() || options.ConnMaxLifetime() != 0 {
+		t.Fatal(`ConnMaxLifetime MUST NOT be set`)
+	}
+}
+
+func TestOpenContext(t *testing.T) {
+	db, err := database.OpenContext(context.Background(), database.Options().
+		SetDatabaseType(database.DATABASE_TYPE_SQLITE).
+		SetDatabaseName(":memory:").
+		SetConnectTimeout(5*time.Second))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer db.Close()
+}
+
+func TestOpenContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	db, err := database.OpenContext(ctx, database.Options().
+		SetDatabaseType(database.DATABASE_TYPE_SQLITE).
+		SetDatabaseName(":memory:"))
+
+	if err == nil {
+		t.Fatal(`err MUST NOT be nil`)
+	}
+
+	if !strings.Contains(err.Error(), `could not be pinged`) {
+		t.Fatal(`err MUST contain 'could not be pinged', found: `, err.Error())
+	}
+
+	if db != nil {
+		t.Fatal(`db MUST be nil`)
+	}
+}
+
+func TestOpenDriverNotRegistered(t *testing.T) {
+	db, err := database.Open(database.Options().
+		SetDatabaseType(database.DATABASE_TYPE_MYSQL).
+		SetDatabaseHost("localhost").
+		SetDatabasePort("3306").
+		SetDatabaseName("test_db").
+		SetUserName("root").
+		SetPassword("secret"))
+
+	if err == nil {
+		t.Fatal(`err MUST NOT be nil`)
+	}
+
+	if !strings.Contains(err.Error(), `_ "github.com/go-sql-driver/mysql"`) {
+		t.Fatal(`err MUST name the import of the driver, found: `, err.Error())
+	}
+
+	if db != nil {
+		t.Fatal(`db MUST be nil`)
+	}
+}
+
+func TestOpenConnectInitStatements(t *testing.T) {
+	db, err := database.Open(database.Options().
+		SetDatabaseType(database.DATABASE_TYPE_SQLITE).
+		SetDatabaseName(filepath.Join(t.TempDir(), "test.db")).
+		SetConnectInitStatements([]string{"PRAGMA foreign_keys = ON", "PRAGMA busy_timeout = 1234"}))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer db.Close()
+
+	// Every connection of the pool runs the statements, not just the first one
+	conns := []*sql.Conn{}
+
+	for range 3 {
+		conn, err := db.Conn(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer conn.Close()
+
+		conns = append(conns, conn)
+	}
+
+	for i, conn := range conns {
+		var foreignKeys, busyTimeout int
+
+		if err := conn.QueryRowContext(context.Background(), "PRAGMA foreign_keys").Scan(&foreignKeys); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := conn.QueryRowContext(context.Background(), "PRAGMA busy_timeout").Scan(&busyTimeout); err != nil {
+			t.Fatal(err)
+		}
+
+		if foreignKeys != 1 || busyTimeout != 1234 {
+			t.Errorf("Expected the init statements to run on connection %d, got foreign_keys %d, busy_timeout %d", i, foreignKeys, busyTimeout)
+		}
+	}
+}
+
+func TestOpenConnectInitStatementsError(t *testing.T) {
+	db, err := database.Open(database.Options().
+		SetDatabaseType(database.DATABASE_TYPE_SQLITE).
+		SetDatabaseName(":memory:").
+		SetConnectInitStatements([]string{"INVALID SQL"}))
+
+	if err == nil {
+		t.Fatal(`err MUST NOT be nil`)
+	}
+
+	if !strings.Contains(err.Error(), `connect init statement failed: INVALID SQL`) {
+		t.Fatal(`err MUST name the failed statement, found: `, err.Error())
+	}
+
+	if db != nil {
+		t.Fatal(`db MUST be nil`)
+	}
+}
+
+func TestOpenSQLiteOptions(t *testing.T) {
+	db, err := database.Open(database.Options().
+		SetDatabaseType(database.DATABASE_TYPE_SQLITE).
+		SetDatabaseName(filepath.Join(t.TempDir(), "test.db")).
+		SetSQLiteWAL(true).
+		SetSQLiteForeignKeys(true).
+		SetSQLiteBusyTimeout(5 * time.Second))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer db.Close()
+
+	var journalMode string
+	var foreignKeys, busyTimeout int
+
+	if err := db.QueryRow("PRAGMA journal_mode").Scan(&journalMode); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := db.QueryRow("PRAGMA foreign_keys").Scan(&foreignKeys); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := db.QueryRow("PRAGMA busy_timeout").Scan(&busyTimeout); err != nil {
+		t.Fatal(err)
+	}
+
+	if journalMode != "wal" || foreignKeys != 1 || busyTimeout != 5000 {
+		t.Errorf("Unexpected pragmas: journal_mode %s, foreign_keys %d, busy_timeout %d", journalMode, foreignKeys, busyTimeout)
+	}
+}
@@ -0,0 +1,251 @@
+package database
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"hash/fnv"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// RegisterMySQLTLSConfig is used by Open to register custom TLS configs
+// with the MySQL driver. As drivers are not included in this package,
+// it must be set before opening a MySQL database with SetTLSConfig
+// or certificate files:
+//
+//	database.RegisterMySQLTLSConfig = mysql.RegisterTLSConfig
+var RegisterMySQLTLSConfig func(key string, config *tls.Config) error
+
+// postgresSSLModes are the supported Postgres sslmode values
+var postgresSSLModes = []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
+
+func (o *openOptions) TLSConfig() *tls.Config {
+	if !o.has("tls_config") {
+		return nil
+	}
+	return o.get("tls_config").(*tls.Config)
+}
+
+func (o *openOptions) HasTLSConfig() bool {
+	return o.has("tls_config")
+}
+
+func (o *openOptions) SetTLSConfig(tlsConfig *tls.Config) openOptionsInterface {
+	o.set("tls_config", tlsConfig)
+	return o
+}
+
+func (o *openOptions) TLSConfigName() string {
+	if !o.has("tls_config_name") {
+		return ""
+	}
+	return o.get("tls_config_name").(string)
+}
+
+func (o *openOptions) HasTLSConfigName() bool {
+	return o.has("tls_config_name")
+}
+
+func (o *openOptions) SetTLSConfigName(tlsConfigName string) openOptionsInterface {
+	o.set("tls_config_name", tlsConfigName)
+	return o
+}
+
+func (o *openOptions) SSLRootCert() string {
+	if !o.has("ssl_root_cert") {
+		return ""
+	}
+	return o.get("ssl_root_cert").(string)
+}
+
+func (o *openOptions) HasSSLRootCert() bool {
+	return o.has("ssl_root_cert")
+}
+
+func (o *openOptions) SetSSLRootCert(sslRootCert string) openOptionsInterface {
+	o.set("ssl_root_cert", sslRootCert)
+	return o
+}
+
+func (o *openOptions) SSLCert() string {
+	if !o.has("ssl_cert") {
+		return ""
+	}
+	return o.get("ssl_cert").(string)
+}
+
+func (o *openOptions) HasSSLCert() bool {
+	return o.has("ssl_cert")
+}
+
+func (o *openOptions) SetSSLCert(sslCert string) openOptionsInterface {
+	o.set("ssl_cert", sslCert)
+	return o
+}
+
+func (o *openOptions) SSLKey() string {
+	if !o.has("ssl_key") {
+		return ""
+	}
+	return o.get("ssl_key").(string)
+}
+
+func (o *openOptions) HasSSLKey() bool {
+	return o.has("ssl_key")
+}
+
+func (o *openOptions) SetSSLKey(sslKey string) openOptionsInterface {
+	o.set("ssl_key", sslKey)
+	return o
+}
+
+// hasCertFiles returns true if any of the certificate file paths is set
+func (o *openOptions) hasCertFiles() bool {
+	return o.SSLRootCert() != "" || o.SSLCert() != "" || o.SSLKey() != ""
+}
+
+// verifyTLS validates the TLS/SSL options for the database type
+func (o *openOptions) verifyTLS() error {
+	databaseType := strings.ToLower(o.DatabaseType())
+	isPostgres := databaseType == DATABASE_TYPE_POSTGRES || databaseType == DATABASE_TYPE_PGX
+	isMySQL := databaseType == DATABASE_TYPE_MYSQL
+	hasTLS := o.TLSConfig() != nil || o.TLSConfigName() != "" || o.hasCertFiles()
+
+	if hasTLS && !isPostgres && !isMySQL {
+		return errors.New(`tls options are only supported for ` + DATABASE_TYPE_MYSQL + ` and ` + DATABASE_TYPE_POSTGRES)
+	}
+
+	if (o.SSLCert() == "") != (o.SSLKey() == "") {
+		return errors.New(`ssl cert and ssl key must be set together`)
+	}
+
+	if o.HasSSLMode() && o.SSLMode() != "" {
+		// Other database types ignore the ssl mode, disabling it is harmless
+		if !isPostgres && o.SSLMode() != "disable" {
+			return errors.New(`ssl mode is only supported for ` + DATABASE_TYPE_POSTGRES + `, use tls config for ` + databaseType)
+		}
+
+		valid := false
+		for _, mode := range postgresSSLModes {
+			if o.SSLMode() == mode {
+				valid = true
+			}
+		}
+
+		if !valid {
+			return errors.New(`ssl mode ` + o.SSLMode() + ` is not supported. Supported modes: ` + strings.Join(postgresSSLModes, ", "))
+		}
+
+		if o.SSLMode() == "disable" && o.hasCertFiles() {
+			return errors.New(`ssl mode disable cannot be used with ssl certificate files`)
+		}
+	}
+
+	if isPostgres && o.TLSConfig() != nil {
+		return errors.New(`tls config is not supported for ` + DATABASE_TYPE_POSTGRES + `, use ssl mode and ssl certificate files instead`)
+	}
+
+	if isPostgres && o.TLSConfigName() != "" {
+		return errors.New(`tls config name is only supported for ` + DATABASE_TYPE_MYSQL)
+	}
+
+	if isMySQL && o.TLSConfigName() != "" && (o.TLSConfig() != nil || o.hasCertFiles()) {
+		return errors.New(`tls config name cannot be combined with tls config or ssl certificate files`)
+	}
+
+	if isMySQL && o.TLSConfig() != nil && o.hasCertFiles() {
+		return errors.New(`tls config cannot be combined with ssl certificate files`)
+	}
+
+	return nil
+}
+
+// tlsDSNParams returns the TLS/SSL parameters to append to the DSN
+// of the database type. For MySQL custom TLS configs are registered
+// with the driver using RegisterMySQLTLSConfig.
+func tlsDSNParams(options openOptionsInterface) (string, error) {
+	databaseType := strings.ToLower(options.DatabaseType())
+
+	if databaseType == DATABASE_TYPE_POSTGRES || databaseType == DATABASE_TYPE_PGX {
+		params := ""
+		if options.SSLRootCert() != "" {
+			params += ` sslrootcert=` + options.SSLRootCert()
+		}
+		if options.SSLCert() != "" {
+			params += ` sslcert=` + options.SSLCert()
+		}
+		if options.SSLKey() != "" {
+			params += ` sslkey=` + options.SSLKey()
+		}
+		return params, nil
+	}
+
+	if databaseType != DATABASE_TYPE_MYSQL {
+		return "", nil
+	}
+
+	if options.TLSConfigName() != "" {
+		return `&tls=` + options.TLSConfigName(), nil
+	}
+
+	tlsConfig := options.TLSConfig()
+
+	if tlsConfig == nil && (options.SSLRootCert() != "" || options.SSLCert() != "") {
+		var err error
+		tlsConfig, err = tlsConfigFromFiles(options.DatabaseHost(), options.SSLRootCert(), options.SSLCert(), options.SSLKey())
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if tlsConfig == nil {
+		return "", nil
+	}
+
+	if RegisterMySQLTLSConfig == nil {
+		return "", errors.New(`RegisterMySQLTLSConfig must be set to mysql.RegisterTLSConfig to use tls config with ` + DATABASE_TYPE_MYSQL)
+	}
+
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(options.DatabaseHost() + ":" + options.DatabasePort() + "/" + options.DatabaseName()))
+	name := "database_tls_" + strconv.FormatUint(uint64(hash.Sum32()), 16)
+
+	if err := RegisterMySQLTLSConfig(name, tlsConfig); err != nil {
+		return "", err
+	}
+
+	return `&tls=` + name, nil
+}
+
+// tlsConfigFromFiles builds a TLS config from the CA certificate
+// and the client certificate and key files
+func tlsConfigFromFiles(serverName string, rootCertFile string, certFile string, keyFile string) (*tls.Config, error) {
+	tlsConfig := &tls.Config{ServerName: serverName, MinVersion: tls.VersionTLS12}
+
+	if rootCertFile != "" {
+		pem, err := os.ReadFile(rootCertFile)
+		if err != nil {
+			return nil, err
+		}
+
+		rootCertPool := x509.NewCertPool()
+		if !rootCertPool.AppendCertsFromPEM(pem) {
+			return nil, errors.New(`ssl root cert ` + rootCertFile + ` does not contain valid PEM certificates`)
+		}
+
+		tlsConfig.RootCAs = rootCertPool
+	}
+
+	if certFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, err
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
@@ -0,0 +1,151 @@
+package database_test
+
+import (
+	"crypto/tls"
+	"strings"
+	"testing"
+
+	database "github.com/dracory/database"
+)
+
+func TestOptionsVerifyTLS(t *testing.T) {
+	tests := []struct {
+		name        string
+		options     func() error
+		errContains string
+	}{
+		{
+			name: "postgres valid ssl mode",
+			options: func() error {
+				return database.Options().
+					SetDatabaseType(database.DATABASE_TYPE_POSTGRES).
+					SetDatabaseHost("localhost").
+					SetDatabasePort("5432").
+					SetDatabaseName("test_db").
+					SetSSLMode("verify-full").
+					SetSSLRootCert("/etc/ssl/ca.pem").
+					Verify()
+			},
+		},
+		{
+			name: "postgres invalid ssl mode",
+			options: func() error {
+				return database.Options().
+					SetDatabaseType(database.DATABASE_TYPE_POSTGRES).
+					SetDatabaseHost("localhost").
+					SetDatabasePort("5432").
+					SetDatabaseName("test_db").
+					SetSSLMode("always").
+					Verify()
+			},
+			errContains: "ssl mode always is not supported",
+		},
+		{
+			name: "postgres ssl disabled with certificate files",
+			options: func() error {
+				return database.Options().
+					SetDatabaseType(database.DATABASE_TYPE_POSTGRES).
+					SetDatabaseHost("localhost").
+					SetDatabasePort("5432").
+					SetDatabaseName("test_db").
+					SetSSLMode("disable").
+					SetSSLRootCert("/etc/ssl/ca.pem").
+					Verify()
+			},
+			errContains: "ssl mode disable cannot be used with ssl certificate files",
+		},
+		{
+			name: "postgres tls config",
+			options: func() error {
+				return database.Options().
+					SetDatabaseType(database.DATABASE_TYPE_POSTGRES).
+					SetDatabaseHost("localhost").
+					SetDatabasePort("5432").
+					SetDatabaseName("test_db").
+					SetTLSConfig(&tls.Config{}).
+					Verify()
+			},
+			errContains: "tls config is not supported for postgres",
+		},
+		{
+			name: "cert without key",
+			options: func() error {
+				return database.Options().
+					SetDatabaseType(database.DATABASE_TYPE_MYSQL).
+					SetDatabaseHost("localhost").
+					SetDatabasePort("3306").
+					SetDatabaseName("test_db").
+					SetSSLCert("/etc/ssl/client.pem").
+					Verify()
+			},
+			errContains: "ssl cert and ssl key must be set together",
+		},
+		{
+			name: "mysql tls config name with tls config",
+			options: func() error {
+				return database.Options().
+					SetDatabaseType(database.DATABASE_TYPE_MYSQL).
+					SetDatabaseHost("localhost").
+					SetDatabasePort("3306").
+					SetDatabaseName("test_db").
+					SetTLSConfigName("custom").
+					SetTLSConfig(&tls.Config{}).
+					Verify()
+			},
+			errContains: "tls config name cannot be combined",
+		},
+		{
+			name: "sqlite tls config",
+			options: func() error {
+				return database.Options().
+					SetDatabaseType(database.DATABASE_TYPE_SQLITE).
+					SetDatabaseName(":memory:").
+					SetTLSConfigName("true").
+					Verify()
+			},
+			errContains: "tls options are only supported for mysql and postgres",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.options()
+
+			if test.errContains == "" {
+				if err != nil {
+					t.Fatal(`err MUST be nil, found: `, err.Error())
+				}
+				return
+			}
+
+			if err == nil {
+				t.Fatal(`err MUST NOT be nil`)
+			}
+
+			if !strings.Contains(err.Error(), test.errContains) {
+				t.Fatal(`err MUST contain '`+test.errContains+`', found: `, err.Error())
+			}
+		})
+	}
+}
+
+func TestOpenMySQLTLSConfigRequiresRegisterer(t *testing.T) {
+	db, err := database.Open(database.Options().
+		SetDatabaseType(database.DATABASE_TYPE_MYSQL).
+		SetDatabaseHost("localhost").
+		SetDatabasePort("3306").
+		SetDatabaseName("test_db").
+		SetTLSConfig(&tls.Config{}))
+
+	if err == nil {
+		t.Fatal(`err MUST NOT be nil`)
+	}
+
+	if !strings.Contains(err.Error(), `RegisterMySQLTLSConfig must be set`) {
+		t.Fatal(`err MUST contain 'RegisterMySQLTLSConfig must be set', found: `, err.Error())
+	}
+
+	if db != nil {
+		t.Fatal(`db MUST be nil`)
+	}
+}
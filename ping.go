@@ -0,0 +1,86 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+)
+
+// Ping verifies that the database carried by the context is still alive,
+// establishing a connection if necessary. Useful for liveness probes.
+//
+// The context must carry a *sql.DB, as pinging a transaction or
+// a connection is not meaningful.
+//
+// Example usage:
+//
+// err := Ping(database.Context(ctx, db))
+//
+// Parameters:
+// - ctx (QueryableContext): The context carrying the database.
+//
+// Returns:
+// - error: An error if the context does not carry a *sql.DB, or the ping failed.
+func Ping(ctx QueryableContext) error {
+	if ctx.queryable == nil {
+		return ErrNoQueryable
+	}
+
+	if ctx.IsTx() {
+		return errors.New("cannot ping, context carries a transaction, not a db")
+	}
+
+	if ctx.IsConn() {
+		return errors.New("cannot ping, context carries a connection, not a db")
+	}
+
+	db, ok := ctx.queryable.(*sql.DB)
+
+	if !ok {
+		return errors.New("cannot ping, context does not carry a db")
+	}
+
+	return db.PingContext(ctx)
+}
+
+// HealthCheck verifies that the database carried by the context can execute
+// queries, by running SELECT 1, and returns the latency of the check.
+// Useful for readiness probes and monitoring dashboards.
+//
+// If the context carries a *sql.DB, it is pinged first. Transactions and
+// connections are only checked with SELECT 1.
+//
+// Example usage:
+//
+//	latency, err := HealthCheck(database.Context(ctx, db))
+//	if err != nil {
+//		// database is not ready
+//	}
+//
+// Parameters:
+// - ctx (QueryableContext): The context carrying the database.
+//
+// Returns:
+// - time.Duration: The time it took to complete the check.
+// - error: An error if the ping or the query failed.
+func HealthCheck(ctx QueryableContext) (time.Duration, error) {
+	if ctx.queryable == nil {
+		return 0, ErrNoQueryable
+	}
+
+	start := time.Now()
+
+	if ctx.IsDB() {
+		if err := Ping(ctx); err != nil {
+			return time.Since(start), err
+		}
+	}
+
+	var one int
+
+	if err := ctx.queryable.QueryRowContext(ctx, "SELECT 1").Scan(&one); err != nil {
+		return time.Since(start), err
+	}
+
+	return time.Since(start), nil
+}
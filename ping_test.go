@@ -0,0 +1,85 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	database "github.com/dracory/database"
+)
+
+func TestPing(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	// Test nil querier error
+	err = database.Ping(database.Context(context.Background(), nil))
+	if err == nil {
+		t.Error("Expected error for nil querier")
+	}
+
+	// Test successful ping
+	err = database.Ping(database.Context(context.Background(), db))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Test ping on a transaction
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+
+	err = database.Ping(database.Context(context.Background(), tx))
+	if err == nil {
+		t.Error("Expected error for transaction")
+	}
+
+	// Test ping on a closed database
+	db.Close()
+	err = database.Ping(database.Context(context.Background(), db))
+	if err == nil {
+		t.Error("Expected error for closed database")
+	}
+}
+
+func TestHealthCheck(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	latency, err := database.HealthCheck(database.Context(context.Background(), db))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if latency <= 0 {
+		t.Errorf("Expected positive latency, got %v", latency)
+	}
+
+	// Test health check on a transaction
+	tx, err := db.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = database.HealthCheck(database.Context(context.Background(), tx))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if err := tx.Rollback(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Test health check on a closed database
+	db.Close()
+	_, err = database.HealthCheck(database.Context(context.Background(), db))
+	if err == nil {
+		t.Error("Expected error for closed database")
+	}
+}
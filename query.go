@@ -1,9 +1,6 @@
 package database
 
-import (
-	"database/sql"
-	"errors"
-)
+import "database/sql"
 
 // Query executes a SQL query in the given context and returns a *sql.Rows object containing the query results.
 //
@@ -26,12 +23,21 @@ import (
 func Query(ctx QueryableContext, sqlStr string, args ...any) (*sql.Rows, error) {
 	// Check for nil querier
 	if ctx.queryable == nil {
-		return nil, errors.New("querier (db/tx/conn) is nil")
+		return nil, ErrNoQueryable
 	}
 
 	// Ensure the context is properly wrapped with the queryable
 	ctx = NewQueryableContextOr(ctx, ctx.queryable)
 
 	// Execute the query in the context
-	return ctx.queryable.QueryContext(ctx, sqlStr, args...)
+	rows, run, err := ctx.queryContext("Query", sqlStr, args...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// The rows are read by the caller, so the number of rows is unknown
+	run.end(-1, nil)
+
+	return rows, nil
 }
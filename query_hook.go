@@ -0,0 +1,41 @@
+package database
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// QueryHook is called around each SQL statement executed through the
+// package helpers. It is the extension point for integrations, which need
+// to act both before and after the statement, i.e. tracing.
+type QueryHook interface {
+	// BeforeQuery is called before the statement is executed. The Duration,
+	// RowsAffected, RowsReturned and Error fields of info are not set yet.
+	//
+	// The returned context is used to execute the statement,
+	// and is passed to AfterQuery.
+	BeforeQuery(ctx context.Context, info QueryLog) context.Context
+
+	// AfterQuery is called after the statement is executed,
+	// and for the Select helpers after the rows are read.
+	AfterQuery(ctx context.Context, info QueryLog)
+}
+
+// queryHook is the package level query hook, nil if not set
+var queryHook atomic.Pointer[QueryHook]
+
+// SetQueryHook sets a package level hook, which is called around each
+// SQL statement executed through the package helpers.
+//
+// Setting it to nil removes the hook. It is safe for concurrent use.
+//
+// Parameters:
+// - hook: The hook, or nil.
+func SetQueryHook(hook QueryHook) {
+	if hook == nil {
+		queryHook.Store(nil)
+		return
+	}
+
+	queryHook.Store(&hook)
+}
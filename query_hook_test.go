@@ -0,0 +1,120 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	database "github.com/dracory/database"
+)
+
+type hookContextKey struct{}
+
+type testQueryHook struct {
+	before []database.QueryLog
+	after  []database.QueryLog
+	values []any
+}
+
+func (h *testQueryHook) BeforeQuery(ctx context.Context, info database.QueryLog) context.Context {
+	h.before = append(h.before, info)
+	return context.WithValue(ctx, hookContextKey{}, len(h.before))
+}
+
+func (h *testQueryHook) AfterQuery(ctx context.Context, info database.QueryLog) {
+	h.after = append(h.after, info)
+	h.values = append(h.values, ctx.Value(hookContextKey{}))
+}
+
+func TestSetQueryHook(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	err = createUserTableAndInserTesttData(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hook := &testQueryHook{}
+	database.SetQueryHook(hook)
+	defer database.SetQueryHook(nil)
+
+	ctx := database.Context(context.Background(), db)
+
+	_, err = database.Execute(ctx, "UPDATE users SET name = ? WHERE id = ?", "Updated", 1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	_, err = database.SelectToMapAny(ctx, "SELECT * FROM users")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(hook.before) != 2 || len(hook.after) != 2 {
+		t.Fatalf("Expected 2 before and 2 after calls, got %d and %d", len(hook.before), len(hook.after))
+	}
+
+	if hook.before[0].Operation != "Execute" || hook.before[0].RowsAffected != -1 {
+		t.Errorf("Unexpected before info: %+v", hook.before[0])
+	}
+
+	if hook.after[0].RowsAffected != 1 || hook.after[1].RowsReturned != 3 {
+		t.Errorf("Expected 1 row affected and 3 rows returned, got %d and %d", hook.after[0].RowsAffected, hook.after[1].RowsReturned)
+	}
+
+	if hook.values[0] != 1 || hook.values[1] != 2 {
+		t.Errorf("Expected the context returned by BeforeQuery in AfterQuery, got %v", hook.values)
+	}
+
+	// Test the hook can be removed
+	database.SetQueryHook(nil)
+
+	_, err = database.Execute(ctx, "DELETE FROM users")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(hook.after) != 2 {
+		t.Errorf("Expected no more calls after removing the hook, got %d", len(hook.after))
+	}
+}
+
+func TestSetQueryHookReplacedDuringQuery(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	err = createUserTableAndInserTesttData(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first := &testQueryHook{}
+	second := &testQueryHook{}
+	database.SetQueryHook(first)
+	defer database.SetQueryHook(nil)
+
+	ctx := database.Context(context.Background(), db)
+
+	err = database.Stream(ctx, "SELECT * FROM users", func(row map[string]any) error {
+		database.SetQueryHook(second)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Test AfterQuery is called on the hook which got BeforeQuery
+	if len(first.before) != 1 || len(first.after) != 1 {
+		t.Errorf("Expected 1 before and 1 after call on the first hook, got %d and %d", len(first.before), len(first.after))
+	}
+
+	if len(second.before) != 0 || len(second.after) != 0 {
+		t.Errorf("Expected no calls on the second hook, got %d and %d", len(second.before), len(second.after))
+	}
+}
@@ -0,0 +1,77 @@
+package database
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// QueryLog contains information about a single SQL statement executed
+// through the package helpers, i.e. Query, Execute and the Select helpers.
+type QueryLog struct {
+	// Operation is the name of the helper that executed the statement, i.e. "Execute"
+	Operation string
+
+	// SQL is the executed SQL statement
+	SQL string
+
+	// Args are the arguments passed to the statement
+	Args []any
+
+	// Dialect is the database type, i.e. "postgres" (see DatabaseType)
+	Dialect string
+
+	// Duration is the time it took to execute the statement,
+	// including reading the rows for the Select helpers
+	Duration time.Duration
+
+	// RowsAffected is the number of rows affected by Execute, -1 otherwise
+	RowsAffected int64
+
+	// RowsReturned is the number of rows read by the Select helpers,
+	// -1 if unknown, i.e. for Query where the rows are read by the caller
+	RowsReturned int64
+
+	// Error is the error returned by the statement, if any
+	Error error
+
+	// Context is the context the statement was executed with, including
+	// the values set by the query hook, i.e. to read a request id from it
+	Context context.Context
+
+	// DryRun is true if the statement was not executed, see WithDryRun
+	DryRun bool
+}
+
+// queryLogger is the package level query logger, nil if not set
+var queryLogger atomic.Pointer[func(info QueryLog)]
+
+// SetLogger sets a package level function, which is called after each
+// SQL statement executed through the package helpers.
+//
+// Setting it to nil disables the logging. When not set, no query
+// information is collected. It is safe for concurrent use.
+//
+// Example:
+//
+//	database.SetLogger(func(info database.QueryLog) {
+//		slog.Debug("sql", "op", info.Operation, "sql", info.SQL, "duration", info.Duration, "error", info.Error)
+//	})
+//
+// The context of the statement is passed in QueryLog.Context, so that
+// request scoped values, i.e. a request id, can be added to the log entries:
+//
+//	database.SetLogger(func(info database.QueryLog) {
+//		slog.DebugContext(info.Context, "sql", "sql", info.SQL, "request_id", info.Context.Value(requestIDKey))
+//	})
+//
+// Parameters:
+// - logger: The function to call with the query information, or nil.
+func SetLogger(logger func(info QueryLog)) {
+	if logger == nil {
+		queryLogger.Store(nil)
+		return
+	}
+
+	queryLogger.Store(&logger)
+}
@@ -0,0 +1,108 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	database "github.com/dracory/database"
+)
+
+func TestSetLogger(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	err = createUserTableAndInserTesttData(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	logs := []database.QueryLog{}
+	database.SetLogger(func(info database.QueryLog) {
+		logs = append(logs, info)
+	})
+	defer database.SetLogger(nil)
+
+	ctx := database.Context(context.Background(), db)
+
+	_, err = database.Execute(ctx, "UPDATE users SET name = ? WHERE id > ?", "Updated", 1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	_, err = database.SelectToMapString(ctx, "SELECT * FROM users")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	_, err = database.SelectToMapAny(ctx, "INVALID SQL")
+	if err == nil {
+		t.Fatal("Expected error for invalid SQL")
+	}
+
+	if len(logs) != 3 {
+		t.Fatalf("Expected 3 logs, got %d", len(logs))
+	}
+
+	if logs[0].Operation != "Execute" || logs[0].SQL != "UPDATE users SET name = ? WHERE id > ?" {
+		t.Errorf("Unexpected execute log: %+v", logs[0])
+	}
+	if logs[0].RowsAffected != 2 || len(logs[0].Args) != 2 {
+		t.Errorf("Expected 2 rows affected and 2 args, got %d and %d", logs[0].RowsAffected, len(logs[0].Args))
+	}
+	if logs[0].Dialect != database.DATABASE_TYPE_SQLITE {
+		t.Errorf("Expected dialect %q, got %q", database.DATABASE_TYPE_SQLITE, logs[0].Dialect)
+	}
+
+	if logs[1].Operation != "SelectToMapString" || logs[1].RowsReturned != 3 || logs[1].Error != nil {
+		t.Errorf("Unexpected select log: %+v", logs[1])
+	}
+
+	if logs[2].Error == nil {
+		t.Error("Expected error to be logged")
+	}
+
+	// Test the logger can be disabled
+	database.SetLogger(nil)
+
+	_, err = database.Count(ctx, "SELECT COUNT(*) FROM users")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(logs) != 3 {
+		t.Errorf("Expected no more logs after disabling, got %d", len(logs))
+	}
+}
+
+func TestSetLoggerContext(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	type requestIDKey struct{}
+
+	requestIDs := []any{}
+	database.SetLogger(func(info database.QueryLog) {
+		requestIDs = append(requestIDs, info.Context.Value(requestIDKey{}))
+	})
+	defer database.SetLogger(nil)
+
+	ctx := database.Context(context.Background(), db).WithValue(requestIDKey{}, "req-1")
+
+	if _, err := database.Execute(ctx, "CREATE TABLE logs (id INTEGER)"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := database.SelectToMapAny(ctx, "SELECT * FROM logs"); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(requestIDs) != 2 || requestIDs[0] != "req-1" || requestIDs[1] != "req-1" {
+		t.Errorf("Expected the request id in every log, got %v", requestIDs)
+	}
+}
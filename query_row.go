@@ -0,0 +1,85 @@
+package database
+
+import (
+	"database/sql"
+	"reflect"
+	"unsafe"
+)
+
+// QueryRow executes a SQL query that is expected to return at most one row
+// in the given context, and returns the row to be scanned by the caller.
+//
+// It mirrors QueryRowContext of the standard library, but goes through the
+// QueryableContext, so the placeholder rebinding, logging and other hooks
+// apply. As with the standard library, errors are deferred until Scan is
+// called, including the error for a nil querier, and sql.ErrNoRows is
+// returned by Scan if the query returns no rows.
+//
+// Example usage:
+//
+//	var name string
+//	err := QueryRow(ctx, "SELECT name FROM users WHERE id = ?", 1).Scan(&name)
+//	if errors.Is(err, sql.ErrNoRows) {
+//		return errors.New("user not found")
+//	}
+//
+// Parameters:
+// - ctx (QueryableContext): The context to use for the query execution.
+// - sqlStr (string): The SQL query to execute.
+// - args (any): Optional arguments to pass to the query.
+//
+// Returns:
+// - *sql.Row: The row to scan, never nil.
+func QueryRow(ctx QueryableContext, sqlStr string, args ...any) *sql.Row {
+	if ctx.queryable == nil {
+		return errorRow(ErrNoQueryable)
+	}
+
+	row, run := ctx.queryRowContext("QueryRow", sqlStr, args...)
+
+	// The row is scanned by the caller, so the number of rows is unknown
+	run.end(-1, nil)
+
+	return row
+}
+
+// QueryRowMap executes a SQL query that is expected to return at most one row
+// in the given context, and returns the row as a map, the same way as
+// SelectToMapAny. It is the map analog of QueryRow(...).Scan(...).
+//
+// If the query returns no rows, ErrNoRows is returned. Any rows after the
+// first one are ignored, so there is no need for a LIMIT.
+//
+// Example usage:
+//
+//	user, err := QueryRowMap(ctx, "SELECT * FROM users WHERE id = ?", 1)
+//	if errors.Is(err, database.ErrNoRows) {
+//		return nil, errors.New("user not found")
+//	}
+//
+// Parameters:
+// - ctx (QueryableContext): The context to use for the query execution.
+// - sqlStr (string): The SQL query to execute.
+// - args (any): Optional arguments to pass to the query.
+//
+// Returns:
+// - map[string]any: The first row of the results.
+// - error: ErrNoRows if no rows were found, or an error if the query failed.
+func QueryRowMap(ctx QueryableContext, sqlStr string, args ...any) (map[string]any, error) {
+	return selectOneMap(ctx, "QueryRowMap", sqlStr, args...)
+}
+
+// errorRow returns a *sql.Row which returns the error from Scan and Err.
+//
+// The standard library does not allow creating a *sql.Row with an error,
+// so the private err field is set using reflection.
+//
+// #nosec G103 - we use unsafe deliberately to set the private field of sql.Row
+func errorRow(err error) *sql.Row {
+	row := &sql.Row{}
+
+	errField := reflect.ValueOf(row).Elem().FieldByName("err")
+	reflect.NewAt(errField.Type(), unsafe.Pointer(errField.UnsafeAddr())).Elem().Set(reflect.ValueOf(&err).Elem())
+
+	return row
+}
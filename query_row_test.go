@@ -0,0 +1,89 @@
+package database_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	database "github.com/dracory/database"
+)
+
+func TestQueryRow(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	err = createUserTableAndInserTesttData(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Test nil querier error is returned by Scan
+	var name string
+	err = database.QueryRow(database.Context(context.Background(), nil), "SELECT name FROM users").Scan(&name)
+	if err == nil || err.Error() != "querier (db/tx/conn) is nil" {
+		t.Errorf("Expected nil querier error, got %v", err)
+	}
+
+	ctx := database.Context(context.Background(), db)
+
+	// Test row is scanned
+	var email string
+	err = database.QueryRow(ctx, "SELECT name, email FROM users WHERE id = ?", 2).Scan(&name, &email)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if name != "Bob" || email != "bob@example.com" {
+		t.Errorf("Unexpected row: %s, %s", name, email)
+	}
+
+	// Test no rows
+	err = database.QueryRow(ctx, "SELECT name FROM users WHERE id = ?", 999).Scan(&name)
+	if !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("Expected sql.ErrNoRows, got %v", err)
+	}
+}
+
+func TestQueryRowMap(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	if _, err := database.QueryRowMap(database.Context(context.Background(), nil), "SELECT * FROM users"); !errors.Is(err, database.ErrNoQueryable) {
+		t.Errorf("Expected ErrNoQueryable, got %v", err)
+	}
+
+	if err := createUserTableAndInserTesttData(db); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := database.Context(context.Background(), db)
+
+	// The rows after the first one are ignored
+	row, err := database.QueryRowMap(ctx, "SELECT * FROM users ORDER BY id DESC")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if row["name"] != "Charlie" || row["email"] != "charlie@example.com" {
+		t.Errorf("Unexpected row: %v", row)
+	}
+
+	row, err = database.QueryRowMap(ctx, "SELECT * FROM users WHERE id = ?", 42)
+	if !errors.Is(err, database.ErrNoRows) || !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("Expected ErrNoRows, got %v", err)
+	}
+
+	if row != nil {
+		t.Errorf("Expected nil row, got %v", row)
+	}
+
+	if _, err := database.QueryRowMap(ctx, "INVALID SQL"); err == nil {
+		t.Error("Expected error for invalid SQL")
+	}
+}
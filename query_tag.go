@@ -0,0 +1,76 @@
+package database
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+)
+
+// queryTagger is the package level query tagger, nil if not set
+var queryTagger atomic.Pointer[func(ctx context.Context) string]
+
+// SetQueryTagger sets a package level function, which returns a tag for
+// the context of each SQL statement executed through the package helpers.
+// The tag is prepended to the statement as a comment, i.e. /* app:orders,req:abc123 */,
+// so the statements in the slow log of the database can be correlated
+// with the application.
+//
+// To keep the statement intact, the characters of the tag other than letters,
+// digits, spaces and , : = . _ - are replaced with _. An empty tag adds no comment.
+//
+// Setting it to nil disables the tagging. It is safe for concurrent use.
+//
+// Note that each distinct tag is a distinct statement, so a tag that changes
+// with each request (i.e. a request id) defeats the prepared statement cache
+// (see SetPreparedStatementCache).
+//
+// Example:
+//
+//	database.SetQueryTagger(func(ctx context.Context) string {
+//		requestID, _ := ctx.Value(requestIDKey).(string)
+//		return "app:orders,req:" + requestID
+//	})
+//
+// Parameters:
+// - tagger: The function returning the tag for the context, or nil.
+func SetQueryTagger(tagger func(ctx context.Context) string) {
+	if tagger == nil {
+		queryTagger.Store(nil)
+		return
+	}
+
+	queryTagger.Store(&tagger)
+}
+
+// tagSQL prepends the tag of the context to the SQL statement as a comment,
+// or returns the statement as is if no tagger is set or the tag is empty
+func tagSQL(ctx context.Context, sqlStr string) string {
+	tagger := queryTagger.Load()
+	if tagger == nil {
+		return sqlStr
+	}
+
+	tag := sanitizeQueryTag((*tagger)(ctx))
+	if tag == "" {
+		return sqlStr
+	}
+
+	return "/* " + tag + " */ " + sqlStr
+}
+
+// sanitizeQueryTag replaces the characters, which could end the comment
+// or be taken for a placeholder or a quote by the driver, with _
+func sanitizeQueryTag(tag string) string {
+	tag = strings.TrimSpace(tag)
+
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		case strings.ContainsRune(" ,:=._-", r):
+			return r
+		default:
+			return '_'
+		}
+	}, tag)
+}
@@ -0,0 +1,81 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	database "github.com/dracory/database"
+)
+
+func TestSetQueryTagger(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	if err := createUserTableAndInserTesttData(db); err != nil {
+		t.Fatal(err)
+	}
+
+	logs := []database.QueryLog{}
+	database.SetLogger(func(info database.QueryLog) {
+		logs = append(logs, info)
+	})
+	defer database.SetLogger(nil)
+
+	database.SetQueryTagger(func(ctx context.Context) string {
+		requestID, _ := ctx.Value(testContextKey{}).(string)
+		if requestID == "" {
+			return ""
+		}
+		return "app:test,req:" + requestID
+	})
+	defer database.SetQueryTagger(nil)
+
+	ctx := database.Context(context.WithValue(context.Background(), testContextKey{}, "abc123"), db)
+
+	count, err := database.Count(ctx, "SELECT COUNT(*) FROM users")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if count != 3 {
+		t.Errorf("Expected count 3, got %d", count)
+	}
+
+	// A tag trying to end the comment is sanitized
+	ctx = database.Context(context.WithValue(context.Background(), testContextKey{}, "x */ DROP TABLE users; /* ?"), db)
+
+	if _, err := database.Execute(ctx, "UPDATE users SET name = ? WHERE id = ?", "Bob", 2); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// No comment for an empty tag
+	if _, err := database.Execute(database.Context(context.Background(), db), "DELETE FROM users WHERE id = ?", 3); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	database.SetQueryTagger(nil)
+
+	if _, err := database.Execute(ctx, "DELETE FROM users WHERE id = ?", 2); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []string{
+		"/* app:test,req:abc123 */ SELECT COUNT(*) FROM users",
+		"/* app:test,req:x __ DROP TABLE users_ __ _ */ UPDATE users SET name = ? WHERE id = ?",
+		"DELETE FROM users WHERE id = ?",
+		"DELETE FROM users WHERE id = ?",
+	}
+
+	if len(logs) != len(expected) {
+		t.Fatalf("Expected %d logs, got %d", len(expected), len(logs))
+	}
+
+	for i, sqlStr := range expected {
+		if logs[i].SQL != sqlStr {
+			t.Errorf("Expected SQL %q, got %q", sqlStr, logs[i].SQL)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package database
+
+import "strings"
+
+// Rebind rewrites the ? placeholders in the SQL query into the placeholder
+// style of the given database type.
+//
+// Placeholder styles:
+//   - Postgres (including pgx): $1, $2, ...
+//   - MSSQL: @p1, @p2, ...
+//   - MySQL, SQLite and others: ? (the query is returned unchanged)
+//
+// Question marks inside string literals ('...'), quoted identifiers
+// ("...", `...`, and [...] for MSSQL) and comments (-- and /* */)
+// are left untouched.
+//
+// Example usage:
+//
+//	sqlStr := Rebind(DATABASE_TYPE_POSTGRES, "SELECT * FROM users WHERE name = ? AND age > ?")
+//	// SELECT * FROM users WHERE name = $1 AND age > $2
+//
+// Parameters:
+// - dbType (string): The database type, i.e. DATABASE_TYPE_POSTGRES.
+// - sqlStr (string): The SQL query with ? placeholders.
+//
+// Returns:
+// - string: The SQL query with the placeholders of the database type.
+func Rebind(dbType string, sqlStr string) string {
+	dialect := DialectFor(dbType)
+
+	// The dialect uses ? placeholders, nothing to rewrite
+	if dialect.Placeholder(1) == "?" {
+		return sqlStr
+	}
+
+	if !strings.Contains(sqlStr, "?") {
+		return sqlStr
+	}
+
+	var b strings.Builder
+	b.Grow(len(sqlStr) + 10)
+
+	n := 0
+	bracketQuotes := dialect.Name() == DATABASE_TYPE_MSSQL
+
+	for i := 0; i < len(sqlStr); i++ {
+		if end := skipLiteralOrComment(sqlStr, i, bracketQuotes); end > i {
+			b.WriteString(sqlStr[i:end])
+			i = end - 1
+			continue
+		}
+
+		if sqlStr[i] == '?' {
+			n++
+			b.WriteString(dialect.Placeholder(n))
+			continue
+		}
+
+		b.WriteByte(sqlStr[i])
+	}
+
+	return b.String()
+}
+
+// skipLiteralOrComment checks if a string literal, a quoted identifier
+// or a comment starts at position i, and returns the index right after it.
+// Otherwise it returns i.
+//
+// Square brackets are treated as quoted identifiers only if bracketQuotes
+// is true (MSSQL), as in other dialects they are used for arrays.
+func skipLiteralOrComment(sqlStr string, i int, bracketQuotes bool) int {
+	c := sqlStr[i]
+
+	switch {
+	case c == '\'' || c == '"' || c == '`' || (c == '[' && bracketQuotes):
+		return skipQuoted(sqlStr, i)
+	case c == '-' && i+1 < len(sqlStr) && sqlStr[i+1] == '-':
+		end := strings.IndexByte(sqlStr[i:], '\n')
+		if end == -1 {
+			return len(sqlStr)
+		}
+		return i + end
+	case c == '/' && i+1 < len(sqlStr) && sqlStr[i+1] == '*':
+		end := strings.Index(sqlStr[i+2:], "*/")
+		if end == -1 {
+			return len(sqlStr)
+		}
+		return i + 2 + end + 2
+	}
+
+	return i
+}
+
+// skipQuoted returns the index right after the quoted section
+// starting at position start. Doubled closing quotes are treated
+// as escaped quotes, i.e. 'it''s'.
+func skipQuoted(sqlStr string, start int) int {
+	closing := sqlStr[start]
+	if closing == '[' {
+		closing = ']'
+	}
+
+	for i := start + 1; i < len(sqlStr); i++ {
+		if sqlStr[i] != closing {
+			continue
+		}
+
+		if i+1 < len(sqlStr) && sqlStr[i+1] == closing {
+			i++
+			continue
+		}
+
+		return i + 1
+	}
+
+	return len(sqlStr)
+}
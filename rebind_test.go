@@ -0,0 +1,110 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	database "github.com/dracory/database"
+)
+
+func TestRebind(t *testing.T) {
+	tests := []struct {
+		name     string
+		dbType   string
+		sql      string
+		expected string
+	}{
+		{
+			name:     "mysql unchanged",
+			dbType:   database.DATABASE_TYPE_MYSQL,
+			sql:      "SELECT * FROM users WHERE id = ?",
+			expected: "SELECT * FROM users WHERE id = ?",
+		},
+		{
+			name:     "sqlite unchanged",
+			dbType:   database.DATABASE_TYPE_SQLITE,
+			sql:      "SELECT * FROM users WHERE id = ?",
+			expected: "SELECT * FROM users WHERE id = ?",
+		},
+		{
+			name:     "postgres",
+			dbType:   database.DATABASE_TYPE_POSTGRES,
+			sql:      "SELECT * FROM users WHERE name = ? AND age > ?",
+			expected: "SELECT * FROM users WHERE name = $1 AND age > $2",
+		},
+		{
+			name:     "pgx",
+			dbType:   database.DATABASE_TYPE_PGX,
+			sql:      "UPDATE users SET name = ? WHERE id = ?",
+			expected: "UPDATE users SET name = $1 WHERE id = $2",
+		},
+		{
+			name:     "mssql",
+			dbType:   database.DATABASE_TYPE_MSSQL,
+			sql:      "SELECT * FROM [users?] WHERE name = ? AND age > ?",
+			expected: "SELECT * FROM [users?] WHERE name = @p1 AND age > @p2",
+		},
+		{
+			name:     "string literals and identifiers",
+			dbType:   database.DATABASE_TYPE_POSTGRES,
+			sql:      `SELECT 'what?', 'it''s ?', "col?" FROM t WHERE a = ?`,
+			expected: `SELECT 'what?', 'it''s ?', "col?" FROM t WHERE a = $1`,
+		},
+		{
+			name:     "comments",
+			dbType:   database.DATABASE_TYPE_POSTGRES,
+			sql:      "SELECT * FROM t -- why?\nWHERE a = ? /* and b = ? */ AND c = ?",
+			expected: "SELECT * FROM t -- why?\nWHERE a = $1 /* and b = ? */ AND c = $2",
+		},
+		{
+			name:     "postgres arrays",
+			dbType:   database.DATABASE_TYPE_POSTGRES,
+			sql:      "SELECT ARRAY[?, ?]",
+			expected: "SELECT ARRAY[$1, $2]",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := database.Rebind(test.dbType, test.sql)
+			if result != test.expected {
+				t.Errorf("Expected %q, got %q", test.expected, result)
+			}
+		})
+	}
+}
+
+func TestQueryableContextWithRebind(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	err = createUserTableAndInserTesttData(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// SQLite uses ? placeholders, so the query must work unchanged
+	ctx := database.Context(context.Background(), db).WithRebind()
+
+	count, err := database.Count(ctx, "SELECT COUNT(*) FROM users WHERE id > ?", 1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("Expected count 2, got %d", count)
+	}
+
+	txCtx, err := ctx.BeginTx(nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer txCtx.Queryable().(interface{ Rollback() error }).Rollback()
+
+	_, err = database.Execute(txCtx, "DELETE FROM users WHERE id = ?", 1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,260 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// queryRun tracks a single statement executed through the package helpers,
+// and reports it to the hooks when it ends. It is nil when no hooks are set,
+// and all its methods are nil-safe, so there is no overhead.
+type queryRun struct {
+	ctx   context.Context
+	info  QueryLog
+	start time.Time
+
+	// hook is the query hook which got BeforeQuery, so that
+	// AfterQuery is called on the same hook, even if replaced meanwhile
+	hook *QueryHook
+}
+
+// beginRun starts tracking a statement, or returns nil if no hooks are set
+func (ctx QueryableContext) beginRun(operation string, sqlStr string, args []any) *queryRun {
+	hook := queryHook.Load()
+
+	if queryLogger.Load() == nil && !isSlowQueryEnabled() && queryMetrics.Load() == nil && hook == nil {
+		return nil
+	}
+
+	run := &queryRun{
+		ctx:  ctx,
+		hook: hook,
+		info: QueryLog{
+			Operation:    operation,
+			SQL:          sqlStr,
+			Args:         args,
+			Dialect:      ctx.databaseType(),
+			RowsAffected: -1,
+			RowsReturned: -1,
+			Context:      ctx,
+			DryRun:       ctx.dryRun,
+		},
+	}
+
+	if hook != nil {
+		run.ctx = (*hook).BeforeQuery(ctx, run.info)
+		run.info.Context = run.context(ctx)
+	}
+
+	run.start = time.Now()
+
+	return run
+}
+
+// context returns the context to execute the statement with,
+// which may have been replaced by the query hook
+func (run *queryRun) context(ctx QueryableContext) context.Context {
+	if run == nil || run.ctx == nil {
+		return ctx
+	}
+
+	return run.ctx
+}
+
+// end finishes tracking a query, and reports it to the hooks
+func (run *queryRun) end(rowsReturned int64, err error) {
+	if run == nil {
+		return
+	}
+
+	run.info.Duration = time.Since(run.start)
+	run.info.RowsReturned = rowsReturned
+	run.info.Error = err
+
+	if logger := queryLogger.Load(); logger != nil {
+		(*logger)(run.info)
+	}
+
+	threshold := time.Duration(slowQueryThreshold.Load())
+
+	if handler := slowQueryHandler.Load(); handler != nil && threshold > 0 && run.info.Duration > threshold {
+		(*handler)(run.info)
+	}
+
+	if metrics := queryMetrics.Load(); metrics != nil {
+		(*metrics).ObserveQuery(run.info.Operation, run.info.Dialect, run.info.Duration, err)
+	}
+
+	if run.hook != nil {
+		(*run.hook).AfterQuery(run.ctx, run.info)
+	}
+}
+
+// endExec finishes tracking a statement executed with ExecContext
+func (run *queryRun) endExec(result sql.Result, err error) {
+	if run == nil {
+		return
+	}
+
+	if result != nil && err == nil {
+		if rowsAffected, rowsErr := result.RowsAffected(); rowsErr == nil {
+			run.info.RowsAffected = rowsAffected
+		}
+	}
+
+	run.end(-1, err)
+}
+
+// queryContext executes a query on the queryable carried by the context,
+// applying the options of the context, i.e. placeholder rebinding.
+//
+// The returned run must be ended by the caller after reading the rows.
+// If the query fails, the run is already ended.
+func (ctx QueryableContext) queryContext(operation string, sqlStr string, args ...any) (*sql.Rows, *queryRun, error) {
+	sqlStr = ctx.prepareSQL(sqlStr)
+	ctx.recordQuery(sqlStr, args)
+	run := ctx.beginRun(operation, sqlStr, args)
+
+	rows, err := ctx.query(run.context(ctx), sqlStr, args...)
+
+	if err != nil {
+		run.end(-1, err)
+		return nil, nil, err
+	}
+
+	return rows, run, nil
+}
+
+// queryRowContext executes a query that is expected to return at most one row
+// on the queryable carried by the context, applying the options of the context.
+//
+// The returned run must be ended by the caller after scanning the row.
+// If preparing the cached statement fails, the error is returned by Scan.
+func (ctx QueryableContext) queryRowContext(operation string, sqlStr string, args ...any) (*sql.Row, *queryRun) {
+	sqlStr = ctx.prepareSQL(sqlStr)
+	ctx.recordQuery(sqlStr, args)
+	run := ctx.beginRun(operation, sqlStr, args)
+
+	execCtx := run.context(ctx)
+
+	// An already cancelled context short-circuits before reaching the driver
+	err := execCtx.Err()
+
+	var stmt *sql.Stmt
+
+	if err == nil {
+		stmt, err = cachedStmt(execCtx, ctx.queryable, sqlStr)
+	}
+
+	// The run is already ended, so nil is returned for it
+	if err != nil {
+		run.end(-1, err)
+		return errorRow(err), nil
+	}
+
+	if stmt != nil {
+		row := stmt.QueryRowContext(execCtx, args...)
+
+		// The statement may have been evicted and closed meanwhile
+		if !isStatementClosedError(row.Err()) {
+			return row, run
+		}
+	}
+
+	return ctx.queryable.QueryRowContext(execCtx, sqlStr, args...), run
+}
+
+// execContext executes a statement on the queryable carried by the context,
+// applying the options of the context.
+func (ctx QueryableContext) execContext(operation string, sqlStr string, args ...any) (sql.Result, error) {
+	sqlStr = ctx.prepareSQL(sqlStr)
+	ctx.recordQuery(sqlStr, args)
+	run := ctx.beginRun(operation, sqlStr, args)
+
+	// In dry run mode the statement is only reported to the hooks
+	if ctx.dryRun {
+		run.endExec(dryRunResult{}, nil)
+		return dryRunResult{}, nil
+	}
+
+	result, err := ctx.exec(run.context(ctx), sqlStr, args...)
+
+	run.endExec(result, err)
+
+	return result, err
+}
+
+// query executes the query using a cached prepared statement if enabled,
+// or directly on the queryable otherwise
+func (ctx QueryableContext) query(execCtx context.Context, sqlStr string, args ...any) (*sql.Rows, error) {
+	// An already cancelled context short-circuits before reaching the driver
+	if err := execCtx.Err(); err != nil {
+		return nil, err
+	}
+
+	stmt, err := cachedStmt(execCtx, ctx.queryable, sqlStr)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if stmt == nil {
+		return ctx.queryable.QueryContext(execCtx, sqlStr, args...)
+	}
+
+	rows, err := stmt.QueryContext(execCtx, args...)
+
+	// The statement may have been evicted and closed meanwhile
+	if isStatementClosedError(err) {
+		return ctx.queryable.QueryContext(execCtx, sqlStr, args...)
+	}
+
+	if err != nil {
+		preparedStatementCache.Load().invalidate(ctx.queryable.(*sql.DB), sqlStr, err)
+	}
+
+	return rows, err
+}
+
+// exec executes the statement using a cached prepared statement if enabled,
+// or directly on the queryable otherwise
+func (ctx QueryableContext) exec(execCtx context.Context, sqlStr string, args ...any) (sql.Result, error) {
+	// An already cancelled context short-circuits before reaching the driver
+	if err := execCtx.Err(); err != nil {
+		return nil, err
+	}
+
+	stmt, err := cachedStmt(execCtx, ctx.queryable, sqlStr)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if stmt == nil {
+		return ctx.queryable.ExecContext(execCtx, sqlStr, args...)
+	}
+
+	result, err := stmt.ExecContext(execCtx, args...)
+
+	// The statement may have been evicted and closed meanwhile
+	if isStatementClosedError(err) {
+		return ctx.queryable.ExecContext(execCtx, sqlStr, args...)
+	}
+
+	if err != nil {
+		preparedStatementCache.Load().invalidate(ctx.queryable.(*sql.DB), sqlStr, err)
+	}
+
+	return result, err
+}
+
+// prepareSQL applies the options of the context to the SQL query
+func (ctx QueryableContext) prepareSQL(sqlStr string) string {
+	if ctx.rebind {
+		sqlStr = Rebind(ctx.databaseType(), sqlStr)
+	}
+
+	// The tag is added after rebinding, so it is not rewritten
+	return tagSQL(ctx, sqlStr)
+}
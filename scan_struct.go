@@ -0,0 +1,169 @@
+package database
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// ScanStruct executes a SQL query in the given context and scans the first row
+// of the results into the struct pointed to by dest. The columns are mapped to
+// the fields the same way as in SelectToStructs, and the fields without
+// a matching column are left as they are.
+//
+// It is the non-generic alternative of SelectOne, for code with a preallocated
+// struct. If the query returns no rows, ErrNoRows is returned and dest is
+// not changed. Any rows after the first one are ignored.
+//
+// Example usage:
+//
+//	var user User
+//	err := ScanStruct(ctx, &user, "SELECT * FROM users WHERE id = ?", 1)
+//	if errors.Is(err, database.ErrNoRows) {
+//		return errors.New("user not found")
+//	}
+//
+// Parameters:
+// - ctx (QueryableContext): The context to use for the query execution.
+// - dest (any): A non-nil pointer to the struct to scan the row into.
+// - sqlStr (string): The SQL query to execute.
+// - args (any): Optional arguments to pass to the query.
+//
+// Returns:
+// - error: ErrNoRows if no rows were found, or an error if dest is not
+// a pointer to a struct, or the query failed.
+func ScanStruct(ctx QueryableContext, dest any, sqlStr string, args ...any) error {
+	if ctx.queryable == nil {
+		return ErrNoQueryable
+	}
+
+	v := reflect.ValueOf(dest)
+
+	if v.Kind() != reflect.Pointer || v.IsNil() || !isMappableStruct(v.Elem().Type()) {
+		return fmt.Errorf("dest must be a non-nil pointer to a struct, got %T", dest)
+	}
+
+	rows, run, err := ctx.queryContext("ScanStruct", sqlStr, args...)
+
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		run.end(-1, err)
+		return err
+	}
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			run.end(-1, err)
+			return err
+		}
+
+		run.end(0, nil)
+		return ErrNoRows
+	}
+
+	if err := scanRowInto(rows, v.Elem(), columns, false); err != nil {
+		run.end(-1, err)
+		return err
+	}
+
+	run.end(1, nil)
+
+	return nil
+}
+
+// ScanStructs executes a SQL query in the given context and appends the rows
+// of the results to the slice pointed to by dest, which is either a *[]T
+// or a *[]*T, where T is a struct. The columns are mapped to the fields
+// the same way as in SelectToStructs.
+//
+// It is the non-generic alternative of SelectToStructs, for code which
+// prefers the pointer destination idiom. If the query returns no rows,
+// the slice is not changed.
+//
+// Example usage:
+//
+//	var users []User
+//	err := ScanStructs(ctx, &users, "SELECT * FROM users WHERE active = ?", 1)
+//
+// Parameters:
+// - ctx (QueryableContext): The context to use for the query execution.
+// - dest (any): A non-nil pointer to the slice to append the rows to.
+// - sqlStr (string): The SQL query to execute.
+// - args (any): Optional arguments to pass to the query.
+//
+// Returns:
+// - error: An error if dest is not a pointer to a slice of structs
+// or struct pointers, or the query failed.
+func ScanStructs(ctx QueryableContext, dest any, sqlStr string, args ...any) error {
+	if ctx.queryable == nil {
+		return ErrNoQueryable
+	}
+
+	v := reflect.ValueOf(dest)
+
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("dest must be a non-nil pointer to a slice, got %T", dest)
+	}
+
+	slice := v.Elem()
+	elemType := slice.Type().Elem()
+	isPointer := elemType.Kind() == reflect.Pointer
+
+	structType := elemType
+	if isPointer {
+		structType = elemType.Elem()
+	}
+
+	if !isMappableStruct(structType) {
+		return fmt.Errorf("dest must be a pointer to a slice of structs or struct pointers, got %T", dest)
+	}
+
+	rows, run, err := ctx.queryContext("ScanStructs", sqlStr, args...)
+
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		run.end(-1, err)
+		return err
+	}
+
+	// The rows are appended to a copy, so dest is not changed on error
+	result := slice
+	count := 0
+
+	for rows.Next() {
+		item := reflect.New(structType)
+
+		if err := scanRowInto(rows, item.Elem(), columns, false); err != nil {
+			run.end(-1, err)
+			return err
+		}
+
+		if isPointer {
+			result = reflect.Append(result, item)
+		} else {
+			result = reflect.Append(result, item.Elem())
+		}
+
+		count++
+	}
+
+	if err := rows.Err(); err != nil {
+		run.end(-1, err)
+		return err
+	}
+
+	slice.Set(result)
+
+	run.end(int64(count), nil)
+
+	return nil
+}
@@ -0,0 +1,125 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	database "github.com/dracory/database"
+)
+
+func TestScanStruct(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	var user testUser
+
+	if err := database.ScanStruct(database.Context(context.Background(), nil), &user, "SELECT * FROM users"); !errors.Is(err, database.ErrNoQueryable) {
+		t.Errorf("Expected ErrNoQueryable, got %v", err)
+	}
+
+	if err := createUserTableAndInserTesttData(db); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := database.Context(context.Background(), db)
+
+	// The fields without a matching column are left as they are
+	user.Ignored = "kept"
+
+	if err := database.ScanStruct(ctx, &user, "SELECT id, name, email FROM users WHERE id = ?", 2); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if user.ID != 2 || user.FullName != "Bob" || user.Email == nil || *user.Email != "bob@example.com" || user.Ignored != "kept" {
+		t.Errorf("Unexpected user: %+v", user)
+	}
+
+	if err := database.ScanStruct(ctx, &user, "SELECT * FROM users WHERE id = ?", 42); !errors.Is(err, database.ErrNoRows) {
+		t.Errorf("Expected ErrNoRows, got %v", err)
+	}
+
+	if user.ID != 2 {
+		t.Errorf("Expected the user to be unchanged, got %+v", user)
+	}
+
+	for _, dest := range []any{nil, user, (*testUser)(nil), new(string)} {
+		if err := database.ScanStruct(ctx, dest, "SELECT * FROM users"); err == nil {
+			t.Errorf("Expected error for dest %T", dest)
+		}
+	}
+
+	if err := database.ScanStruct(ctx, &user, "INVALID SQL"); err == nil {
+		t.Error("Expected error for invalid SQL")
+	}
+}
+
+func TestScanStructs(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	var users []testUser
+
+	if err := database.ScanStructs(database.Context(context.Background(), nil), &users, "SELECT * FROM users"); !errors.Is(err, database.ErrNoQueryable) {
+		t.Errorf("Expected ErrNoQueryable, got %v", err)
+	}
+
+	if err := createUserTableAndInserTesttData(db); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := database.Context(context.Background(), db)
+
+	if err := database.ScanStructs(ctx, &users, "SELECT * FROM users ORDER BY id ASC"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(users) != 3 || users[0].FullName != "Alice" || users[2].ID != 3 {
+		t.Errorf("Unexpected users: %+v", users)
+	}
+
+	// The rows are appended
+	if err := database.ScanStructs(ctx, &users, "SELECT * FROM users WHERE id = ?", 2); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(users) != 4 || users[3].FullName != "Bob" {
+		t.Errorf("Unexpected users: %+v", users)
+	}
+
+	var userPtrs []*testUser
+
+	if err := database.ScanStructs(ctx, &userPtrs, "SELECT * FROM users ORDER BY id DESC"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(userPtrs) != 3 || userPtrs[0].FullName != "Charlie" || userPtrs[0].Email == nil || *userPtrs[0].Email != "charlie@example.com" {
+		t.Errorf("Unexpected users: %+v", userPtrs)
+	}
+
+	var none []testUser
+
+	if err := database.ScanStructs(ctx, &none, "SELECT * FROM users WHERE id = ?", 42); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if none != nil {
+		t.Errorf("Expected the slice to be unchanged, got %+v", none)
+	}
+
+	for _, dest := range []any{nil, users, &testUser{}, &[]string{}, (*[]testUser)(nil)} {
+		if err := database.ScanStructs(ctx, dest, "SELECT * FROM users"); err == nil {
+			t.Errorf("Expected error for dest %T", dest)
+		}
+	}
+
+	if err := database.ScanStructs(ctx, &users, "INVALID SQL"); err == nil {
+		t.Error("Expected error for invalid SQL")
+	}
+}
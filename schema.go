@@ -0,0 +1,55 @@
+package database
+
+import "errors"
+
+// Tables returns the names of the user tables of the current database,
+// sorted by name. System tables and views are not included.
+//
+// The tables are listed as follows, based on DatabaseType:
+//   - SQLite: from sqlite_master, excluding the internal sqlite_ tables
+//   - MySQL: from information_schema.tables, for the current database
+//   - Postgres: from pg_catalog.pg_tables, excluding the system schemas.
+//     Tables outside the current schema are qualified, i.e. audit.logs
+//   - MSSQL: from INFORMATION_SCHEMA.TABLES
+//
+// Example usage:
+//
+//	tables, err := Tables(ctx)
+//
+// Parameters:
+// - ctx (QueryableContext): The context to use for the query execution.
+//
+// Returns:
+// - []string: The names of the tables.
+// - error: An error if the database type is not supported, or the query failed.
+func Tables(ctx QueryableContext) ([]string, error) {
+	if ctx.queryable == nil {
+		return []string{}, ErrNoQueryable
+	}
+
+	var sqlStr string
+
+	switch dbType := ctx.databaseType(); dbType {
+	case DATABASE_TYPE_SQLITE:
+		sqlStr = "SELECT name FROM sqlite_master" +
+			" WHERE type = 'table' AND name NOT LIKE 'sqlite_%'" +
+			" ORDER BY name"
+	case DATABASE_TYPE_MYSQL:
+		sqlStr = "SELECT table_name FROM information_schema.tables" +
+			" WHERE table_schema = DATABASE() AND table_type = 'BASE TABLE'" +
+			" ORDER BY table_name"
+	case DATABASE_TYPE_POSTGRES:
+		sqlStr = "SELECT CASE WHEN schemaname = current_schema() THEN tablename ELSE schemaname || '.' || tablename END AS name" +
+			" FROM pg_catalog.pg_tables" +
+			" WHERE schemaname NOT IN ('pg_catalog', 'information_schema') AND schemaname NOT LIKE 'pg_toast%'" +
+			" ORDER BY name"
+	case DATABASE_TYPE_MSSQL:
+		sqlStr = "SELECT TABLE_NAME FROM INFORMATION_SCHEMA.TABLES" +
+			" WHERE TABLE_TYPE = 'BASE TABLE'" +
+			" ORDER BY TABLE_NAME"
+	default:
+		return []string{}, errors.New("tables are not supported for database type: " + dbType)
+	}
+
+	return selectScalars[string](ctx, "Tables", sqlStr)
+}
@@ -0,0 +1,274 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/spf13/cast"
+)
+
+// ColumnType is the normalized type of a column, independent of the dialect
+type ColumnType string
+
+const COLUMN_TYPE_STRING ColumnType = "string"
+const COLUMN_TYPE_INTEGER ColumnType = "integer"
+const COLUMN_TYPE_FLOAT ColumnType = "float"
+const COLUMN_TYPE_DECIMAL ColumnType = "decimal"
+const COLUMN_TYPE_BOOLEAN ColumnType = "boolean"
+const COLUMN_TYPE_DATE ColumnType = "date"
+const COLUMN_TYPE_DATETIME ColumnType = "datetime"
+const COLUMN_TYPE_TIME ColumnType = "time"
+const COLUMN_TYPE_BINARY ColumnType = "binary"
+const COLUMN_TYPE_JSON ColumnType = "json"
+const COLUMN_TYPE_UUID ColumnType = "uuid"
+const COLUMN_TYPE_OTHER ColumnType = "other"
+
+// ColumnInfo describes a column of a table, as returned by Columns
+type ColumnInfo struct {
+	// Name is the name of the column
+	Name string
+
+	// DataType is the type of the column as reported by the database, i.e. varchar
+	DataType string
+
+	// Type is the normalized type of the column, i.e. COLUMN_TYPE_STRING
+	Type ColumnType
+
+	// Nullable is true if the column accepts NULL values
+	Nullable bool
+
+	// Default is the default value expression of the column, nil if none
+	Default *string
+
+	// PrimaryKey is true if the column is part of the primary key
+	PrimaryKey bool
+}
+
+// Columns returns the columns of the table, in the order they are defined.
+//
+// The columns are read as follows, based on DatabaseType:
+//   - SQLite: from pragma_table_info
+//   - MySQL: from information_schema.columns, for the current database
+//   - Postgres: from information_schema.columns, for the current schema,
+//     or the given one if the table is qualified, i.e. audit.logs
+//   - MSSQL: from INFORMATION_SCHEMA.COLUMNS
+//
+// Example usage:
+//
+//	columns, err := Columns(ctx, "users")
+//	for _, column := range columns {
+//		fmt.Println(column.Name, column.Type, column.Nullable)
+//	}
+//
+// Parameters:
+// - ctx (QueryableContext): The context to use for the query execution.
+// - table (string): The name of the table.
+//
+// Returns:
+// - []ColumnInfo: The columns of the table, empty if the table does not exist.
+// - error: An error if the database type is not supported, or the query failed.
+func Columns(ctx QueryableContext, table string) ([]ColumnInfo, error) {
+	if ctx.queryable == nil {
+		return []ColumnInfo{}, ErrNoQueryable
+	}
+
+	if table == "" {
+		return []ColumnInfo{}, errors.New("table name is required")
+	}
+
+	dbType := ctx.databaseType()
+
+	sqlStr, args, err := columnsSQL(dbType, table)
+	if err != nil {
+		return []ColumnInfo{}, err
+	}
+
+	rows, run, err := ctx.queryContext("Columns", Rebind(dbType, sqlStr), args...)
+
+	if err != nil {
+		return []ColumnInfo{}, err
+	}
+	defer rows.Close()
+
+	columns, err := scanColumnInfos(rows)
+
+	if err != nil {
+		run.end(-1, err)
+		return []ColumnInfo{}, err
+	}
+
+	run.end(int64(len(columns)), nil)
+
+	return columns, nil
+}
+
+// columnsSQL returns the query listing the columns of the table for the
+// database type. The query returns the name, data type, nullable flag,
+// default value and primary key flag of each column.
+func columnsSQL(dbType string, table string) (string, []any, error) {
+	schema, name, qualified := strings.Cut(table, ".")
+
+	if !qualified {
+		schema, name = "", table
+	}
+
+	switch dbType {
+	case DATABASE_TYPE_SQLITE:
+		return `SELECT name, type, "notnull" = 0, dflt_value, pk > 0 FROM pragma_table_info(?) ORDER BY cid`, []any{table}, nil
+	case DATABASE_TYPE_MYSQL:
+		return "SELECT column_name, data_type, is_nullable = 'YES', column_default, column_key = 'PRI'" +
+			" FROM information_schema.columns" +
+			" WHERE table_schema = DATABASE() AND table_name = ?" +
+			" ORDER BY ordinal_position", []any{table}, nil
+	case DATABASE_TYPE_POSTGRES, DATABASE_TYPE_MSSQL:
+		schemaCondition := "c.table_schema = current_schema()"
+		args := []any{name}
+
+		if dbType == DATABASE_TYPE_MSSQL {
+			schemaCondition = "c.table_schema = SCHEMA_NAME()"
+		}
+
+		if qualified {
+			schemaCondition = "c.table_schema = ?"
+			args = []any{schema, name}
+		}
+
+		primaryKey := "EXISTS (SELECT 1 FROM information_schema.table_constraints tc" +
+			" JOIN information_schema.key_column_usage k ON k.constraint_name = tc.constraint_name" +
+			" AND k.table_schema = tc.table_schema AND k.table_name = tc.table_name" +
+			" WHERE tc.constraint_type = 'PRIMARY KEY' AND tc.table_schema = c.table_schema" +
+			" AND tc.table_name = c.table_name AND k.column_name = c.column_name)"
+
+		nullable := "c.is_nullable = 'YES'"
+
+		// MSSQL has no boolean expressions in the select list
+		if dbType == DATABASE_TYPE_MSSQL {
+			primaryKey = "CASE WHEN " + primaryKey + " THEN 1 ELSE 0 END"
+			nullable = "CASE WHEN " + nullable + " THEN 1 ELSE 0 END"
+		}
+
+		return "SELECT c.column_name, c.data_type, " + nullable + ", c.column_default, " + primaryKey +
+			" FROM information_schema.columns c" +
+			" WHERE " + schemaCondition + " AND c.table_name = ?" +
+			" ORDER BY c.ordinal_position", args, nil
+	}
+
+	return "", nil, errors.New("columns are not supported for database type: " + dbType)
+}
+
+// scanColumnInfos scans the rows returned by the columns query
+func scanColumnInfos(rows *sql.Rows) ([]ColumnInfo, error) {
+	columns := []ColumnInfo{}
+
+	for rows.Next() {
+		var column ColumnInfo
+		var nullable, primaryKey any
+		var defaultValue sql.NullString
+
+		if err := rows.Scan(&column.Name, &column.DataType, &nullable, &defaultValue, &primaryKey); err != nil {
+			return nil, err
+		}
+
+		var err error
+
+		if column.Nullable, err = scanBool(nullable); err != nil {
+			return nil, err
+		}
+
+		if column.PrimaryKey, err = scanBool(primaryKey); err != nil {
+			return nil, err
+		}
+
+		if defaultValue.Valid {
+			column.Default = &defaultValue.String
+		}
+
+		column.Type = NormalizeColumnType(column.DataType)
+		columns = append(columns, column)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return columns, nil
+}
+
+// scanBool converts a boolean or integer flag scanned from any driver to bool
+func scanBool(v any) (bool, error) {
+	// Some drivers return the integer result as []byte
+	if b, ok := v.([]byte); ok {
+		v = string(b)
+	}
+
+	return cast.ToBoolE(v)
+}
+
+// NormalizeColumnType maps the data type of a column, as reported by any of
+// the supported databases, to a ColumnType.
+//
+// The length and precision (i.e. varchar(255)) are ignored. Unknown types
+// are matched following the SQLite type affinity rules (i.e. any type
+// containing "INT" is an integer), or mapped to COLUMN_TYPE_OTHER.
+//
+// Example usage:
+//
+//	NormalizeColumnType("character varying") // COLUMN_TYPE_STRING
+//	NormalizeColumnType("BIGINT")            // COLUMN_TYPE_INTEGER
+//
+// Parameters:
+// - dataType (string): The data type of the column.
+//
+// Returns:
+// - ColumnType: The normalized type.
+func NormalizeColumnType(dataType string) ColumnType {
+	base := strings.ToLower(strings.TrimSpace(dataType))
+
+	if i := strings.Index(base, "("); i >= 0 {
+		base = strings.TrimSpace(base[:i])
+	}
+
+	base = strings.TrimSuffix(base, " unsigned")
+
+	switch base {
+	case "bool", "boolean", "bit":
+		return COLUMN_TYPE_BOOLEAN
+	case "uuid", "uniqueidentifier":
+		return COLUMN_TYPE_UUID
+	case "json", "jsonb":
+		return COLUMN_TYPE_JSON
+	case "date":
+		return COLUMN_TYPE_DATE
+	case "time", "time without time zone", "time with time zone":
+		return COLUMN_TYPE_TIME
+	case "datetime", "datetime2", "smalldatetime", "datetimeoffset", "timestamp",
+		"timestamp without time zone", "timestamp with time zone", "timestamptz":
+		return COLUMN_TYPE_DATETIME
+	case "decimal", "numeric", "money", "smallmoney":
+		return COLUMN_TYPE_DECIMAL
+	case "float", "double", "double precision", "real":
+		return COLUMN_TYPE_FLOAT
+	case "serial", "bigserial", "smallserial":
+		return COLUMN_TYPE_INTEGER
+	case "bytea", "binary", "varbinary", "image":
+		return COLUMN_TYPE_BINARY
+	case "enum", "set", "citext", "string":
+		return COLUMN_TYPE_STRING
+	case "interval", "point":
+		return COLUMN_TYPE_OTHER
+	}
+
+	switch {
+	case strings.Contains(base, "int"):
+		return COLUMN_TYPE_INTEGER
+	case strings.Contains(base, "char"), strings.Contains(base, "clob"), strings.Contains(base, "text"):
+		return COLUMN_TYPE_STRING
+	case strings.Contains(base, "blob"):
+		return COLUMN_TYPE_BINARY
+	case strings.Contains(base, "real"), strings.Contains(base, "floa"), strings.Contains(base, "doub"):
+		return COLUMN_TYPE_FLOAT
+	}
+
+	return COLUMN_TYPE_OTHER
+}
@@ -0,0 +1,119 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	database "github.com/dracory/database"
+)
+
+func TestColumns(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	// Test nil querier error
+	_, err = database.Columns(database.Context(context.Background(), nil), "users")
+	if err == nil || err.Error() != "querier (db/tx/conn) is nil" {
+		t.Errorf("Expected nil querier error, got %v", err)
+	}
+
+	ctx := database.Context(context.Background(), db)
+
+	_, err = database.Execute(ctx, `CREATE TABLE products (
+		id INTEGER PRIMARY KEY,
+		name VARCHAR(255) NOT NULL,
+		price DECIMAL(10, 2) DEFAULT 0,
+		active BOOLEAN NOT NULL DEFAULT 1,
+		created_at DATETIME,
+		image BLOB
+	)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	columns, err := database.Columns(ctx, "products")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []struct {
+		name       string
+		columnType database.ColumnType
+		nullable   bool
+		primaryKey bool
+		defaultVal string
+	}{
+		{"id", database.COLUMN_TYPE_INTEGER, true, true, ""},
+		{"name", database.COLUMN_TYPE_STRING, false, false, ""},
+		{"price", database.COLUMN_TYPE_DECIMAL, true, false, "0"},
+		{"active", database.COLUMN_TYPE_BOOLEAN, false, false, "1"},
+		{"created_at", database.COLUMN_TYPE_DATETIME, true, false, ""},
+		{"image", database.COLUMN_TYPE_BINARY, true, false, ""},
+	}
+
+	if len(columns) != len(expected) {
+		t.Fatalf("Expected %d columns, got %d", len(expected), len(columns))
+	}
+
+	for i, e := range expected {
+		column := columns[i]
+
+		if column.Name != e.name || column.Type != e.columnType || column.Nullable != e.nullable || column.PrimaryKey != e.primaryKey {
+			t.Errorf("Unexpected column %d: %+v", i, column)
+		}
+
+		if e.defaultVal == "" && column.Default != nil {
+			t.Errorf("Expected no default for %s, got %q", column.Name, *column.Default)
+		}
+
+		if e.defaultVal != "" && (column.Default == nil || *column.Default != e.defaultVal) {
+			t.Errorf("Expected default %q for %s, got %v", e.defaultVal, column.Name, column.Default)
+		}
+	}
+
+	// Test missing table
+	columns, err = database.Columns(ctx, "missing")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(columns) != 0 {
+		t.Errorf("Expected no columns for missing table, got %v", columns)
+	}
+}
+
+func TestNormalizeColumnType(t *testing.T) {
+	tests := map[string]database.ColumnType{
+		"VARCHAR(255)":                database.COLUMN_TYPE_STRING,
+		"character varying":           database.COLUMN_TYPE_STRING,
+		"nvarchar":                    database.COLUMN_TYPE_STRING,
+		"longtext":                    database.COLUMN_TYPE_STRING,
+		"INTEGER":                     database.COLUMN_TYPE_INTEGER,
+		"bigint unsigned":             database.COLUMN_TYPE_INTEGER,
+		"tinyint(1)":                  database.COLUMN_TYPE_INTEGER,
+		"bigserial":                   database.COLUMN_TYPE_INTEGER,
+		"double precision":            database.COLUMN_TYPE_FLOAT,
+		"real":                        database.COLUMN_TYPE_FLOAT,
+		"numeric(10,2)":               database.COLUMN_TYPE_DECIMAL,
+		"boolean":                     database.COLUMN_TYPE_BOOLEAN,
+		"date":                        database.COLUMN_TYPE_DATE,
+		"timestamp without time zone": database.COLUMN_TYPE_DATETIME,
+		"datetime2":                   database.COLUMN_TYPE_DATETIME,
+		"time":                        database.COLUMN_TYPE_TIME,
+		"bytea":                       database.COLUMN_TYPE_BINARY,
+		"mediumblob":                  database.COLUMN_TYPE_BINARY,
+		"jsonb":                       database.COLUMN_TYPE_JSON,
+		"uuid":                        database.COLUMN_TYPE_UUID,
+		"interval":                    database.COLUMN_TYPE_OTHER,
+		"point":                       database.COLUMN_TYPE_OTHER,
+		"geometry":                    database.COLUMN_TYPE_OTHER,
+	}
+
+	for dataType, expected := range tests {
+		if got := database.NormalizeColumnType(dataType); got != expected {
+			t.Errorf("NormalizeColumnType(%q): expected %q, got %q", dataType, expected, got)
+		}
+	}
+}
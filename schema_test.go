@@ -0,0 +1,57 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	database "github.com/dracory/database"
+)
+
+func TestTables(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	// Test nil querier error
+	_, err = database.Tables(database.Context(context.Background(), nil))
+	if err == nil || err.Error() != "querier (db/tx/conn) is nil" {
+		t.Errorf("Expected nil querier error, got %v", err)
+	}
+
+	ctx := database.Context(context.Background(), db)
+
+	// Test empty database
+	tables, err := database.Tables(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(tables) != 0 {
+		t.Errorf("Expected no tables, got %v", tables)
+	}
+
+	err = createUserTableAndInserTesttData(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// AUTOINCREMENT creates the internal sqlite_sequence table, which is excluded
+	_, err = database.Execute(ctx, "CREATE TABLE posts (id INTEGER PRIMARY KEY AUTOINCREMENT, title TEXT)")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = database.Execute(ctx, "CREATE VIEW user_names AS SELECT name FROM users")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tables, err = database.Tables(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(tables) != 2 || tables[0] != "posts" || tables[1] != "users" {
+		t.Errorf("Expected [posts users], got %v", tables)
+	}
+}
@@ -1,7 +1,9 @@
 package database
 
 import (
-	"errors"
+	"database/sql"
+	"strings"
+	"time"
 
 	"github.com/spf13/cast"
 )
@@ -15,6 +17,10 @@ import (
 //
 // If the query returns no rows, the function returns an empty slice.
 //
+// Values of character and text columns (CHAR, VARCHAR, TEXT, etc.) are
+// returned as string, even if the driver returns them as []byte.
+// Values of binary columns (BLOB, BINARY, BYTEA, etc.) are left as []byte.
+//
 // Example usage:
 //
 // listMap, err := SelectToMapAny(context.Background(), "SELECT * FROM users")
@@ -28,56 +34,107 @@ import (
 // - []map[string]any: A slice of maps containing the query results.
 // - error: An error if the query failed.
 func SelectToMapAny(ctx QueryableContext, sqlStr string, args ...any) ([]map[string]any, error) {
+	return selectToMapAny(ctx, "SelectToMapAny", SelectToMapAnyOptions{}, sqlStr, args...)
+}
+
+// SelectToMapAnyOptions configures how the values are converted
+// by SelectToMapAnyWithOptions. The zero value keeps the values
+// the same as SelectToMapAny.
+type SelectToMapAnyOptions struct {
+	// DecodeBytesAsString converts all the []byte values to string,
+	// including the values of binary columns (BLOB, BINARY, BYTEA, etc.),
+	// i.e. for drivers which return computed or untyped columns as []byte
+	// (MySQL without column metadata, SQLite expressions).
+	DecodeBytesAsString bool
+
+	// NormalizeTime converts the values of the date and time columns
+	// (DATE, DATETIME, TIMESTAMP, etc.) to time.Time with AsTime, using
+	// the column types of the query, so they are the same regardless of
+	// whether the driver returns them as time.Time, string or []byte
+	// (i.e. MySQL without parseTime=true, SQLite without a declared type).
+	// Values which cannot be parsed are left as they are.
+	NormalizeTime bool
+
+	// TimeAsRFC3339 converts the time.Time values to strings in the
+	// RFC 3339 format with nanoseconds, i.e. "2024-01-02T15:04:05.123Z",
+	// so the rows can be serialized consistently. Note that MySQL returns
+	// the DATETIME columns as time.Time only with parseTime=true (see ParseTime),
+	// unless combined with NormalizeTime.
+	TimeAsRFC3339 bool
+}
+
+// SelectToMapAnyWithOptions works like SelectToMapAny, but allows
+// opting into friendlier value types, i.e. strings instead of bytes
+// or time.Time values.
+//
+// Example usage:
+//
+// // binary columns as strings, timestamps as "2006-01-02T15:04:05Z07:00"
+// listMap, err := SelectToMapAnyWithOptions(ctx, SelectToMapAnyOptions{DecodeBytesAsString: true, TimeAsRFC3339: true}, "SELECT * FROM users")
+//
+// Parameters:
+// - ctx (context.Context): The context to use for the query execution.
+// - options (SelectToMapAnyOptions): The value conversion options.
+// - sqlStr (string): The SQL query to execute.
+// - args (any): Optional arguments to pass to the query.
+//
+// Returns:
+// - []map[string]any: A slice of maps containing the query results.
+// - error: An error if the query failed.
+func SelectToMapAnyWithOptions(ctx QueryableContext, options SelectToMapAnyOptions, sqlStr string, args ...any) ([]map[string]any, error) {
+	return selectToMapAny(ctx, "SelectToMapAnyWithOptions", options, sqlStr, args...)
+}
+
+// selectToMapAny executes the query and scans the rows into maps
+func selectToMapAny(ctx QueryableContext, operation string, options SelectToMapAnyOptions, sqlStr string, args ...any) ([]map[string]any, error) {
 	if ctx.queryable == nil {
-		return []map[string]any{}, errors.New("querier (db/tx/conn) is nil")
+		return []map[string]any{}, ErrNoQueryable
 	}
 
-	listMap := []map[string]any{}
-
-	rows, err := ctx.queryable.QueryContext(ctx, sqlStr, args...)
+	rows, run, err := ctx.queryContext(operation, sqlStr, args...)
 
 	if err != nil {
 		return []map[string]any{}, err
 	}
 	defer rows.Close()
 
-	// Get column names
-	columns, err := rows.Columns()
+	listMap, err := scanRowsToMaps(rows, options, ctx.maxRows)
+
 	if err != nil {
+		run.end(-1, err)
 		return []map[string]any{}, err
 	}
 
-	for rows.Next() {
-		// Create a slice of interface{} to hold the values
-		values := make([]interface{}, len(columns))
-		// Create a slice of pointers to interface{} for scanning
-		valuePtrs := make([]interface{}, len(columns))
-		for i := range values {
-			valuePtrs[i] = &values[i]
-		}
+	run.end(int64(len(listMap)), nil)
+
+	return listMap, nil
+}
+
+// scanRowsToMaps scans all the rows into a slice of maps,
+// returning an error after more than maxRows rows, unless it is zero
+func scanRowsToMaps(rows *sql.Rows, options SelectToMapAnyOptions, maxRows int) ([]map[string]any, error) {
+	listMap := []map[string]any{}
+
+	scanner, err := newRowMapScanner(rows, options)
+	if err != nil {
+		return nil, err
+	}
 
-		// Scan the row into the slice of pointers
-		if err := rows.Scan(valuePtrs...); err != nil {
-			return []map[string]any{}, err
+	for rows.Next() {
+		if err := checkMaxRows(len(listMap), maxRows); err != nil {
+			return nil, err
 		}
 
-		// Create a map for this row
-		row := make(map[string]any)
-		for i, col := range columns {
-			val := values[i]
-			// Handle nil values
-			if val == nil {
-				row[col] = nil
-			} else {
-				row[col] = val
-			}
+		row, err := scanner.scan(rows)
+		if err != nil {
+			return nil, err
 		}
 
 		listMap = append(listMap, row)
 	}
 
 	if err := rows.Err(); err != nil {
-		return []map[string]any{}, err
+		return nil, err
 	}
 
 	return listMap, nil
@@ -92,6 +149,16 @@ func SelectToMapAny(ctx QueryableContext, sqlStr string, args ...any) ([]map[str
 //
 // If the query returns no rows, the function returns an empty slice.
 //
+// Values are converted to strings as follows:
+//   - NULL: an empty string (see SelectToMapStringWithOptions and SelectToMapStringPtr
+//     to distinguish NULL from an empty string)
+//   - text columns: the text as is
+//   - binary columns: the raw bytes as a string
+//   - integer and float columns: the decimal representation, i.e. "42", "1.5"
+//   - boolean columns: "true" or "false"
+//   - date and time columns: the driver value, if returned as time.Time it is
+//     formatted as "2006-01-02 15:04:05 -0700 MST"
+//
 // Example usage:
 //
 // listMap, err := SelectToMapString(context.Background(), "SELECT * FROM users")
@@ -105,11 +172,50 @@ func SelectToMapAny(ctx QueryableContext, sqlStr string, args ...any) ([]map[str
 // - []map[string]string: A slice of maps containing the query results.
 // - error: An error if the query failed.
 func SelectToMapString(ctx QueryableContext, sqlStr string, args ...any) ([]map[string]string, error) {
+	return SelectToMapStringWithOptions(ctx, SelectToMapStringOptions{}, sqlStr, args...)
+}
+
+// SelectToMapStringOptions configures how NULL values are handled
+// by SelectToMapStringWithOptions.
+type SelectToMapStringOptions struct {
+	// NullValue is the string NULL values are converted to.
+	// Defaults to an empty string.
+	NullValue string
+
+	// OmitNulls omits the columns with NULL values from the row maps,
+	// instead of converting them to NullValue.
+	OmitNulls bool
+}
+
+// SelectToMapStringWithOptions works like SelectToMapString, but allows
+// configuring how NULL values are handled, so they can be converted to
+// a sentinel value or omitted from the row maps entirely.
+//
+// Non-NULL values are converted to strings the same way as in SelectToMapString.
+//
+// Example usage:
+//
+// // NULL values are converted to "<NULL>"
+// listMap, err := SelectToMapStringWithOptions(ctx, SelectToMapStringOptions{NullValue: "<NULL>"}, "SELECT * FROM users")
+//
+// // NULL values are omitted, use the comma ok idiom to check for them
+// listMap, err := SelectToMapStringWithOptions(ctx, SelectToMapStringOptions{OmitNulls: true}, "SELECT * FROM users")
+//
+// Parameters:
+// - ctx (context.Context): The context to use for the query execution.
+// - options (SelectToMapStringOptions): The NULL handling options.
+// - sqlStr (string): The SQL query to execute.
+// - args (any): Optional arguments to pass to the query.
+//
+// Returns:
+// - []map[string]string: A slice of maps containing the query results.
+// - error: An error if the query failed.
+func SelectToMapStringWithOptions(ctx QueryableContext, options SelectToMapStringOptions, sqlStr string, args ...any) ([]map[string]string, error) {
 	if ctx.queryable == nil {
-		return []map[string]string{}, errors.New("querier (db/tx/conn) is nil")
+		return []map[string]string{}, ErrNoQueryable
 	}
 
-	listMapAny, err := SelectToMapAny(ctx, sqlStr, args...)
+	listMapAny, err := selectToMapAny(ctx, "SelectToMapString", SelectToMapAnyOptions{}, sqlStr, args...)
 
 	if err != nil {
 		return []map[string]string{}, err
@@ -119,9 +225,186 @@ func SelectToMapString(ctx QueryableContext, sqlStr string, args ...any) ([]map[
 
 	// Iterate over the list of maps and convert each map from map[string]any to map[string]string.
 	for i := range listMapAny {
-		mapString := cast.ToStringMapString(listMapAny[i])
+		mapString := make(map[string]string, len(listMapAny[i]))
+
+		for key, value := range listMapAny[i] {
+			if value == nil {
+				if !options.OmitNulls {
+					mapString[key] = options.NullValue
+				}
+				continue
+			}
+
+			mapString[key] = cast.ToString(value)
+		}
+
 		listMapString = append(listMapString, mapString)
 	}
 
 	return listMapString, nil
 }
+
+// SelectToMapStringPtr executes a SQL query in the given context and returns a slice
+// of maps, where each map represents a row of the query results. The values of the
+// columns are returned as string pointers, which are nil for NULL values.
+//
+// Non-NULL values are converted to strings the same way as in SelectToMapString.
+//
+// Example usage:
+//
+// listMap, err := SelectToMapStringPtr(context.Background(), "SELECT * FROM users")
+//
+//	if listMap[0]["email"] == nil {
+//		// email is NULL
+//	}
+//
+// Parameters:
+// - ctx (context.Context): The context to use for the query execution.
+// - sqlStr (string): The SQL query to execute.
+// - args (any): Optional arguments to pass to the query.
+//
+// Returns:
+// - []map[string]*string: A slice of maps containing the query results.
+// - error: An error if the query failed.
+func SelectToMapStringPtr(ctx QueryableContext, sqlStr string, args ...any) ([]map[string]*string, error) {
+	if ctx.queryable == nil {
+		return []map[string]*string{}, ErrNoQueryable
+	}
+
+	listMapAny, err := selectToMapAny(ctx, "SelectToMapStringPtr", SelectToMapAnyOptions{}, sqlStr, args...)
+
+	if err != nil {
+		return []map[string]*string{}, err
+	}
+
+	listMapStringPtr := []map[string]*string{}
+
+	for i := range listMapAny {
+		mapStringPtr := make(map[string]*string, len(listMapAny[i]))
+
+		for key, value := range listMapAny[i] {
+			if value == nil {
+				mapStringPtr[key] = nil
+				continue
+			}
+
+			str := cast.ToString(value)
+			mapStringPtr[key] = &str
+		}
+
+		listMapStringPtr = append(listMapStringPtr, mapStringPtr)
+	}
+
+	return listMapStringPtr, nil
+}
+
+// rowMapScanner scans the rows of a result set into maps,
+// keyed by the column names.
+type rowMapScanner struct {
+	columns      []string
+	isTextColumn []bool
+	isTimeColumn []bool
+	options      SelectToMapAnyOptions
+}
+
+func newRowMapScanner(rows *sql.Rows, options SelectToMapAnyOptions) (*rowMapScanner, error) {
+	// Get column names
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get column types, used to convert text columns returned as []byte
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, err
+	}
+
+	isTextColumn := make([]bool, len(columnTypes))
+	isTimeColumn := make([]bool, len(columnTypes))
+	for i, columnType := range columnTypes {
+		isTextColumn[i] = isTextColumnType(columnType.DatabaseTypeName())
+
+		switch NormalizeColumnType(columnType.DatabaseTypeName()) {
+		case COLUMN_TYPE_DATE, COLUMN_TYPE_DATETIME:
+			isTimeColumn[i] = true
+		}
+	}
+
+	return &rowMapScanner{columns: columns, isTextColumn: isTextColumn, isTimeColumn: isTimeColumn, options: options}, nil
+}
+
+// scan scans the current row into a map
+func (s *rowMapScanner) scan(rows *sql.Rows) (map[string]any, error) {
+	values, err := s.scanValues(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a map for this row
+	row := make(map[string]any, len(s.columns))
+	for i, col := range s.columns {
+		row[col] = values[i]
+	}
+
+	return row, nil
+}
+
+// scanValues scans the current row into a slice, in the order of the columns
+func (s *rowMapScanner) scanValues(rows *sql.Rows) ([]any, error) {
+	// Create a slice of interface{} to hold the values
+	values := make([]interface{}, len(s.columns))
+	// Create a slice of pointers to interface{} for scanning
+	valuePtrs := make([]interface{}, len(s.columns))
+	for i := range values {
+		valuePtrs[i] = &values[i]
+	}
+
+	// Scan the row into the slice of pointers
+	if err := rows.Scan(valuePtrs...); err != nil {
+		return nil, err
+	}
+
+	for i, val := range values {
+		if s.options.NormalizeTime && s.isTimeColumn[i] {
+			if t, ok := AsTime(val); ok {
+				val = t
+			}
+		}
+
+		if b, ok := val.([]byte); ok && (s.isTextColumn[i] || s.options.DecodeBytesAsString) {
+			// Some drivers (i.e. MySQL) return text columns as []byte
+			values[i] = string(b)
+		} else if t, ok := val.(time.Time); ok && s.options.TimeAsRFC3339 {
+			values[i] = t.Format(time.RFC3339Nano)
+		} else {
+			values[i] = val
+		}
+	}
+
+	return values, nil
+}
+
+// isTextColumnType checks if the database type name of a column
+// is a character or text type, as opposed to a binary type.
+func isTextColumnType(typeName string) bool {
+	typeName = strings.ToUpper(typeName)
+
+	if strings.Contains(typeName, "BINARY") || strings.Contains(typeName, "BLOB") || typeName == "BYTEA" {
+		return false
+	}
+
+	if typeName == "ENUM" || typeName == "SET" {
+		return true
+	}
+
+	textTypes := []string{"CHAR", "TEXT", "CLOB", "JSON", "UUID", "XML"}
+
+	for _, textType := range textTypes {
+		if strings.Contains(typeName, textType) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,162 @@
+package database
+
+import (
+	"database/sql"
+	"encoding/base64"
+	"encoding/csv"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/spf13/cast"
+)
+
+// SelectToCSV executes a SQL query in the given context and writes the rows
+// to w as CSV, with a header record of the column names, followed by one
+// record per row. The rows are streamed to w, without loading them in memory.
+//
+// NULL values are written as empty fields, use SelectToCSVWithOptions
+// to write a custom token instead. Times are written as RFC3339,
+// and binary values as base64.
+//
+// Example usage:
+//
+//	file, err := os.Create("users.csv")
+//	// ...
+//	count, err := SelectToCSV(ctx, file, "SELECT id, name, email FROM users")
+//
+// Parameters:
+// - ctx (QueryableContext): The context to use for the query execution.
+// - w (io.Writer): The writer to write the CSV to.
+// - sqlStr (string): The SQL query to execute.
+// - args (any): Optional arguments to pass to the query.
+//
+// Returns:
+// - int: The number of data rows written, excluding the header.
+// - error: An error if the query failed, or writing failed.
+func SelectToCSV(ctx QueryableContext, w io.Writer, sqlStr string, args ...any) (int, error) {
+	return SelectToCSVWithOptions(ctx, w, SelectToCSVOptions{}, sqlStr, args...)
+}
+
+// SelectToCSVOptions configures SelectToCSVWithOptions.
+type SelectToCSVOptions struct {
+	// NullValue is the field NULL values are written as.
+	// Defaults to an empty field.
+	NullValue string
+}
+
+// SelectToCSVWithOptions works like SelectToCSV, but allows configuring
+// how NULL values are written, i.e. as \N for database imports.
+//
+// Example usage:
+//
+//	count, err := SelectToCSVWithOptions(ctx, file, SelectToCSVOptions{NullValue: `\N`}, "SELECT * FROM users")
+//
+// Parameters:
+// - ctx (QueryableContext): The context to use for the query execution.
+// - w (io.Writer): The writer to write the CSV to.
+// - options (SelectToCSVOptions): The NULL handling options.
+// - sqlStr (string): The SQL query to execute.
+// - args (any): Optional arguments to pass to the query.
+//
+// Returns:
+// - int: The number of data rows written, excluding the header.
+// - error: An error if the query failed, or writing failed.
+func SelectToCSVWithOptions(ctx QueryableContext, w io.Writer, options SelectToCSVOptions, sqlStr string, args ...any) (int, error) {
+	if ctx.queryable == nil {
+		return 0, ErrNoQueryable
+	}
+
+	rows, run, err := ctx.queryContext("SelectToCSV", sqlStr, args...)
+
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	count, err := writeRowsToCSV(rows, csv.NewWriter(w), options)
+
+	if err != nil {
+		run.end(int64(count), err)
+		return count, err
+	}
+
+	run.end(int64(count), nil)
+
+	return count, nil
+}
+
+// writeRowsToCSV writes the header and all the rows as CSV records
+func writeRowsToCSV(rows *sql.Rows, writer *csv.Writer, options SelectToCSVOptions) (int, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return 0, err
+	}
+
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return 0, err
+	}
+
+	isBinary := make([]bool, len(columns))
+	for i, columnType := range columnTypes {
+		isBinary[i] = NormalizeColumnType(columnType.DatabaseTypeName()) == COLUMN_TYPE_BINARY
+	}
+
+	if err := writer.Write(columns); err != nil {
+		return 0, err
+	}
+
+	values := make([]any, len(columns))
+	valuePtrs := make([]any, len(columns))
+	for i := range values {
+		valuePtrs[i] = &values[i]
+	}
+
+	record := make([]string, len(columns))
+	count := 0
+
+	for rows.Next() {
+		if err := rows.Scan(valuePtrs...); err != nil {
+			return count, err
+		}
+
+		for i, value := range values {
+			if record[i], err = csvField(value, isBinary[i], options.NullValue); err != nil {
+				return count, errors.New("column " + columns[i] + ": " + err.Error())
+			}
+		}
+
+		// The writer is buffered, and flushes to the underlying writer as it fills
+		if err := writer.Write(record); err != nil {
+			return count, err
+		}
+
+		count++
+	}
+
+	if err := rows.Err(); err != nil {
+		return count, err
+	}
+
+	writer.Flush()
+
+	return count, writer.Error()
+}
+
+// csvField converts a scanned value to a CSV field
+func csvField(value any, isBinary bool, nullValue string) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return nullValue, nil
+	case []byte:
+		if isBinary {
+			return base64.StdEncoding.EncodeToString(v), nil
+		}
+		return string(v), nil
+	case time.Time:
+		return v.Format(time.RFC3339Nano), nil
+	}
+
+	return cast.ToStringE(value)
+}
@@ -0,0 +1,77 @@
+package database_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	database "github.com/dracory/database"
+)
+
+func TestSelectToCSV(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	err = createUserTableAndInserTesttData(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+
+	// Test nil querier error
+	_, err = database.SelectToCSV(database.Context(context.Background(), nil), &buf, "SELECT * FROM users")
+	if err == nil || err.Error() != "querier (db/tx/conn) is nil" {
+		t.Errorf("Expected nil querier error, got %v", err)
+	}
+
+	ctx := database.Context(context.Background(), db)
+
+	_, err = database.Execute(ctx, "UPDATE users SET name = ?, email = NULL WHERE id = ?", "Bob, Jr.", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Test header, quoting and NULL as empty field
+	count, err := database.SelectToCSV(ctx, &buf, "SELECT id, name, email FROM users ORDER BY id")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if count != 3 {
+		t.Errorf("Expected 3 rows, got %d", count)
+	}
+
+	expected := "id,name,email\n" +
+		"1,Alice,alice@example.com\n" +
+		"2,\"Bob, Jr.\",\n" +
+		"3,Charlie,charlie@example.com\n"
+
+	if buf.String() != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, buf.String())
+	}
+
+	// Test custom NULL token
+	buf.Reset()
+
+	count, err = database.SelectToCSVWithOptions(ctx, &buf, database.SelectToCSVOptions{NullValue: `\N`}, "SELECT id, email FROM users WHERE id = ?", 2)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if count != 1 || buf.String() != "id,email\n2,\\N\n" {
+		t.Errorf("Unexpected CSV for custom NULL token: %d rows, %q", count, buf.String())
+	}
+
+	// Test no rows writes only the header
+	buf.Reset()
+
+	count, err = database.SelectToCSV(ctx, &buf, "SELECT id, name FROM users WHERE id > ?", 100)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if count != 0 || buf.String() != "id,name\n" {
+		t.Errorf("Expected only the header, got %d rows, %q", count, buf.String())
+	}
+}
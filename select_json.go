@@ -0,0 +1,209 @@
+package database
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SelectToJSON executes a SQL query in the given context and returns the rows
+// as a JSON array of objects, with the keys in the order of the columns.
+//
+// The values are typed using the column types of the query, so that they are
+// correct even with drivers returning all values as []byte (i.e. MySQL):
+//   - integer, float and decimal columns as numbers
+//   - boolean columns as true or false
+//   - JSON columns as embedded JSON
+//   - date and time columns as RFC3339 strings
+//   - binary columns as base64 strings
+//   - NULL as null, and any other column as a string
+//
+// If the query returns no rows, the result is an empty array.
+//
+// Example usage:
+//
+//	data, err := SelectToJSON(ctx, "SELECT id, name FROM users")
+//	// [{"id":1,"name":"Alice"},{"id":2,"name":"Bob"}]
+//
+// Parameters:
+// - ctx (QueryableContext): The context to use for the query execution.
+// - sqlStr (string): The SQL query to execute.
+// - args (any): Optional arguments to pass to the query.
+//
+// Returns:
+// - []byte: The JSON array.
+// - error: An error if the query failed, or a value could not be marshaled.
+func SelectToJSON(ctx QueryableContext, sqlStr string, args ...any) ([]byte, error) {
+	if ctx.queryable == nil {
+		return nil, ErrNoQueryable
+	}
+
+	rows, run, err := ctx.queryContext("SelectToJSON", sqlStr, args...)
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	data, count, err := scanRowsToJSON(rows)
+
+	if err != nil {
+		run.end(-1, err)
+		return nil, err
+	}
+
+	run.end(count, nil)
+
+	return data, nil
+}
+
+// scanRowsToJSON scans all the rows into a JSON array of objects
+func scanRowsToJSON(rows *sql.Rows) ([]byte, int64, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// The keys are marshaled once, as they are the same for all rows
+	keys := make([][]byte, len(columns))
+	types := make([]ColumnType, len(columns))
+
+	for i, column := range columns {
+		if keys[i], err = json.Marshal(column); err != nil {
+			return nil, 0, err
+		}
+
+		types[i] = NormalizeColumnType(columnTypes[i].DatabaseTypeName())
+	}
+
+	values := make([]any, len(columns))
+	valuePtrs := make([]any, len(columns))
+	for i := range values {
+		valuePtrs[i] = &values[i]
+	}
+
+	var buf bytes.Buffer
+	var count int64
+
+	buf.WriteByte('[')
+
+	for rows.Next() {
+		if err := rows.Scan(valuePtrs...); err != nil {
+			return nil, 0, err
+		}
+
+		if count > 0 {
+			buf.WriteByte(',')
+		}
+
+		buf.WriteByte('{')
+
+		for i := range columns {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+
+			value, err := json.Marshal(jsonValue(types[i], values[i]))
+			if err != nil {
+				return nil, 0, errors.New("column " + columns[i] + ": " + err.Error())
+			}
+
+			buf.Write(keys[i])
+			buf.WriteByte(':')
+			buf.Write(value)
+		}
+
+		buf.WriteByte('}')
+		count++
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	buf.WriteByte(']')
+
+	return buf.Bytes(), count, nil
+}
+
+// jsonValue converts a scanned value to the value to marshal,
+// based on the normalized type of its column
+func jsonValue(columnType ColumnType, value any) any {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case string:
+		return jsonTextValue(columnType, v)
+	case []byte:
+		if columnType == COLUMN_TYPE_BINARY {
+			return v
+		}
+		return jsonTextValue(columnType, string(v))
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	}
+
+	return value
+}
+
+// jsonTextValue converts a value returned as text by the driver
+func jsonTextValue(columnType ColumnType, text string) any {
+	switch columnType {
+	case COLUMN_TYPE_INTEGER, COLUMN_TYPE_FLOAT, COLUMN_TYPE_DECIMAL:
+		// NaN and Inf parse as floats, but are not valid JSON numbers
+		if _, err := strconv.ParseFloat(text, 64); err == nil && json.Valid([]byte(text)) {
+			return json.Number(text)
+		}
+	case COLUMN_TYPE_BOOLEAN:
+		if b, err := strconv.ParseBool(text); err == nil {
+			return b
+		}
+	case COLUMN_TYPE_JSON:
+		if json.Valid([]byte(text)) {
+			return json.RawMessage(text)
+		}
+	case COLUMN_TYPE_DATE, COLUMN_TYPE_DATETIME:
+		// A date without time is already a RFC3339 full-date
+		if columnType == COLUMN_TYPE_DATE && len(text) == len(time.DateOnly) {
+			return text
+		}
+
+		if t, ok := parseTimeText(text); ok {
+			return t.Format(time.RFC3339Nano)
+		}
+	}
+
+	return text
+}
+
+// timeTextLayouts are the layouts dates and times are returned as text in
+var timeTextLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999-07",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+	time.DateOnly,
+}
+
+// parseTimeText parses a date or time returned as text by the driver
+func parseTimeText(text string) (time.Time, bool) {
+	text = strings.TrimSpace(text)
+
+	for _, layout := range timeTextLayouts {
+		if t, err := time.Parse(layout, text); err == nil {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
@@ -0,0 +1,66 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	database "github.com/dracory/database"
+)
+
+func TestSelectToJSON(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	// Test nil querier error
+	_, err = database.SelectToJSON(database.Context(context.Background(), nil), "SELECT 1")
+	if err == nil || err.Error() != "querier (db/tx/conn) is nil" {
+		t.Errorf("Expected nil querier error, got %v", err)
+	}
+
+	ctx := database.Context(context.Background(), db)
+
+	_, err = database.Execute(ctx, `CREATE TABLE products (
+		id INTEGER PRIMARY KEY,
+		name VARCHAR(255),
+		price DECIMAL(10, 2),
+		active BOOLEAN,
+		attributes JSON,
+		created_at DATETIME,
+		image BLOB
+	)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = database.Execute(ctx, `INSERT INTO products VALUES
+		(1, 'Pen', '1.50', 'true', '{"color":"blue"}', '2024-01-02 03:04:05', X'0102'),
+		(2, NULL, NULL, NULL, NULL, NULL, NULL)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Test empty result
+	data, err := database.SelectToJSON(ctx, "SELECT * FROM products WHERE id > ?", 10)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if string(data) != "[]" {
+		t.Errorf("Expected empty array, got %s", data)
+	}
+
+	// Test typed values, in the order of the columns
+	data, err = database.SelectToJSON(ctx, "SELECT * FROM products ORDER BY id")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := `[{"id":1,"name":"Pen","price":1.5,"active":true,"attributes":{"color":"blue"},"created_at":"2024-01-02T03:04:05Z","image":"AQI="},` +
+		`{"id":2,"name":null,"price":null,"active":null,"attributes":null,"created_at":null,"image":null}]`
+
+	if string(data) != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, data)
+	}
+}
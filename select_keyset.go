@@ -0,0 +1,113 @@
+package database
+
+import (
+	"errors"
+	"reflect"
+)
+
+// SelectKeyset selects the next page of rows of the table ordered by the
+// given column, using keyset (cursor) pagination, and returns them as a
+// slice of structs of type T (see SelectToStructs), with the cursor
+// of the following page.
+//
+// Unlike LIMIT/OFFSET pagination, the rows of the previous pages are not
+// scanned by the database, so deep pages are as fast as the first one,
+// given that the order column is indexed. The statement is:
+//
+//	SELECT * FROM table WHERE order_col > ? ORDER BY order_col LIMIT n
+//
+// with the placeholder, quoting and LIMIT clause of the dialect.
+//
+// The order column must be unique and not null (i.e. the primary key),
+// and must be mapped to a field of T, which the cursor is read from.
+//
+// Example usage:
+//
+//	var cursor any // nil for the first page
+//	for {
+//		users, next, err := SelectKeyset[User](ctx, "users", "id", cursor, 100)
+//		if err != nil {
+//			return err
+//		}
+//		// process users
+//		if next == nil {
+//			break
+//		}
+//		cursor = next
+//	}
+//
+// Parameters:
+// - ctx (QueryableContext): The context to use for the query execution.
+// - table (string): The name of the table.
+// - orderCol (string): The name of the column to order and paginate by.
+// - after (any): The cursor returned for the previous page, or nil for the first page.
+// - limit (int): The maximum number of rows to return.
+//
+// Returns:
+// - []T: A slice of structs containing the rows of the page.
+// - any: The cursor of the next page, or nil if this is the last page.
+// - error: An error if the arguments are invalid, or the query failed.
+func SelectKeyset[T any](ctx QueryableContext, table string, orderCol string, after any, limit int) ([]T, any, error) {
+	if ctx.queryable == nil {
+		return []T{}, nil, ErrNoQueryable
+	}
+
+	if limit < 1 {
+		return []T{}, nil, errors.New("limit must be greater than zero")
+	}
+
+	t := reflect.TypeFor[T]()
+
+	if t.Kind() != reflect.Struct {
+		return []T{}, nil, errors.New("type " + t.String() + " is not a struct")
+	}
+
+	index, ok := structFields(t)[orderCol]
+
+	if !ok {
+		return []T{}, nil, errors.New("no matching struct field for order column: " + orderCol)
+	}
+
+	dbType := ctx.databaseType()
+
+	quotedTable, err := quoteIdentifier(dbType, table)
+	if err != nil {
+		return []T{}, nil, err
+	}
+
+	quotedOrderCol, err := quoteIdentifier(dbType, orderCol)
+	if err != nil {
+		return []T{}, nil, err
+	}
+
+	sqlStr := "SELECT * FROM " + quotedTable
+	args := []any{}
+
+	if after != nil {
+		sqlStr += " WHERE " + quotedOrderCol + " > ?"
+		args = append(args, after)
+	}
+
+	// One more row is selected, to know if there is a next page
+	sqlStr += " ORDER BY " + quotedOrderCol + " " + DialectFor(dbType).LimitOffset(limit+1, 0)
+
+	items, err := selectToStructs[T](ctx, false, Rebind(dbType, sqlStr), args...)
+
+	if err != nil {
+		return []T{}, nil, err
+	}
+
+	if len(items) <= limit {
+		return items, nil, nil
+	}
+
+	items = items[:limit]
+
+	next, err := reflect.ValueOf(items[limit-1]).FieldByIndexErr(index)
+
+	if err != nil {
+		return []T{}, nil, err
+	}
+
+	return items, next.Interface(), nil
+}
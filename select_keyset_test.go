@@ -0,0 +1,97 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	database "github.com/dracory/database"
+)
+
+func TestSelectKeyset(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	if err := createUserTableAndInserTesttData(db); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := database.Context(context.Background(), db)
+
+	users, next, err := database.SelectKeyset[testUser](ctx, "users", "id", nil, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(users) != 2 || users[0].FullName != "Alice" || users[1].FullName != "Bob" {
+		t.Fatalf("Expected Alice and Bob on the first page, got %+v", users)
+	}
+
+	if next != 2 {
+		t.Fatalf("Expected next cursor 2, got %v", next)
+	}
+
+	users, next, err = database.SelectKeyset[testUser](ctx, "users", "id", next, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(users) != 1 || users[0].FullName != "Charlie" {
+		t.Errorf("Expected Charlie on the last page, got %+v", users)
+	}
+
+	if next != nil {
+		t.Errorf("Expected no next cursor on the last page, got %v", next)
+	}
+}
+
+func TestSelectKeysetExactPage(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	if err := createUserTableAndInserTesttData(db); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := database.Context(context.Background(), db)
+
+	users, next, err := database.SelectKeyset[testUser](ctx, "users", "id", nil, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(users) != 3 || next != nil {
+		t.Errorf("Expected all users and no next cursor, got %d users and %v", len(users), next)
+	}
+}
+
+func TestSelectKeysetInvalid(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	ctx := database.Context(context.Background(), db)
+
+	if _, _, err := database.SelectKeyset[testUser](ctx, "users", "id", nil, 0); err == nil {
+		t.Error("Expected an error for a zero limit")
+	}
+
+	if _, _, err := database.SelectKeyset[testUser](ctx, "users", "unknown", nil, 10); err == nil {
+		t.Error("Expected an error for an order column without a field")
+	}
+
+	if _, _, err := database.SelectKeyset[string](ctx, "users", "id", nil, 10); err == nil {
+		t.Error("Expected an error for a non struct type")
+	}
+
+	if _, _, err := database.SelectKeyset[testUser](database.Context(context.Background(), nil), "users", "id", nil, 10); err == nil {
+		t.Error("Expected an error for a nil querier")
+	}
+}
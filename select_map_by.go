@@ -0,0 +1,183 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+)
+
+// SelectToMapBy executes a SQL query in the given context and returns the rows
+// indexed by the value of the key column, each row scanned into a value of type V.
+//
+// If V is a struct, the columns are mapped to its fields the same way as in
+// SelectToStructs, including the key column if V has a field for it. Otherwise
+// the query must return exactly two columns, the key column and the value column.
+//
+// If several rows have the same key, the last one is kept. Use
+// SelectToMapByWithOptions to return an error instead.
+//
+// Example usage:
+//
+//	usersByID, err := SelectToMapBy[int64, User](ctx, "id", "SELECT * FROM users")
+//	namesByID, err := SelectToMapBy[int64, string](ctx, "id", "SELECT id, name FROM users")
+//
+// Parameters:
+// - ctx (QueryableContext): The context to use for the query execution.
+// - keyColumn (string): The name of the column to index the rows by.
+// - sqlStr (string): The SQL query to execute.
+// - args (any): Optional arguments to pass to the query.
+//
+// Returns:
+// - map[K]V: The rows indexed by the key column.
+// - error: An error if the query failed, or the key column is missing.
+func SelectToMapBy[K comparable, V any](ctx QueryableContext, keyColumn string, sqlStr string, args ...any) (map[K]V, error) {
+	return SelectToMapByWithOptions[K, V](ctx, SelectToMapByOptions{}, keyColumn, sqlStr, args...)
+}
+
+// SelectToMapByOptions configures SelectToMapByWithOptions.
+type SelectToMapByOptions struct {
+	// ErrorOnDuplicate returns an error if several rows have the same key,
+	// instead of keeping the last one.
+	ErrorOnDuplicate bool
+}
+
+// SelectToMapByWithOptions works like SelectToMapBy, but allows configuring
+// how rows with duplicate keys are handled.
+//
+// Example usage:
+//
+//	usersByEmail, err := SelectToMapByWithOptions[string, User](ctx, SelectToMapByOptions{ErrorOnDuplicate: true}, "email", "SELECT * FROM users")
+//
+// Parameters:
+// - ctx (QueryableContext): The context to use for the query execution.
+// - options (SelectToMapByOptions): The duplicate key handling options.
+// - keyColumn (string): The name of the column to index the rows by.
+// - sqlStr (string): The SQL query to execute.
+// - args (any): Optional arguments to pass to the query.
+//
+// Returns:
+// - map[K]V: The rows indexed by the key column.
+// - error: An error if the query failed, the key column is missing, or a key is duplicated.
+func SelectToMapByWithOptions[K comparable, V any](ctx QueryableContext, options SelectToMapByOptions, keyColumn string, sqlStr string, args ...any) (map[K]V, error) {
+	if ctx.queryable == nil {
+		return map[K]V{}, ErrNoQueryable
+	}
+
+	rows, run, err := ctx.queryContext("SelectToMapBy", sqlStr, args...)
+
+	if err != nil {
+		return map[K]V{}, err
+	}
+	defer rows.Close()
+
+	indexed, err := scanRowsToMapBy[K, V](rows, keyColumn, options.ErrorOnDuplicate)
+
+	if err != nil {
+		run.end(-1, err)
+		return map[K]V{}, err
+	}
+
+	run.end(int64(len(indexed)), nil)
+
+	return indexed, nil
+}
+
+// scanRowsToMapBy scans all the rows into a map indexed by the key column
+func scanRowsToMapBy[K comparable, V any](rows *sql.Rows, keyColumn string, errorOnDuplicate bool) (map[K]V, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	keyIndex := slices.Index(columns, keyColumn)
+
+	if keyIndex < 0 {
+		return nil, errors.New("key column not found in the query results: " + keyColumn)
+	}
+
+	isStruct := isMappableStruct(reflect.TypeFor[V]())
+	hasKeyField := false
+
+	if isStruct {
+		_, hasKeyField = structFields(reflect.TypeFor[V]())[keyColumn]
+	}
+
+	if !isStruct && len(columns) != 2 {
+		return nil, errors.New("expected 2 columns (key and value) for type " + reflect.TypeFor[V]().String() + ", got " + strconv.Itoa(len(columns)))
+	}
+
+	indexed := map[K]V{}
+
+	for rows.Next() {
+		var key K
+		var item V
+		var destinations []any
+
+		if isStruct {
+			destinations, err = structScanDestinations(reflect.ValueOf(&item).Elem(), columns, false)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			destinations = []any{&item, &item}
+		}
+
+		// The key column is scanned into the key, and copied
+		// to the struct field afterwards, if there is one
+		keyField := reflect.ValueOf(destinations[keyIndex]).Elem()
+		destinations[keyIndex] = &key
+
+		if err := rows.Scan(destinations...); err != nil {
+			return nil, err
+		}
+
+		if hasKeyField {
+			if err := setKeyField(keyField, key); err != nil {
+				return nil, err
+			}
+		}
+
+		if _, exists := indexed[key]; exists && errorOnDuplicate {
+			return nil, fmt.Errorf("duplicate key %v in column %s", key, keyColumn)
+		}
+
+		indexed[key] = item
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return indexed, nil
+}
+
+// setKeyField sets the struct field of the key column to the scanned key.
+// The key is converted only between numeric types, or types of the same kind.
+func setKeyField(field reflect.Value, key any) error {
+	value := reflect.ValueOf(key)
+
+	if field.Kind() == reflect.Pointer {
+		field.Set(reflect.New(field.Type().Elem()))
+		field = field.Elem()
+	}
+
+	if value.Type().AssignableTo(field.Type()) {
+		field.Set(value)
+		return nil
+	}
+
+	if value.Kind() == field.Kind() || (isNumericKind(value.Kind()) && isNumericKind(field.Kind())) {
+		field.Set(value.Convert(field.Type()))
+		return nil
+	}
+
+	return errors.New("key of type " + value.Type().String() + " can not be assigned to field of type " + field.Type().String())
+}
+
+// isNumericKind checks if the kind is an integer or float kind
+func isNumericKind(kind reflect.Kind) bool {
+	return (kind >= reflect.Int && kind <= reflect.Uint64) || kind == reflect.Float32 || kind == reflect.Float64
+}
@@ -0,0 +1,97 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	database "github.com/dracory/database"
+)
+
+func TestSelectToMapBy(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	err = createUserTableAndInserTesttData(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Test nil querier error
+	_, err = database.SelectToMapBy[int64, testUser](database.Context(context.Background(), nil), "id", "SELECT * FROM users")
+	if err == nil || err.Error() != "querier (db/tx/conn) is nil" {
+		t.Errorf("Expected nil querier error, got %v", err)
+	}
+
+	ctx := database.Context(context.Background(), db)
+
+	// Test structs keyed by id, including the key field
+	users, err := database.SelectToMapBy[int64, testUser](ctx, "id", "SELECT id, name, email FROM users")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(users) != 3 {
+		t.Fatalf("Expected 3 users, got %d", len(users))
+	}
+	if users[2].FullName != "Bob" || users[2].ID != 2 {
+		t.Errorf("Unexpected user for key 2: %+v", users[2])
+	}
+
+	// Test scalars keyed by name
+	ids, err := database.SelectToMapBy[string, int64](ctx, "name", "SELECT name, id FROM users")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if ids["Charlie"] != 3 {
+		t.Errorf("Expected id 3 for Charlie, got %d", ids["Charlie"])
+	}
+
+	// Test missing key column
+	_, err = database.SelectToMapBy[int64, testUser](ctx, "missing", "SELECT id, name FROM users")
+	if err == nil {
+		t.Error("Expected error for missing key column")
+	}
+
+	// Test scalars with more than 2 columns
+	_, err = database.SelectToMapBy[int64, string](ctx, "id", "SELECT id, name, email FROM users")
+	if err == nil {
+		t.Error("Expected error for scalar value with 3 columns")
+	}
+}
+
+func TestSelectToMapByDuplicates(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	err = createUserTableAndInserTesttData(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := database.Context(context.Background(), db)
+
+	_, err = database.Execute(ctx, "UPDATE users SET name = 'Alice'")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Test the last row is kept by default
+	byName, err := database.SelectToMapBy[string, testUser](ctx, "name", "SELECT id, name, email FROM users ORDER BY id")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(byName) != 1 || byName["Alice"].ID != 3 {
+		t.Errorf("Expected the last row to be kept, got %+v", byName)
+	}
+
+	// Test duplicates return an error when configured
+	_, err = database.SelectToMapByWithOptions[string, testUser](ctx, database.SelectToMapByOptions{ErrorOnDuplicate: true}, "name", "SELECT id, name, email FROM users")
+	if err == nil {
+		t.Error("Expected error for duplicate key")
+	}
+}
@@ -0,0 +1,190 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+)
+
+// ErrNoRows is returned by SelectOneMap when the query returns no rows.
+// It is the same error as sql.ErrNoRows, so it can be checked with either.
+var ErrNoRows = sql.ErrNoRows
+
+// SelectOne executes a SQL query in the given context and scans the first row
+// of the results into a value of type T.
+//
+// If T is a struct, the columns are mapped to its fields the same way as in
+// SelectToStructs. Otherwise the query must return a single column, which is
+// scanned directly into T (i.e. string, int64, time.Time, sql.NullString).
+//
+// Any rows after the first one are ignored. Use SelectOneStrict to return
+// an error instead.
+//
+// Example usage:
+//
+//	user, found, err := SelectOne[User](ctx, "SELECT * FROM users WHERE id = ?", 1)
+//	name, found, err := SelectOne[string](ctx, "SELECT name FROM users WHERE id = ?", 1)
+//
+// Parameters:
+// - ctx (QueryableContext): The context to use for the query execution.
+// - sqlStr (string): The SQL query to execute.
+// - args (any): Optional arguments to pass to the query.
+//
+// Returns:
+// - T: The scanned row, or the zero value if no rows were found.
+// - bool: True if a row was found, false otherwise.
+// - error: An error if the query failed.
+func SelectOne[T any](ctx QueryableContext, sqlStr string, args ...any) (T, bool, error) {
+	return selectOne[T](ctx, false, sqlStr, args...)
+}
+
+// SelectOneStrict works like SelectOne, but returns an error if the query
+// returns more than one row, or if a column has no matching struct field.
+//
+// Parameters:
+// - ctx (QueryableContext): The context to use for the query execution.
+// - sqlStr (string): The SQL query to execute.
+// - args (any): Optional arguments to pass to the query.
+//
+// Returns:
+// - T: The scanned row, or the zero value if no rows were found.
+// - bool: True if a row was found, false otherwise.
+// - error: An error if the query failed or returned more than one row.
+func SelectOneStrict[T any](ctx QueryableContext, sqlStr string, args ...any) (T, bool, error) {
+	return selectOne[T](ctx, true, sqlStr, args...)
+}
+
+// SelectOneMap executes a SQL query in the given context and returns the first
+// row of the results as a map, the same way as SelectToMapAny (see also QueryRowMap).
+//
+// If the query returns no rows, ErrNoRows is returned.
+// Any rows after the first one are ignored.
+//
+// Example usage:
+//
+//	user, err := SelectOneMap(ctx, "SELECT * FROM users WHERE id = ?", 1)
+//	if errors.Is(err, database.ErrNoRows) {
+//		return nil, errors.New("user not found")
+//	}
+//
+// Parameters:
+// - ctx (QueryableContext): The context to use for the query execution.
+// - sqlStr (string): The SQL query to execute.
+// - args (any): Optional arguments to pass to the query.
+//
+// Returns:
+// - map[string]any: The first row of the results.
+// - error: ErrNoRows if no rows were found, or an error if the query failed.
+func SelectOneMap(ctx QueryableContext, sqlStr string, args ...any) (map[string]any, error) {
+	return selectOneMap(ctx, "SelectOneMap", sqlStr, args...)
+}
+
+func selectOneMap(ctx QueryableContext, operation string, sqlStr string, args ...any) (map[string]any, error) {
+	if ctx.queryable == nil {
+		return nil, ErrNoQueryable
+	}
+
+	rows, run, err := ctx.queryContext(operation, sqlStr, args...)
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	row, err := scanFirstRowToMap(rows)
+
+	if errors.Is(err, ErrNoRows) {
+		run.end(0, nil)
+		return nil, err
+	}
+
+	if err != nil {
+		run.end(-1, err)
+		return nil, err
+	}
+
+	run.end(1, nil)
+
+	return row, nil
+}
+
+func selectOne[T any](ctx QueryableContext, strict bool, sqlStr string, args ...any) (T, bool, error) {
+	var zero T
+
+	if ctx.queryable == nil {
+		return zero, false, ErrNoQueryable
+	}
+
+	rows, run, err := ctx.queryContext("SelectOne", sqlStr, args...)
+
+	if err != nil {
+		return zero, false, err
+	}
+	defer rows.Close()
+
+	item, found, err := scanFirstRow[T](rows, strict)
+
+	if err != nil {
+		run.end(-1, err)
+		return zero, false, err
+	}
+
+	if found {
+		run.end(1, nil)
+	} else {
+		run.end(0, nil)
+	}
+
+	return item, found, nil
+}
+
+// scanFirstRow scans the first row into a value of type T.
+// In strict mode more than one row is an error.
+func scanFirstRow[T any](rows *sql.Rows, strict bool) (T, bool, error) {
+	var zero T
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return zero, false, err
+	}
+
+	if !rows.Next() {
+		return zero, false, rows.Err()
+	}
+
+	item, err := scanRow[T](rows, columns, strict)
+	if err != nil {
+		return zero, false, err
+	}
+
+	if strict && rows.Next() {
+		return zero, false, errors.New("expected one row, got more")
+	}
+
+	if err := rows.Err(); err != nil {
+		return zero, false, err
+	}
+
+	return item, true, nil
+}
+
+// scanFirstRowToMap scans the first row into a map, or returns ErrNoRows
+func scanFirstRowToMap(rows *sql.Rows) (map[string]any, error) {
+	scanner, err := newRowMapScanner(rows, SelectToMapAnyOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+		return nil, ErrNoRows
+	}
+
+	row, err := scanner.scan(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	return row, rows.Err()
+}
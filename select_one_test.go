@@ -0,0 +1,130 @@
+package database_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	database "github.com/dracory/database"
+)
+
+func TestSelectOne(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	err = createUserTableAndInserTesttData(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Test nil querier error
+	_, _, err = database.SelectOne[testUser](database.Context(context.Background(), nil), "SELECT * FROM users")
+	if err == nil {
+		t.Error("Expected error for nil querier")
+	} else if err.Error() != "querier (db/tx/conn) is nil" {
+		t.Errorf("Unexpected error message: %v", err)
+	}
+
+	// Test struct row
+	user, found, err := database.SelectOne[testUser](database.Context(context.Background(), db), "SELECT id, name, email FROM users WHERE id = ?", 2)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !found {
+		t.Fatal("Expected row to be found")
+	}
+	if user.ID != 2 || user.FullName != "Bob" {
+		t.Errorf("Unexpected user: %+v", user)
+	}
+
+	// Test scalar row
+	name, found, err := database.SelectOne[string](database.Context(context.Background(), db), "SELECT name FROM users WHERE id = ?", 3)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !found || name != "Charlie" {
+		t.Errorf("Expected name 'Charlie', got '%v' (found: %v)", name, found)
+	}
+
+	// Test scalar with multiple columns
+	_, _, err = database.SelectOne[string](database.Context(context.Background(), db), "SELECT id, name FROM users")
+	if err == nil {
+		t.Error("Expected error for scalar with multiple columns")
+	}
+
+	// Test no rows
+	_, found, err = database.SelectOne[testUser](database.Context(context.Background(), db), "SELECT id, name FROM users WHERE id = ?", 100)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if found {
+		t.Error("Expected row not to be found")
+	}
+
+	// Test multiple rows are allowed in non-strict mode
+	_, found, err = database.SelectOne[int](database.Context(context.Background(), db), "SELECT id FROM users ORDER BY id")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !found {
+		t.Error("Expected row to be found")
+	}
+
+	// Test multiple rows are rejected in strict mode
+	_, _, err = database.SelectOneStrict[int](database.Context(context.Background(), db), "SELECT id FROM users ORDER BY id")
+	if err == nil {
+		t.Error("Expected error for multiple rows in strict mode")
+	}
+}
+
+func TestSelectOneMap(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	err = createUserTableAndInserTesttData(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Test nil querier error
+	_, err = database.SelectOneMap(database.Context(context.Background(), nil), "SELECT * FROM users")
+	if err == nil || err.Error() != "querier (db/tx/conn) is nil" {
+		t.Errorf("Expected nil querier error, got %v", err)
+	}
+
+	ctx := database.Context(context.Background(), db)
+
+	// Test first row is returned
+	row, err := database.SelectOneMap(ctx, "SELECT id, name FROM users ORDER BY id DESC")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if row["name"] != "Charlie" {
+		t.Errorf("Expected name 'Charlie', got %v", row["name"])
+	}
+
+	// Test no rows
+	row, err = database.SelectOneMap(ctx, "SELECT * FROM users WHERE id = ?", 999)
+	if !errors.Is(err, database.ErrNoRows) {
+		t.Errorf("Expected ErrNoRows, got %v", err)
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("Expected ErrNoRows to match sql.ErrNoRows, got %v", err)
+	}
+	if row != nil {
+		t.Errorf("Expected nil row, got %v", row)
+	}
+
+	// Test invalid SQL
+	_, err = database.SelectOneMap(ctx, "INVALID SQL")
+	if err == nil || errors.Is(err, database.ErrNoRows) {
+		t.Errorf("Expected query error, got %v", err)
+	}
+}
@@ -0,0 +1,76 @@
+package database
+
+// SelectOrdered executes a SQL query in the given context and returns the
+// column names in the order of the SELECT, together with the values of each row
+// in the same order. The values are converted the same way as in SelectToMapAny.
+//
+// This is useful when the column order matters, i.e. for exporting to CSV
+// or rendering a table, as the maps returned by SelectToMapAny have no order.
+//
+// If the query returns no rows, the function returns the columns and an empty slice.
+//
+// Example usage:
+//
+//	columns, rows, err := SelectOrdered(ctx, "SELECT id, name, email FROM users")
+//	writer.Write(columns)
+//	for _, row := range rows {
+//		writer.Write(cast.ToStringSlice(row))
+//	}
+//
+// Parameters:
+// - ctx (QueryableContext): The context to use for the query execution.
+// - sqlStr (string): The SQL query to execute.
+// - args (any): Optional arguments to pass to the query.
+//
+// Returns:
+// - []string: The names of the columns, in the order of the SELECT.
+// - [][]any: The values of the rows, in the order of the columns.
+// - error: An error if the query failed.
+func SelectOrdered(ctx QueryableContext, sqlStr string, args ...any) (columns []string, rows [][]any, err error) {
+	return selectOrdered(ctx, "SelectOrdered", sqlStr, args...)
+}
+
+func selectOrdered(ctx QueryableContext, operation string, sqlStr string, args ...any) ([]string, [][]any, error) {
+	if ctx.queryable == nil {
+		return nil, [][]any{}, ErrNoQueryable
+	}
+
+	sqlRows, run, err := ctx.queryContext(operation, sqlStr, args...)
+
+	if err != nil {
+		return nil, [][]any{}, err
+	}
+	defer sqlRows.Close()
+
+	scanner, err := newRowMapScanner(sqlRows, SelectToMapAnyOptions{})
+	if err != nil {
+		run.end(-1, err)
+		return nil, [][]any{}, err
+	}
+
+	rows := [][]any{}
+
+	for sqlRows.Next() {
+		if err := checkMaxRows(len(rows), ctx.maxRows); err != nil {
+			run.end(-1, err)
+			return nil, [][]any{}, err
+		}
+
+		values, err := scanner.scanValues(sqlRows)
+		if err != nil {
+			run.end(-1, err)
+			return nil, [][]any{}, err
+		}
+
+		rows = append(rows, values)
+	}
+
+	if err := sqlRows.Err(); err != nil {
+		run.end(-1, err)
+		return nil, [][]any{}, err
+	}
+
+	run.end(int64(len(rows)), nil)
+
+	return scanner.columns, rows, nil
+}
@@ -0,0 +1,60 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	database "github.com/dracory/database"
+
+	"github.com/spf13/cast"
+)
+
+func TestSelectOrdered(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	if _, _, err := database.SelectOrdered(database.Context(context.Background(), nil), "SELECT * FROM users"); !errors.Is(err, database.ErrNoQueryable) {
+		t.Errorf("Expected ErrNoQueryable, got %v", err)
+	}
+
+	if err := createUserTableAndInserTesttData(db); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := database.Context(context.Background(), db)
+
+	columns, rows, err := database.SelectOrdered(ctx, "SELECT email, name, id FROM users ORDER BY id ASC")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !slices.Equal(columns, []string{"email", "name", "id"}) {
+		t.Errorf("Expected columns in SELECT order, got %v", columns)
+	}
+
+	if len(rows) != 3 {
+		t.Fatalf("Expected 3 rows, got %d", len(rows))
+	}
+
+	if rows[1][0] != "bob@example.com" || rows[1][1] != "Bob" || cast.ToInt(rows[1][2]) != 2 {
+		t.Errorf("Unexpected row: %v", rows[1])
+	}
+
+	columns, rows, err = database.SelectOrdered(ctx, "SELECT id, name FROM users WHERE id = ?", 42)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(columns) != 2 || rows == nil || len(rows) != 0 {
+		t.Errorf("Expected columns and no rows, got %v and %v", columns, rows)
+	}
+
+	if _, _, err := database.SelectOrdered(ctx, "INVALID SQL"); err == nil {
+		t.Error("Expected error for invalid SQL")
+	}
+}
@@ -0,0 +1,136 @@
+package database
+
+import (
+	"errors"
+	"strings"
+)
+
+// SelectPage executes a SQL query in the given context and returns a page
+// of its results as a slice of structs of type T (see SelectToStructs),
+// together with the total number of rows of the query.
+//
+// The page is selected by appending the LIMIT/OFFSET clause of the dialect
+// to baseSQL, and the total is counted with SELECT COUNT(*) over baseSQL
+// as a subquery, without its trailing ORDER BY clause (which MSSQL rejects
+// in a subquery). Pages are numbered from 1.
+//
+// If the context carries a transaction (Tx), both queries run in it, so the
+// page and the total are consistent (depending on the isolation level).
+// Otherwise they run as separate statements.
+//
+// The base query should have an ORDER BY clause, so that the pages are stable.
+// For MSSQL it is required.
+//
+// Example usage:
+//
+//	users, total, err := SelectPage[User](ctx, "SELECT * FROM users WHERE active = ? ORDER BY id", 2, 20, 1)
+//
+// Parameters:
+// - ctx (QueryableContext): The context to use for the query execution.
+// - baseSQL (string): The SQL query to page, without LIMIT/OFFSET.
+// - page (int): The page number, starting from 1.
+// - pageSize (int): The maximum number of rows per page.
+// - args (any): Optional arguments to pass to the query.
+//
+// Returns:
+// - []T: A slice of structs containing the rows of the page.
+// - int64: The total number of rows of the query.
+// - error: An error if the page or page size is invalid, or a query failed.
+func SelectPage[T any](ctx QueryableContext, baseSQL string, page int, pageSize int, args ...any) ([]T, int64, error) {
+	if ctx.queryable == nil {
+		return []T{}, 0, ErrNoQueryable
+	}
+
+	if page < 1 {
+		return []T{}, 0, errors.New("page must be greater than zero")
+	}
+
+	if pageSize < 1 {
+		return []T{}, 0, errors.New("page size must be greater than zero")
+	}
+
+	baseSQL = strings.TrimRight(strings.TrimSpace(baseSQL), "; \t\n")
+
+	dialect := DialectFor(ctx.databaseType())
+	countSQL := stripOrderBy(baseSQL, dialect.Name() == DATABASE_TYPE_MSSQL)
+
+	total, err := Count(ctx, "SELECT COUNT(*) FROM ("+countSQL+") page_count", args...)
+
+	if err != nil {
+		return []T{}, 0, err
+	}
+
+	offset := (page - 1) * pageSize
+
+	if total == 0 || int64(offset) >= total {
+		return []T{}, total, nil
+	}
+
+	items, err := selectToStructs[T](ctx, false, baseSQL+" "+dialect.LimitOffset(pageSize, offset), args...)
+
+	if err != nil {
+		return []T{}, 0, err
+	}
+
+	return items, total, nil
+}
+
+// stripOrderBy returns the SQL query without its trailing ORDER BY clause,
+// i.e. the last ORDER BY outside of parentheses, literals and comments.
+// The query is returned unchanged if it has no such clause.
+//
+// Square brackets are treated as quoted identifiers only if bracketQuotes
+// is true (MSSQL), see skipLiteralOrComment.
+func stripOrderBy(sqlStr string, bracketQuotes bool) string {
+	depth := 0
+	orderBy := -1
+
+	for i := 0; i < len(sqlStr); i++ {
+		if end := skipLiteralOrComment(sqlStr, i, bracketQuotes); end > i {
+			i = end - 1
+			continue
+		}
+
+		switch sqlStr[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		default:
+			if depth == 0 && isOrderByAt(sqlStr, i) {
+				orderBy = i
+			}
+		}
+	}
+
+	if orderBy == -1 {
+		return sqlStr
+	}
+
+	return strings.TrimSpace(sqlStr[:orderBy])
+}
+
+// isOrderByAt checks if the keywords ORDER BY, separated by whitespace,
+// start at position i of the SQL query
+func isOrderByAt(sqlStr string, i int) bool {
+	if i > 0 && isIdentifierByte(sqlStr[i-1]) {
+		return false
+	}
+
+	if len(sqlStr) < i+5 || !strings.EqualFold(sqlStr[i:i+5], "ORDER") {
+		return false
+	}
+
+	rest := strings.TrimLeft(sqlStr[i+5:], " \t\r\n")
+
+	if len(rest) == len(sqlStr)-i-5 || len(rest) < 2 || !strings.EqualFold(rest[:2], "BY") {
+		return false
+	}
+
+	return len(rest) == 2 || !isIdentifierByte(rest[2])
+}
+
+// isIdentifierByte checks if the byte can be part of an unquoted identifier
+func isIdentifierByte(c byte) bool {
+	return c == '_' || (c >= '0' && c <= '9') || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
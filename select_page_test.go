@@ -0,0 +1,132 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	database "github.com/dracory/database"
+)
+
+func TestSelectPage(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	if err := createUserTableAndInserTesttData(db); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := database.Context(context.Background(), db)
+
+	users, total, err := database.SelectPage[testUser](ctx, "SELECT id, name, email FROM users ORDER BY id;", 2, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if total != 3 {
+		t.Errorf("Expected total 3, got %d", total)
+	}
+
+	if len(users) != 1 || users[0].FullName != "Charlie" {
+		t.Errorf("Expected only Charlie on page 2, got %+v", users)
+	}
+
+	users, total, err = database.SelectPage[testUser](ctx, "SELECT id, name, email FROM users WHERE id > ? ORDER BY id", 1, 10, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if total != 2 || len(users) != 2 || users[0].FullName != "Bob" {
+		t.Errorf("Expected Bob and Charlie of 2, got %+v of %d", users, total)
+	}
+
+	users, total, err = database.SelectPage[testUser](ctx, "SELECT id, name, email FROM users ORDER BY id", 5, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if total != 3 || len(users) != 0 {
+		t.Errorf("Expected an empty page past the end, got %+v of %d", users, total)
+	}
+}
+
+func TestSelectPageInTransaction(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	if err := createUserTableAndInserTesttData(db); err != nil {
+		t.Fatal(err)
+	}
+
+	err = database.Transaction(context.Background(), db, func(txCtx database.QueryableContext) error {
+		if _, err := database.Execute(txCtx, "INSERT INTO users (name, email) VALUES ('Dave', 'dave@example.com')"); err != nil {
+			return err
+		}
+
+		users, total, err := database.SelectPage[testUser](txCtx, "SELECT id, name, email FROM users ORDER BY id", 1, 3)
+		if err != nil {
+			return err
+		}
+
+		if total != 4 || len(users) != 3 {
+			t.Errorf("Expected 3 users of 4 in the transaction, got %d of %d", len(users), total)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSelectPageInvalid(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	ctx := database.Context(context.Background(), db)
+
+	if _, _, err := database.SelectPage[testUser](ctx, "SELECT * FROM users", -1, 10); err == nil {
+		t.Error("Expected an error for a negative page")
+	}
+
+	if _, _, err := database.SelectPage[testUser](ctx, "SELECT * FROM users", 1, 0); err == nil {
+		t.Error("Expected an error for a zero page size")
+	}
+
+	if _, _, err := database.SelectPage[testUser](database.Context(context.Background(), nil), "SELECT * FROM users", 1, 10); err == nil {
+		t.Error("Expected an error for a nil querier")
+	}
+}
+
+func TestSelectPageMSSQL(t *testing.T) {
+	mock := database.NewMockQueryable().SetDatabaseType(database.DATABASE_TYPE_MSSQL)
+	defer mock.Close()
+
+	baseSQL := "SELECT id, name, email FROM users WHERE name <> 'ORDER BY' AND id IN (SELECT TOP 10 id FROM users ORDER BY id) ORDER  BY\n id"
+
+	// The trailing ORDER BY is not allowed in the count subquery
+	mock.ExpectQuery("SELECT COUNT(*) FROM (SELECT id, name, email FROM users WHERE name <> 'ORDER BY' AND id IN (SELECT TOP 10 id FROM users ORDER BY id)) page_count").
+		WillReturnRows([]string{"count"}, []any{3})
+	mock.ExpectQuery(baseSQL+" OFFSET 2 ROWS FETCH NEXT 2 ROWS ONLY").
+		WillReturnRows([]string{"id", "name", "email"}, []any{3, "Charlie", "charlie@example.com"})
+
+	users, total, err := database.SelectPage[testUser](database.Context(context.Background(), mock), baseSQL, 2, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if total != 3 || len(users) != 1 || users[0].FullName != "Charlie" {
+		t.Errorf("Expected only Charlie of 3 on page 2, got %+v of %d", users, total)
+	}
+
+	mock.AssertExpectations(t)
+}
@@ -0,0 +1,171 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"reflect"
+	"strconv"
+)
+
+// SelectToScalarSlice executes a SQL query in the given context, which is expected
+// to return a single column, and returns the values of the column as a slice of T.
+//
+// If the query returns no rows, the function returns an empty slice.
+//
+// Example usage:
+//
+// ids, err := SelectToScalarSlice[int64](ctx, "SELECT id FROM users WHERE active = ?", 1)
+// emails, err := SelectToScalarSlice[string](ctx, "SELECT email FROM users")
+//
+// Parameters:
+// - ctx (QueryableContext): The context to use for the query execution.
+// - sqlStr (string): The SQL query to execute.
+// - args (any): Optional arguments to pass to the query.
+//
+// Returns:
+// - []T: A slice with the values of the column.
+// - error: An error if the query failed, or returned more than one column.
+func SelectToScalarSlice[T any](ctx QueryableContext, sqlStr string, args ...any) ([]T, error) {
+	return selectScalars[T](ctx, "SelectToScalarSlice", sqlStr, args...)
+}
+
+// selectScalars executes the query as the given operation,
+// and scans the single column of all the rows
+func selectScalars[T any](ctx QueryableContext, operation string, sqlStr string, args ...any) ([]T, error) {
+	if ctx.queryable == nil {
+		return []T{}, ErrNoQueryable
+	}
+
+	rows, run, err := ctx.queryContext(operation, sqlStr, args...)
+
+	if err != nil {
+		return []T{}, err
+	}
+	defer rows.Close()
+
+	list, err := scanRowsToScalars[T](rows)
+
+	if err != nil {
+		run.end(-1, err)
+		return []T{}, err
+	}
+
+	run.end(int64(len(list)), nil)
+
+	return list, nil
+}
+
+func scanRowsToScalars[T any](rows *sql.Rows) ([]T, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return []T{}, err
+	}
+
+	if len(columns) != 1 {
+		return []T{}, errors.New("scalar query must return 1 column, got " + strconv.Itoa(len(columns)))
+	}
+
+	list := []T{}
+
+	for rows.Next() {
+		var value T
+
+		if err := rows.Scan(scanDestination(reflect.ValueOf(&value).Elem())); err != nil {
+			return []T{}, err
+		}
+
+		list = append(list, value)
+	}
+
+	if err := rows.Err(); err != nil {
+		return []T{}, err
+	}
+
+	return list, nil
+}
+
+// Scalar executes a SQL query in the given context and returns the value
+// of the first column of the first row, scanned into type T (i.e. the result
+// of MAX(created_at) into time.Time). Any other columns and rows are ignored.
+//
+// If the query returns no rows, sql.ErrNoRows is returned. For columns which
+// may be NULL, use a pointer type (i.e. *time.Time), which is nil for NULL.
+//
+// Example usage:
+//
+//	latest, err := Scalar[time.Time](ctx, "SELECT MAX(created_at) FROM users")
+//	name, err := Scalar[*string](ctx, "SELECT nickname FROM users WHERE id = ?", 1)
+//
+// Parameters:
+// - ctx (QueryableContext): The context to use for the query execution.
+// - sqlStr (string): The SQL query to execute.
+// - args (any): Optional arguments to pass to the query.
+//
+// Returns:
+// - T: The scanned value.
+// - error: sql.ErrNoRows if no rows were found, or an error if the query failed.
+func Scalar[T any](ctx QueryableContext, sqlStr string, args ...any) (T, error) {
+	var value T
+
+	if ctx.queryable == nil {
+		return value, ErrNoQueryable
+	}
+
+	rows, run, err := ctx.queryContext("Scalar", sqlStr, args...)
+
+	if err != nil {
+		return value, err
+	}
+	defer rows.Close()
+
+	value, err = scanFirstScalar[T](rows)
+
+	if errors.Is(err, sql.ErrNoRows) {
+		run.end(0, nil)
+		return value, err
+	}
+
+	if err != nil {
+		run.end(-1, err)
+		return value, err
+	}
+
+	run.end(1, nil)
+
+	return value, nil
+}
+
+// scanFirstScalar scans the first column of the first row
+func scanFirstScalar[T any](rows *sql.Rows) (T, error) {
+	var value T
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return value, err
+	}
+
+	if len(columns) == 0 {
+		return value, errors.New("query returned no columns")
+	}
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return value, err
+		}
+		return value, sql.ErrNoRows
+	}
+
+	destinations := make([]any, len(columns))
+	// Timestamps returned as text are scanned the same way as struct fields
+	destinations[0] = scanDestination(reflect.ValueOf(&value).Elem())
+
+	for i := 1; i < len(columns); i++ {
+		destinations[i] = new(any)
+	}
+
+	if err := rows.Scan(destinations...); err != nil {
+		return value, err
+	}
+
+	return value, rows.Err()
+}
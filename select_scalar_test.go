@@ -0,0 +1,173 @@
+package database_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	database "github.com/dracory/database"
+)
+
+func TestSelectToScalarSlice(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	err = createUserTableAndInserTesttData(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := database.Context(context.Background(), db)
+
+	// Test nil querier error
+	_, err = database.SelectToScalarSlice[int64](database.Context(context.Background(), nil), "SELECT id FROM users")
+	if err == nil {
+		t.Error("Expected error for nil querier")
+	} else if err.Error() != "querier (db/tx/conn) is nil" {
+		t.Errorf("Unexpected error message: %v", err)
+	}
+
+	// Test integer column
+	ids, err := database.SelectToScalarSlice[int64](ctx, "SELECT id FROM users WHERE id > ? ORDER BY id ASC", 1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(ids) != 2 || ids[0] != 2 || ids[1] != 3 {
+		t.Errorf("Expected [2 3], got %v", ids)
+	}
+
+	// Test string column
+	names, err := database.SelectToScalarSlice[string](ctx, "SELECT name FROM users ORDER BY id ASC")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(names) != 3 || names[0] != "Alice" {
+		t.Errorf("Unexpected names: %v", names)
+	}
+
+	// Test no rows
+	names, err = database.SelectToScalarSlice[string](ctx, "SELECT name FROM users WHERE id = ?", 100)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(names) != 0 {
+		t.Errorf("Expected 0 rows, got %d", len(names))
+	}
+
+	// Test text timestamps into time.Time, like the struct fields
+	_, err = db.Exec("CREATE TABLE events (created_at TEXT)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = db.Exec("INSERT INTO events VALUES ('2024-01-02 03:04:05'), ('2024-03-04 05:06:07')")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	times, err := database.SelectToScalarSlice[time.Time](ctx, "SELECT created_at FROM events ORDER BY created_at")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(times) != 2 || !times[1].Equal(time.Date(2024, 3, 4, 5, 6, 7, 0, time.UTC)) {
+		t.Errorf("Unexpected times: %v", times)
+	}
+
+	// Test more than one column
+	_, err = database.SelectToScalarSlice[string](ctx, "SELECT id, name FROM users")
+	if err == nil {
+		t.Error("Expected error for more than one column")
+	}
+}
+
+func TestScalar(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	err = createUserTableAndInserTesttData(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Test nil querier error
+	_, err = database.Scalar[int64](database.Context(context.Background(), nil), "SELECT 1")
+	if err == nil || err.Error() != "querier (db/tx/conn) is nil" {
+		t.Errorf("Expected nil querier error, got %v", err)
+	}
+
+	ctx := database.Context(context.Background(), db)
+
+	// Test the first column of the first row
+	maxID, err := database.Scalar[int64](ctx, "SELECT MAX(id), COUNT(*) FROM users")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if maxID != 3 {
+		t.Errorf("Expected 3, got %d", maxID)
+	}
+
+	name, err := database.Scalar[string](ctx, "SELECT name FROM users ORDER BY id")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if name != "Alice" {
+		t.Errorf("Expected 'Alice', got %q", name)
+	}
+
+	// Test NULL into a pointer type
+	nullName, err := database.Scalar[*string](ctx, "SELECT NULL")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if nullName != nil {
+		t.Errorf("Expected nil, got %v", *nullName)
+	}
+
+	email, err := database.Scalar[*string](ctx, "SELECT email FROM users WHERE id = ?", 1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if email == nil || *email != "alice@example.com" {
+		t.Errorf("Expected 'alice@example.com', got %v", email)
+	}
+
+	// Test a timestamp returned as text by the driver
+	_, err = db.Exec("CREATE TABLE events (created_at TEXT)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = db.Exec("INSERT INTO events VALUES ('2024-01-02 03:04:05'), ('2024-03-04 05:06:07')")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lastEvent, err := database.Scalar[time.Time](ctx, "SELECT MAX(created_at) FROM events")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if expected := time.Date(2024, 3, 4, 5, 6, 7, 0, time.UTC); !lastEvent.Equal(expected) {
+		t.Errorf("Expected %v, got %v", expected, lastEvent)
+	}
+
+	// Test NULL into a time pointer
+	noEvent, err := database.Scalar[*time.Time](ctx, "SELECT MAX(created_at) FROM events WHERE created_at > ?", "2030")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if noEvent != nil {
+		t.Errorf("Expected nil, got %v", *noEvent)
+	}
+
+	// Test no rows
+	_, err = database.Scalar[int64](ctx, "SELECT id FROM users WHERE id = ?", 999)
+	if !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("Expected sql.ErrNoRows, got %v", err)
+	}
+}
@@ -0,0 +1,79 @@
+package database
+
+// SelectToStructs executes a SQL query in the given context and returns a slice
+// of structs of type T, where each struct represents a row of the query results.
+//
+// Columns are matched to struct fields using the `db:"column_name"` struct tag.
+// Fields without a tag are matched using the snake_case of the field name.
+// Fields tagged with `db:"-"` are ignored. Embedded structs are flattened.
+//
+// For nullable columns, use pointer fields (i.e. *string, *int64, *time.Time),
+// which are set to nil for NULL, or the sql.Null* types. The time.Time,
+// *time.Time and sql.NullTime fields also accept timestamps returned as text
+// by the driver (i.e. SQLite, or MySQL without parseTime).
+//
+// Columns that have no matching field are ignored. Use SelectToStructsStrict
+// to return an error instead.
+//
+// If the query returns no rows, the function returns an empty slice.
+//
+// Example usage:
+//
+//	type User struct {
+//		ID    int     `db:"id"`
+//		Name  string  `db:"name"`
+//		Email *string `db:"email"`
+//	}
+//
+//	users, err := SelectToStructs[User](ctx, "SELECT * FROM users")
+//
+// Parameters:
+// - ctx (QueryableContext): The context to use for the query execution.
+// - sqlStr (string): The SQL query to execute.
+// - args (any): Optional arguments to pass to the query.
+//
+// Returns:
+// - []T: A slice of structs containing the query results.
+// - error: An error if the query failed.
+func SelectToStructs[T any](ctx QueryableContext, sqlStr string, args ...any) ([]T, error) {
+	return selectToStructs[T](ctx, false, sqlStr, args...)
+}
+
+// SelectToStructsStrict works like SelectToStructs, but returns an error
+// listing the columns that had no matching struct field.
+//
+// Parameters:
+// - ctx (QueryableContext): The context to use for the query execution.
+// - sqlStr (string): The SQL query to execute.
+// - args (any): Optional arguments to pass to the query.
+//
+// Returns:
+// - []T: A slice of structs containing the query results.
+// - error: An error if the query failed, or a column had no matching field.
+func SelectToStructsStrict[T any](ctx QueryableContext, sqlStr string, args ...any) ([]T, error) {
+	return selectToStructs[T](ctx, true, sqlStr, args...)
+}
+
+func selectToStructs[T any](ctx QueryableContext, strict bool, sqlStr string, args ...any) ([]T, error) {
+	if ctx.queryable == nil {
+		return []T{}, ErrNoQueryable
+	}
+
+	rows, run, err := ctx.queryContext("SelectToStructs", sqlStr, args...)
+
+	if err != nil {
+		return []T{}, err
+	}
+	defer rows.Close()
+
+	list, err := scanRowsToStructs[T](rows, strict)
+
+	if err != nil {
+		run.end(-1, err)
+		return []T{}, err
+	}
+
+	run.end(int64(len(list)), nil)
+
+	return list, nil
+}
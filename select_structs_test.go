@@ -0,0 +1,236 @@
+package database_test
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	database "github.com/dracory/database"
+)
+
+type testTimestamps struct {
+	CreatedAt *string
+}
+
+type testUser struct {
+	testTimestamps
+	ID       int     `db:"id"`
+	FullName string  `db:"name"`
+	Email    *string // matched by snake_case
+	Ignored  string  `db:"-"`
+}
+
+func TestSelectToStructs(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	err = createUserTableAndInserTesttData(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = db.Exec("ALTER TABLE users ADD COLUMN created_at TEXT")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = db.Exec("UPDATE users SET email = NULL, created_at = '2024-01-01' WHERE name = 'Bob'")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Test nil querier error
+	_, err = database.SelectToStructs[testUser](database.Context(context.Background(), nil), "SELECT * FROM users")
+	if err == nil {
+		t.Error("Expected error for nil querier")
+	} else if err.Error() != "querier (db/tx/conn) is nil" {
+		t.Errorf("Unexpected error message: %v", err)
+	}
+
+	// Test successful query
+	users, err := database.SelectToStructs[testUser](database.Context(context.Background(), db), "SELECT * FROM users ORDER BY id ASC")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(users) != 3 {
+		t.Fatalf("Expected 3 rows, got %d", len(users))
+	}
+
+	if users[0].ID != 1 || users[0].FullName != "Alice" {
+		t.Errorf("Unexpected first user: %+v", users[0])
+	}
+
+	if users[0].Email == nil || *users[0].Email != "alice@example.com" {
+		t.Errorf("Expected email 'alice@example.com', got %v", users[0].Email)
+	}
+
+	if users[1].Email != nil {
+		t.Errorf("Expected nil email for NULL column, got %v", *users[1].Email)
+	}
+
+	if users[1].CreatedAt == nil || *users[1].CreatedAt != "2024-01-01" {
+		t.Errorf("Expected embedded created_at '2024-01-01', got %v", users[1].CreatedAt)
+	}
+
+	// Test unmatched columns are ignored
+	_, err = database.SelectToStructs[testUser](database.Context(context.Background(), db), "SELECT id, name, 1 AS extra FROM users")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Test unmatched columns are reported in strict mode
+	_, err = database.SelectToStructsStrict[testUser](database.Context(context.Background(), db), "SELECT id, name, 1 AS extra FROM users")
+	if err == nil {
+		t.Fatal("Expected error for unmatched column in strict mode")
+	} else if !strings.Contains(err.Error(), "extra") {
+		t.Errorf("Expected error to list column 'extra', got: %v", err)
+	}
+
+	// Test non-struct type
+	_, err = database.SelectToStructs[int](database.Context(context.Background(), db), "SELECT id FROM users")
+	if err == nil {
+		t.Error("Expected error for non-struct type")
+	}
+}
+
+type testNullable struct {
+	Name      *string
+	Age       *int64
+	Score     *float64
+	Active    *bool
+	SeenAt    *time.Time
+	Nickname  sql.NullString
+	Visits    sql.NullInt64
+	Rating    sql.NullFloat64
+	Verified  sql.NullBool
+	DeletedAt sql.NullTime
+}
+
+// assertNullable checks the fields of the nullable row with all NULLs,
+// and of the row with all values set
+func assertNullable(t *testing.T, rows []testNullable) {
+	t.Helper()
+
+	if len(rows) != 2 {
+		t.Fatalf("Expected 2 rows, got %d", len(rows))
+	}
+
+	null := rows[0]
+
+	if null.Name != nil || null.Age != nil || null.Score != nil || null.Active != nil || null.SeenAt != nil {
+		t.Errorf("Expected nil pointers for NULL, got %+v", null)
+	}
+
+	if null.Nickname.Valid || null.Visits.Valid || null.Rating.Valid || null.Verified.Valid || null.DeletedAt.Valid {
+		t.Errorf("Expected invalid sql.Null* for NULL, got %+v", null)
+	}
+
+	set := rows[1]
+	expectedTime := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	if set.Name == nil || *set.Name != "Alice" || set.Age == nil || *set.Age != 42 || set.Score == nil || *set.Score != 1.5 || set.Active == nil || !*set.Active {
+		t.Errorf("Unexpected pointers: %+v", set)
+	}
+
+	if set.SeenAt == nil || !set.SeenAt.Equal(expectedTime) {
+		t.Errorf("Expected SeenAt %v, got %v", expectedTime, set.SeenAt)
+	}
+
+	if set.Nickname != (sql.NullString{String: "Al", Valid: true}) || set.Visits != (sql.NullInt64{Int64: 7, Valid: true}) ||
+		set.Rating != (sql.NullFloat64{Float64: 4.5, Valid: true}) || set.Verified != (sql.NullBool{Bool: true, Valid: true}) {
+		t.Errorf("Unexpected sql.Null* values: %+v", set)
+	}
+
+	if !set.DeletedAt.Valid || !set.DeletedAt.Time.Equal(expectedTime) {
+		t.Errorf("Expected DeletedAt %v, got %+v", expectedTime, set.DeletedAt)
+	}
+}
+
+const testNullableSQL = "SELECT name, age, score, active, seen_at, nickname, visits, rating, verified, deleted_at FROM nullables ORDER BY id"
+
+var testNullableColumns = []string{"name", "age", "score", "active", "seen_at", "nickname", "visits", "rating", "verified", "deleted_at"}
+
+func TestSelectToStructsNullableSqlite(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	_, err = db.Exec(`CREATE TABLE nullables (
+		id INTEGER PRIMARY KEY, name TEXT, age INTEGER, score REAL, active BOOLEAN, seen_at DATETIME,
+		nickname TEXT, visits INTEGER, rating REAL, verified BOOLEAN, deleted_at TEXT
+	)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// deleted_at is a TEXT column, so the driver returns it as a string
+	_, err = db.Exec(`INSERT INTO nullables VALUES
+		(1, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL, NULL),
+		(2, 'Alice', 42, 1.5, 1, '2024-01-02 03:04:05', 'Al', 7, 4.5, 1, '2024-01-02T03:04:05Z')`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rows, err := database.SelectToStructs[testNullable](database.Context(context.Background(), db), testNullableSQL)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	assertNullable(t, rows)
+}
+
+func TestSelectToStructsNullableDriverValues(t *testing.T) {
+	nulls := []any{nil, nil, nil, nil, nil, nil, nil, nil, nil, nil}
+	seenAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		dbType string
+		values []any
+	}{
+		// MySQL returns the values as text without parseTime
+		{database.DATABASE_TYPE_MYSQL, []any{[]byte("Alice"), []byte("42"), []byte("1.5"), []byte("1"), []byte("2024-01-02 03:04:05"), []byte("Al"), []byte("7"), []byte("4.5"), []byte("1"), []byte("2024-01-02 03:04:05")}},
+		// Postgres returns typed values, and timestamps as time.Time
+		{database.DATABASE_TYPE_POSTGRES, []any{"Alice", int64(42), float64(1.5), true, seenAt, "Al", int64(7), float64(4.5), true, seenAt}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.dbType, func(t *testing.T) {
+			mock := database.NewMockQueryable().SetDatabaseType(test.dbType)
+			defer mock.Close()
+
+			mock.ExpectQuery(testNullableSQL).WillReturnRows(testNullableColumns, nulls, test.values)
+
+			rows, err := database.SelectToStructs[testNullable](database.Context(context.Background(), mock), testNullableSQL)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			assertNullable(t, rows)
+
+			mock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestSelectToStructsNullIntoTime(t *testing.T) {
+	mock := database.NewMockQueryable()
+	defer mock.Close()
+
+	mock.ExpectQuery("SELECT created_at FROM users").WillReturnRows([]string{"created_at"}, []any{nil})
+
+	type row struct {
+		CreatedAt time.Time
+	}
+
+	if _, err := database.SelectToStructs[row](database.Context(context.Background(), mock), "SELECT created_at FROM users"); err == nil {
+		t.Error("Expected error for NULL into time.Time")
+	}
+}
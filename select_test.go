@@ -3,6 +3,7 @@ package database_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	database "github.com/dracory/database"
 
@@ -78,6 +79,146 @@ func TestSelectToMapAny(t *testing.T) {
 	}
 }
 
+func TestSelectToMapAnyTextAndBlobColumns(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	_, err = db.Exec("CREATE TABLE files (name TEXT, content BLOB)")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Store raw bytes in both columns, so the driver returns []byte for both
+	_, err = db.Exec("INSERT INTO files (name, content) VALUES (?, ?)", []byte("readme.txt"), []byte("hello"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := database.SelectToMapAny(database.Context(context.Background(), db), "SELECT name, content FROM files")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(result) != 1 {
+		t.Fatalf("Expected 1 row, got %d", len(result))
+	}
+
+	if name, ok := result[0]["name"].(string); !ok || name != "readme.txt" {
+		t.Errorf("Expected text column as string 'readme.txt', got %T %v", result[0]["name"], result[0]["name"])
+	}
+
+	if content, ok := result[0]["content"].([]byte); !ok || string(content) != "hello" {
+		t.Errorf("Expected blob column as []byte 'hello', got %T %v", result[0]["content"], result[0]["content"])
+	}
+}
+
+func TestSelectToMapAnyWithOptions(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	ctx := database.Context(context.Background(), db)
+
+	_, err = database.Execute(ctx, "CREATE TABLE files (name TEXT, content BLOB, created_at DATETIME)")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = database.Execute(ctx, "INSERT INTO files VALUES ('readme.txt', X'68656C6C6F', '2024-01-02 03:04:05')")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The defaults are the same as SelectToMapAny
+	result, err := database.SelectToMapAnyWithOptions(ctx, database.SelectToMapAnyOptions{}, "SELECT * FROM files")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if _, ok := result[0]["content"].([]byte); !ok {
+		t.Errorf("Expected blob column as []byte, got %T", result[0]["content"])
+	}
+
+	if _, ok := result[0]["created_at"].(time.Time); !ok {
+		t.Errorf("Expected datetime column as time.Time, got %T", result[0]["created_at"])
+	}
+
+	result, err = database.SelectToMapAnyWithOptions(ctx, database.SelectToMapAnyOptions{
+		DecodeBytesAsString: true,
+		TimeAsRFC3339:       true,
+	}, "SELECT * FROM files")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if result[0]["name"] != "readme.txt" {
+		t.Errorf("Expected name 'readme.txt', got %T %v", result[0]["name"], result[0]["name"])
+	}
+
+	if result[0]["content"] != "hello" {
+		t.Errorf("Expected blob column as string 'hello', got %T %v", result[0]["content"], result[0]["content"])
+	}
+
+	if result[0]["created_at"] != "2024-01-02T03:04:05Z" {
+		t.Errorf("Expected datetime column as '2024-01-02T03:04:05Z', got %T %v", result[0]["created_at"], result[0]["created_at"])
+	}
+}
+
+func TestSelectToMapAnyNormalizeTime(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	ctx := database.Context(context.Background(), db)
+
+	_, err = database.Execute(ctx, "CREATE TABLE events (name TEXT, happened_at DATETIME)")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Stored as bytes, so the driver returns []byte instead of time.Time
+	_, err = database.Execute(ctx, "INSERT INTO events VALUES (?, ?)", "2024-01-02 03:04:05", []byte("2024-01-02 03:04:05"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := database.SelectToMapAnyWithOptions(ctx, database.SelectToMapAnyOptions{NormalizeTime: true}, "SELECT * FROM events")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	if happenedAt, ok := result[0]["happened_at"].(time.Time); !ok || !happenedAt.Equal(expected) {
+		t.Errorf("Expected datetime column as time.Time %v, got %T %v", expected, result[0]["happened_at"], result[0]["happened_at"])
+	}
+
+	// Text columns are not normalized, even if they look like a time
+	if result[0]["name"] != "2024-01-02 03:04:05" {
+		t.Errorf("Expected text column as string, got %T %v", result[0]["name"], result[0]["name"])
+	}
+
+	result, err = database.SelectToMapAnyWithOptions(ctx, database.SelectToMapAnyOptions{NormalizeTime: true, TimeAsRFC3339: true}, "SELECT * FROM events")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if result[0]["happened_at"] != "2024-01-02T03:04:05Z" {
+		t.Errorf("Expected datetime column as '2024-01-02T03:04:05Z', got %T %v", result[0]["happened_at"], result[0]["happened_at"])
+	}
+}
+
 func TestSelectToMapString(t *testing.T) {
 	db, err := initSqliteDB()
 	if err != nil {
@@ -164,3 +305,85 @@ func createUserTableAndInserTesttData(db database.QueryableInterface) error {
 
 	return nil
 }
+
+func TestSelectToMapStringNullHandling(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	err = createUserTableAndInserTesttData(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = db.Exec("UPDATE users SET email = NULL WHERE name = 'Bob'")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := database.Context(context.Background(), db)
+	sqlStr := "SELECT * FROM users ORDER BY id ASC"
+
+	// Test default, NULL is an empty string
+	result, err := database.SelectToMapString(ctx, sqlStr)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if email, ok := result[1]["email"]; !ok || email != "" {
+		t.Errorf("Expected empty email, got '%v' (present: %v)", email, ok)
+	}
+
+	// Test sentinel value
+	result, err = database.SelectToMapStringWithOptions(ctx, database.SelectToMapStringOptions{NullValue: "<NULL>"}, sqlStr)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result[1]["email"] != "<NULL>" {
+		t.Errorf("Expected email '<NULL>', got '%v'", result[1]["email"])
+	}
+	if result[0]["email"] != "alice@example.com" {
+		t.Errorf("Expected email 'alice@example.com', got '%v'", result[0]["email"])
+	}
+
+	// Test omitted NULLs
+	result, err = database.SelectToMapStringWithOptions(ctx, database.SelectToMapStringOptions{OmitNulls: true}, sqlStr)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, ok := result[1]["email"]; ok {
+		t.Error("Expected NULL email to be omitted")
+	}
+	if result[1]["name"] != "Bob" {
+		t.Errorf("Expected name 'Bob', got '%v'", result[1]["name"])
+	}
+
+	// Test pointer values
+	resultPtr, err := database.SelectToMapStringPtr(ctx, sqlStr)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(resultPtr) != 3 {
+		t.Fatalf("Expected 3 rows, got %d", len(resultPtr))
+	}
+	if resultPtr[1]["email"] != nil {
+		t.Errorf("Expected nil email, got '%v'", *resultPtr[1]["email"])
+	}
+	if resultPtr[0]["email"] == nil || *resultPtr[0]["email"] != "alice@example.com" {
+		t.Errorf("Expected email 'alice@example.com', got %v", resultPtr[0]["email"])
+	}
+	if resultPtr[0]["id"] == nil || *resultPtr[0]["id"] != "1" {
+		t.Errorf("Expected id '1', got %v", resultPtr[0]["id"])
+	}
+
+	// Test nil querier error
+	_, err = database.SelectToMapStringPtr(database.Context(context.Background(), nil), sqlStr)
+	if err == nil {
+		t.Error("Expected error for nil querier")
+	}
+}
@@ -0,0 +1,125 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"hash/fnv"
+	"strconv"
+)
+
+// ShardRouter routes queries to one of several databases (shards),
+// by a hash of a key, i.e. the tenant id.
+//
+// The helpers (Query, Execute, SelectTo*, etc.) use whatever queryable
+// the context carries, so only the context changes, not the calls.
+//
+// Example:
+//
+//	router, err := database.NewShardRouter([]*sql.DB{shard0, shard1, shard2}, nil)
+//	if err != nil {
+//		return err
+//	}
+//
+//	ctx := router.ContextFor(ctx, tenantID)
+//	rows, err := database.Query(ctx, "SELECT * FROM orders WHERE tenant_id = ?", tenantID)
+type ShardRouter struct {
+	shards []*sql.DB
+	hash   func(key string) int
+}
+
+// NewShardRouter creates a new router for the shards.
+//
+// The hash function maps a key to an integer, which is reduced modulo the
+// number of shards (negative values are allowed) to select the shard.
+// If it is nil, the 32-bit FNV-1a hash of the key is used.
+//
+// Note that changing the number or the order of the shards, or the hash
+// function, routes keys to other shards.
+//
+// Parameters:
+// - shards: The databases, at least one, in a stable order.
+// - hash: The hash function of the keys, or nil for FNV-1a.
+//
+// Returns:
+// - *ShardRouter: The new router.
+// - error: An error if there are no shards, or a shard is nil.
+func NewShardRouter(shards []*sql.DB, hash func(key string) int) (*ShardRouter, error) {
+	if len(shards) == 0 {
+		return nil, errors.New("at least one shard is required")
+	}
+
+	for i, shard := range shards {
+		if shard == nil {
+			return nil, errors.New("shard " + strconv.Itoa(i) + " is nil")
+		}
+	}
+
+	if hash == nil {
+		hash = fnvHash
+	}
+
+	return &ShardRouter{
+		shards: append([]*sql.DB{}, shards...),
+		hash:   hash,
+	}, nil
+}
+
+// Shards returns the databases of the router.
+func (r *ShardRouter) Shards() []*sql.DB {
+	return r.shards
+}
+
+// ShardIndex returns the index of the shard the key is routed to.
+func (r *ShardRouter) ShardIndex(key string) int {
+	index := r.hash(key) % len(r.shards)
+
+	if index < 0 {
+		index += len(r.shards)
+	}
+
+	return index
+}
+
+// ShardFor returns the database the key is routed to.
+func (r *ShardRouter) ShardFor(key string) *sql.DB {
+	return r.shards[r.ShardIndex(key)]
+}
+
+// ContextFor returns a QueryableContext carrying the database the key is
+// routed to. If ctx is (or was derived from) a QueryableContext, its options
+// are kept, but its queryable is replaced, so a transaction must be started
+// on the returned context.
+//
+// Parameters:
+// - ctx: The parent context.
+// - key: The key to route by, i.e. the tenant id.
+//
+// Returns:
+// - QueryableContext: The context carrying the shard of the key.
+func (r *ShardRouter) ContextFor(ctx context.Context, key string) QueryableContext {
+	shard := r.ShardFor(key)
+
+	return NewQueryableContextOr(ctx, shard).WithQueryable(shard)
+}
+
+// Close closes all the shards.
+//
+// Returns:
+// - error: The joined errors of closing the databases, or nil.
+func (r *ShardRouter) Close() error {
+	errs := []error{}
+
+	for _, shard := range r.shards {
+		errs = append(errs, shard.Close())
+	}
+
+	return errors.Join(errs...)
+}
+
+// fnvHash is the default hash function of ShardRouter
+func fnvHash(key string) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	return int(h.Sum32())
+}
@@ -0,0 +1,88 @@
+package database_test
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"testing"
+
+	database "github.com/dracory/database"
+)
+
+func TestShardRouter(t *testing.T) {
+	shard0 := initNamedSqliteDB(t, "shard0")
+	shard1 := initNamedSqliteDB(t, "shard1")
+
+	// Routes numeric keys by their value, to know the expected shard
+	router, err := database.NewShardRouter([]*sql.DB{shard0, shard1}, func(key string) int {
+		n, _ := strconv.Atoi(key)
+		return n
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer router.Close()
+
+	ctx := context.Background()
+
+	if name := nodeName(t, router.ContextFor(ctx, "4")); name != "shard0" {
+		t.Errorf("Expected key 4 on shard0, got %s", name)
+	}
+
+	if name := nodeName(t, router.ContextFor(ctx, "7")); name != "shard1" {
+		t.Errorf("Expected key 7 on shard1, got %s", name)
+	}
+
+	// Negative hashes are routed too
+	if index := router.ShardIndex("-3"); index != 1 {
+		t.Errorf("Expected key -3 on shard 1, got %d", index)
+	}
+
+	// The queryable of an existing context is replaced, keeping its options
+	qCtx := database.Context(ctx, shard0).WithRebind()
+
+	if name := nodeName(t, router.ContextFor(qCtx, "1")); name != "shard1" {
+		t.Errorf("Expected key 1 on shard1, got %s", name)
+	}
+}
+
+func TestShardRouterDefaultHash(t *testing.T) {
+	shards := []*sql.DB{initNamedSqliteDB(t, "shard0"), initNamedSqliteDB(t, "shard1"), initNamedSqliteDB(t, "shard2")}
+
+	router, err := database.NewShardRouter(shards, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer router.Close()
+
+	used := map[int]bool{}
+
+	for i := range 100 {
+		key := "tenant-" + strconv.Itoa(i)
+		index := router.ShardIndex(key)
+
+		if index < 0 || index >= len(shards) {
+			t.Fatalf("Shard index out of range: %d", index)
+		}
+
+		if router.ShardIndex(key) != index || router.ShardFor(key) != shards[index] {
+			t.Fatalf("Expected stable routing for %s", key)
+		}
+
+		used[index] = true
+	}
+
+	if len(used) != len(shards) {
+		t.Errorf("Expected keys on all the shards, got %v", used)
+	}
+}
+
+func TestNewShardRouterInvalid(t *testing.T) {
+	if _, err := database.NewShardRouter(nil, nil); err == nil {
+		t.Error("Expected an error without shards")
+	}
+
+	if _, err := database.NewShardRouter([]*sql.DB{nil}, nil); err == nil {
+		t.Error("Expected an error for a nil shard")
+	}
+}
@@ -0,0 +1,59 @@
+package database
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// slowQueryThreshold is the duration above which queries are reported
+// to the slow query handler, zero if disabled
+var slowQueryThreshold atomic.Int64
+
+// slowQueryHandler is the package level slow query handler, nil if not set
+var slowQueryHandler atomic.Pointer[func(info QueryLog)]
+
+// SetSlowQueryThreshold sets the duration above which statements executed
+// through the package helpers are reported to the slow query handler.
+//
+// A threshold of zero disables the slow query reporting.
+// It is safe for concurrent use.
+//
+// Example:
+//
+//	database.SetSlowQueryThreshold(200 * time.Millisecond)
+//	database.SetSlowQueryHandler(func(info database.QueryLog) {
+//		slog.Warn("slow query", "sql", info.SQL, "duration", info.Duration)
+//	})
+//
+// Parameters:
+// - threshold: The duration threshold, or zero to disable.
+func SetSlowQueryThreshold(threshold time.Duration) {
+	if threshold < 0 {
+		threshold = 0
+	}
+
+	slowQueryThreshold.Store(int64(threshold))
+}
+
+// SetSlowQueryHandler sets a package level function, which is called after
+// each statement executed through the package helpers that took longer than
+// the slow query threshold (see SetSlowQueryThreshold).
+//
+// Setting it to nil disables the slow query reporting.
+// It is safe for concurrent use.
+//
+// Parameters:
+// - handler: The function to call with the query information, or nil.
+func SetSlowQueryHandler(handler func(info QueryLog)) {
+	if handler == nil {
+		slowQueryHandler.Store(nil)
+		return
+	}
+
+	slowQueryHandler.Store(&handler)
+}
+
+// isSlowQueryEnabled checks if both the slow query threshold and handler are set
+func isSlowQueryEnabled() bool {
+	return slowQueryThreshold.Load() > 0 && slowQueryHandler.Load() != nil
+}
@@ -0,0 +1,71 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	database "github.com/dracory/database"
+)
+
+func TestSlowQueryHandler(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	err = createUserTableAndInserTesttData(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	slow := []database.QueryLog{}
+	database.SetSlowQueryHandler(func(info database.QueryLog) {
+		slow = append(slow, info)
+	})
+	defer database.SetSlowQueryHandler(nil)
+	defer database.SetSlowQueryThreshold(0)
+
+	ctx := database.Context(context.Background(), db)
+
+	// Test a threshold of zero disables the handler
+	database.SetSlowQueryThreshold(0)
+
+	_, err = database.SelectToMapAny(ctx, "SELECT * FROM users")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(slow) != 0 {
+		t.Fatalf("Expected no slow queries with zero threshold, got %d", len(slow))
+	}
+
+	// Test fast queries are not reported
+	database.SetSlowQueryThreshold(time.Hour)
+
+	_, err = database.SelectToMapAny(ctx, "SELECT * FROM users")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(slow) != 0 {
+		t.Fatalf("Expected no slow queries below threshold, got %d", len(slow))
+	}
+
+	// Test slow queries are reported
+	database.SetSlowQueryThreshold(time.Nanosecond)
+
+	_, err = database.SelectToMapAny(ctx, "SELECT * FROM users")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(slow) != 1 {
+		t.Fatalf("Expected 1 slow query, got %d", len(slow))
+	}
+
+	if slow[0].SQL != "SELECT * FROM users" || slow[0].Duration <= time.Nanosecond {
+		t.Errorf("Unexpected slow query: %+v", slow[0])
+	}
+}
@@ -0,0 +1,56 @@
+package database
+
+// defaultSoftDeleteColumn is the column marking the soft deleted rows,
+// unless configured otherwise with SoftDeleteColumn
+const defaultSoftDeleteColumn = "deleted_at"
+
+// WithExcludeDeleted returns a copy of the context, in which the Select and
+// Update builders exclude the soft deleted rows, the rows whose soft delete
+// column ("deleted_at" by default) is not NULL, unless IncludeDeleted(true)
+// is called on the builder.
+//
+// Example:
+//
+//	qCtx := database.Context(ctx, db).WithExcludeDeleted(true)
+//
+//	// SELECT * FROM "users" WHERE "deleted_at" IS NULL
+//	users, err := database.Select("users").ToMapAny(qCtx)
+//
+// Parameters:
+// - exclude: True to exclude the soft deleted rows, false to include them.
+//
+// Returns:
+// - QueryableContext: A new context with the soft delete filtering.
+func (ctx QueryableContext) WithExcludeDeleted(exclude bool) QueryableContext {
+	ctx.excludeDeleted = exclude
+	return ctx
+}
+
+// softDeleteFilter returns the conditions with the condition excluding
+// the soft deleted rows added, if they are excluded
+func softDeleteFilter(dbType string, conditions []string, column string, exclude bool) ([]string, error) {
+	if !exclude {
+		return conditions, nil
+	}
+
+	if column == "" {
+		column = defaultSoftDeleteColumn
+	}
+
+	quotedColumn, err := quoteIdentifier(dbType, column)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(conditions[:len(conditions):len(conditions)], quotedColumn+" IS NULL"), nil
+}
+
+// isExcludingDeleted checks if the soft deleted rows are excluded, by the
+// option of the builder if set, or by the option of the context otherwise
+func isExcludingDeleted(includeDeleted *bool, excludeDeleted bool) bool {
+	if includeDeleted != nil {
+		return !*includeDeleted
+	}
+
+	return excludeDeleted
+}
@@ -0,0 +1,219 @@
+package database
+
+import (
+	"container/list"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// stmtCache is a concurrency safe LRU cache of prepared statements,
+// keyed by the database and the SQL text
+type stmtCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	entries    map[stmtCacheKey]*list.Element
+	lru        *list.List // front is the most recently used
+}
+
+// stmtCacheKey identifies a cached prepared statement
+type stmtCacheKey struct {
+	db  *sql.DB
+	sql string
+}
+
+// stmtCacheEntry is the value of the LRU list elements
+type stmtCacheEntry struct {
+	key  stmtCacheKey
+	stmt *sql.Stmt
+}
+
+// preparedStatementCache is the package level prepared statement cache,
+// nil if disabled
+var preparedStatementCache atomic.Pointer[stmtCache]
+
+// SetPreparedStatementCache enables or disables caching of prepared statements.
+//
+// When enabled, the statements executed through the package helpers directly
+// on a *sql.DB are prepared once and reused, keyed by the database and the
+// SQL text. The least recently used statements are closed and evicted, when
+// there are more than maxEntries.
+//
+// Statements executed in a transaction (Tx) or on a connection (Conn) are not
+// cached, as their lifetime differs from the lifetime of the database.
+//
+// A cached statement is evicted if executing it fails with a bad connection,
+// but not for ordinary errors (i.e. a constraint violation), and all the statements
+// of a database are evicted once executing one reports the database is closed. Disabling the cache
+// (or changing its size) closes all the cached statements.
+//
+// It is safe for concurrent use.
+//
+// Example:
+//
+//	database.SetPreparedStatementCache(true, 100)
+//
+// Parameters:
+// - enabled: True to enable the cache, false to disable it.
+// - maxEntries: The maximum number of cached statements, must be positive when enabled.
+func SetPreparedStatementCache(enabled bool, maxEntries int) {
+	var cache *stmtCache
+
+	if enabled && maxEntries > 0 {
+		cache = &stmtCache{
+			maxEntries: maxEntries,
+			entries:    map[stmtCacheKey]*list.Element{},
+			lru:        list.New(),
+		}
+	}
+
+	if previous := preparedStatementCache.Swap(cache); previous != nil {
+		previous.clear()
+	}
+}
+
+// cachedStmt returns the cached prepared statement for the SQL, preparing
+// and caching it if needed.
+//
+// It returns nil if the cache is disabled or the queryable is not a *sql.DB,
+// in which case the statement should be executed directly.
+func cachedStmt(ctx context.Context, queryable QueryableInterface, sqlStr string) (*sql.Stmt, error) {
+	cache := preparedStatementCache.Load()
+
+	if cache == nil {
+		return nil, nil
+	}
+
+	db, isDB := queryable.(*sql.DB)
+
+	if !isDB {
+		return nil, nil
+	}
+
+	return cache.get(ctx, db, sqlStr)
+}
+
+// get returns the cached statement, or prepares and caches a new one
+func (c *stmtCache) get(ctx context.Context, db *sql.DB, sqlStr string) (*sql.Stmt, error) {
+	key := stmtCacheKey{db: db, sql: sqlStr}
+
+	c.mu.Lock()
+	if element, ok := c.entries[key]; ok {
+		c.lru.MoveToFront(element)
+		c.mu.Unlock()
+		return element.Value.(*stmtCacheEntry).stmt, nil
+	}
+	c.mu.Unlock()
+
+	// Prepared outside of the lock, so that a slow prepare
+	// does not block the other statements
+	stmt, err := db.PrepareContext(ctx, sqlStr)
+
+	if err != nil {
+		if isDatabaseClosedError(err) {
+			c.evictDB(db)
+		}
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Another goroutine may have cached the same statement meanwhile
+	if element, ok := c.entries[key]; ok {
+		c.lru.MoveToFront(element)
+		_ = stmt.Close()
+		return element.Value.(*stmtCacheEntry).stmt, nil
+	}
+
+	c.entries[key] = c.lru.PushFront(&stmtCacheEntry{key: key, stmt: stmt})
+
+	for c.lru.Len() > c.maxEntries {
+		c.removeElement(c.lru.Back())
+	}
+
+	return stmt, nil
+}
+
+// invalidate evicts the statement after executing it failed with an error,
+// which makes it unusable (a closed database or a bad connection), so that
+// it is prepared again next time. Other errors, i.e. a constraint violation
+// or a deadlock, keep the statement cached. Open rows keep using the
+// statement until closed.
+func (c *stmtCache) invalidate(db *sql.DB, sqlStr string, err error) {
+	if c == nil {
+		return
+	}
+
+	if isDatabaseClosedError(err) {
+		c.evictDB(db)
+		return
+	}
+
+	if !errors.Is(err, driver.ErrBadConn) {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[stmtCacheKey{db: db, sql: sqlStr}]; ok {
+		c.removeElement(element)
+	}
+}
+
+// evictDB evicts all the statements of the database
+func (c *stmtCache) evictDB(db *sql.DB) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, element := range c.entries {
+		if key.db == db {
+			c.removeElement(element)
+		}
+	}
+}
+
+// clear evicts all the statements
+func (c *stmtCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, element := range c.entries {
+		c.removeElement(element)
+	}
+}
+
+// len returns the number of cached statements
+func (c *stmtCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.lru.Len()
+}
+
+// removeElement closes and removes the statement, the lock must be held
+func (c *stmtCache) removeElement(element *list.Element) {
+	entry := element.Value.(*stmtCacheEntry)
+
+	c.lru.Remove(element)
+	delete(c.entries, entry.key)
+
+	// Closing is deferred by database/sql until open rows are closed
+	_ = entry.stmt.Close()
+}
+
+// isDatabaseClosedError checks if the error was returned because the
+// database was closed. database/sql does not export this error.
+func isDatabaseClosedError(err error) bool {
+	return err != nil && err.Error() == "sql: database is closed"
+}
+
+// isStatementClosedError checks if the error was returned because the
+// statement was closed. database/sql does not export this error.
+func isStatementClosedError(err error) bool {
+	return err != nil && err.Error() == "sql: statement is closed"
+}
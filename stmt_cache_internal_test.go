@@ -0,0 +1,157 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"testing"
+
+	_ "modernc.org/sqlite"
+)
+
+func TestPreparedStatementCache(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	// A single connection, so that the in memory database is shared
+	db.SetMaxOpenConns(1)
+
+	SetPreparedStatementCache(true, 2)
+	defer SetPreparedStatementCache(false, 0)
+
+	cache := preparedStatementCache.Load()
+	ctx := Context(context.Background(), db)
+
+	_, err = Execute(ctx, "CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT)")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for _, name := range []string{"Alice", "Bob"} {
+		_, err = Execute(ctx, "INSERT INTO users (name) VALUES (?)", name)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	if cache.len() != 2 {
+		t.Errorf("Expected 2 cached statements, got %d", cache.len())
+	}
+
+	rows, err := Query(ctx, "SELECT name FROM users ORDER BY id")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	names := []string{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, name)
+	}
+	rows.Close()
+
+	if len(names) != 2 || names[0] != "Alice" || names[1] != "Bob" {
+		t.Errorf("Unexpected names: %v", names)
+	}
+
+	// Test the least recently used statement (CREATE TABLE) was evicted
+	if cache.len() != 2 {
+		t.Errorf("Expected 2 cached statements after eviction, got %d", cache.len())
+	}
+	if _, ok := cache.entries[stmtCacheKey{db: db, sql: "CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT)"}]; ok {
+		t.Error("Expected the least recently used statement to be evicted")
+	}
+
+	// Test statements in a transaction are not cached
+	err = Transaction(context.Background(), db, func(txCtx QueryableContext) error {
+		_, err := Execute(txCtx, "UPDATE users SET name = ? WHERE id = ?", "Alicia", 1)
+		return err
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, ok := cache.entries[stmtCacheKey{db: db, sql: "UPDATE users SET name = ? WHERE id = ?"}]; ok {
+		t.Error("Expected statement in transaction not to be cached")
+	}
+
+	// Test a statement failing with an ordinary error stays cached
+	_, err = Execute(ctx, "INSERT INTO users (id, name) VALUES (?, ?)", 1, "Duplicate")
+	if err == nil {
+		t.Fatal("Expected error for duplicate id")
+	}
+	insertKey := stmtCacheKey{db: db, sql: "INSERT INTO users (id, name) VALUES (?, ?)"}
+	if _, ok := cache.entries[insertKey]; !ok {
+		t.Error("Expected statement failing with a constraint violation to stay cached")
+	}
+
+	// Test a statement failing with a bad connection is evicted
+	cache.invalidate(db, insertKey.sql, fmt.Errorf("exec: %w", driver.ErrBadConn))
+	if _, ok := cache.entries[insertKey]; ok {
+		t.Error("Expected statement failing with a bad connection to be evicted")
+	}
+
+	// Test a statement closed meanwhile falls back to the queryable
+	_, err = Execute(ctx, "SELECT name FROM users WHERE id = ?", 1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	cache.entries[stmtCacheKey{db: db, sql: "SELECT name FROM users WHERE id = ?"}].Value.(*stmtCacheEntry).stmt.Close()
+
+	var name string
+	if err := QueryRow(ctx, "SELECT name FROM users WHERE id = ?", 1).Scan(&name); err != nil {
+		t.Fatalf("Unexpected error for a closed statement: %v", err)
+	}
+
+	// Test all the statements of a closed database are evicted
+	db.Close()
+
+	_, err = Execute(ctx, "INSERT INTO users (name) VALUES (?)", "Charlie")
+	if err == nil {
+		t.Fatal("Expected error for closed database")
+	}
+	if cache.len() != 0 {
+		t.Errorf("Expected no cached statements after closing the database, got %d", cache.len())
+	}
+}
+
+func TestSetPreparedStatementCacheDisable(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	SetPreparedStatementCache(true, 10)
+	cache := preparedStatementCache.Load()
+
+	_, err = Execute(Context(context.Background(), db), "SELECT 1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if cache.len() != 1 {
+		t.Errorf("Expected 1 cached statement, got %d", cache.len())
+	}
+
+	SetPreparedStatementCache(false, 0)
+
+	if preparedStatementCache.Load() != nil {
+		t.Error("Expected cache to be disabled")
+	}
+	if cache.len() != 0 {
+		t.Errorf("Expected cached statements to be closed when disabling, got %d", cache.len())
+	}
+
+	// Test statements are executed directly when disabled
+	_, err = Execute(Context(context.Background(), db), "SELECT 1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
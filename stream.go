@@ -0,0 +1,139 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"reflect"
+)
+
+// ErrStopIteration can be returned by the callback passed to Stream
+// or StreamStructs to stop the iteration early without an error.
+var ErrStopIteration = errors.New("stop iteration")
+
+// Stream executes a SQL query in the given context and calls fn for each row
+// of the query results, without loading all the rows in memory.
+//
+// Each row is passed as a map, in the same format as in SelectToMapAny.
+// If fn returns ErrStopIteration, the iteration stops and Stream returns nil.
+// If fn returns any other error, the iteration stops and the error is returned.
+// The rows are always closed before Stream returns.
+//
+// Example usage:
+//
+//	err := Stream(ctx, "SELECT * FROM logs", func(row map[string]any) error {
+//		if row["level"] == "fatal" {
+//			return database.ErrStopIteration
+//		}
+//		return process(row)
+//	})
+//
+// Parameters:
+// - ctx (QueryableContext): The context to use for the query execution.
+// - sqlStr (string): The SQL query to execute.
+// - fn (func(map[string]any) error): The function to call for each row.
+// - args (any): Optional arguments to pass to the query.
+//
+// Returns:
+// - error: An error if the query failed, or the error returned by fn.
+func Stream(ctx QueryableContext, sqlStr string, fn func(row map[string]any) error, args ...any) error {
+	if ctx.queryable == nil {
+		return ErrNoQueryable
+	}
+
+	if fn == nil {
+		return errors.New("stream function is nil")
+	}
+
+	rows, run, err := ctx.queryContext("Stream", sqlStr, args...)
+
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	scanner, err := newRowMapScanner(rows, SelectToMapAnyOptions{})
+	if err != nil {
+		run.end(-1, err)
+		return err
+	}
+
+	count, err := streamRows(rows, func() (map[string]any, error) { return scanner.scan(rows) }, fn)
+
+	run.end(count, err)
+
+	return err
+}
+
+// StreamStructs works like Stream, but scans each row into a struct of type T,
+// mapping the columns to fields the same way as in SelectToStructs.
+//
+// Example usage:
+//
+//	err := StreamStructs(ctx, "SELECT * FROM users", func(user User) error {
+//		return sendNewsletter(user.Email)
+//	})
+//
+// Parameters:
+// - ctx (QueryableContext): The context to use for the query execution.
+// - sqlStr (string): The SQL query to execute.
+// - fn (func(T) error): The function to call for each row.
+// - args (any): Optional arguments to pass to the query.
+//
+// Returns:
+// - error: An error if the query failed, or the error returned by fn.
+func StreamStructs[T any](ctx QueryableContext, sqlStr string, fn func(row T) error, args ...any) error {
+	if ctx.queryable == nil {
+		return ErrNoQueryable
+	}
+
+	if fn == nil {
+		return errors.New("stream function is nil")
+	}
+
+	if reflect.TypeFor[T]().Kind() != reflect.Struct {
+		return errors.New("type " + reflect.TypeFor[T]().String() + " is not a struct")
+	}
+
+	rows, run, err := ctx.queryContext("StreamStructs", sqlStr, args...)
+
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		run.end(-1, err)
+		return err
+	}
+
+	count, err := streamRows(rows, func() (T, error) { return scanRow[T](rows, columns, false) }, fn)
+
+	run.end(count, err)
+
+	return err
+}
+
+// streamRows calls fn for each row scanned with scan,
+// and returns the number of rows read.
+func streamRows[T any](rows *sql.Rows, scan func() (T, error), fn func(row T) error) (int64, error) {
+	var count int64
+
+	for rows.Next() {
+		row, err := scan()
+		if err != nil {
+			return count, err
+		}
+
+		count++
+
+		if err := fn(row); err != nil {
+			if errors.Is(err, ErrStopIteration) {
+				return count, nil
+			}
+			return count, err
+		}
+	}
+
+	return count, rows.Err()
+}
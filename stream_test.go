@@ -0,0 +1,106 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	database "github.com/dracory/database"
+)
+
+func TestStream(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	err = createUserTableAndInserTesttData(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := database.Context(context.Background(), db)
+
+	// Test nil querier error
+	err = database.Stream(database.Context(context.Background(), nil), "SELECT * FROM users", func(row map[string]any) error {
+		return nil
+	})
+	if err == nil {
+		t.Error("Expected error for nil querier")
+	}
+
+	// Test all rows are streamed
+	names := []string{}
+	err = database.Stream(ctx, "SELECT * FROM users WHERE id > ? ORDER BY id ASC", func(row map[string]any) error {
+		names = append(names, row["name"].(string))
+		return nil
+	}, 0)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(names) != 3 || names[0] != "Alice" || names[2] != "Charlie" {
+		t.Errorf("Unexpected names: %v", names)
+	}
+
+	// Test early stop
+	count := 0
+	err = database.Stream(ctx, "SELECT * FROM users ORDER BY id ASC", func(row map[string]any) error {
+		count++
+		return database.ErrStopIteration
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("Expected 1 row before stopping, got %d", count)
+	}
+
+	// Test callback error is returned
+	errExpected := errors.New("callback failed")
+	err = database.Stream(ctx, "SELECT * FROM users", func(row map[string]any) error {
+		return errExpected
+	})
+	if !errors.Is(err, errExpected) {
+		t.Errorf("Expected error %v, got %v", errExpected, err)
+	}
+
+	// Test the rows are closed after an error, so the connection can be reused
+	_, err = database.Execute(ctx, "DELETE FROM users WHERE id = ?", 1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+func TestStreamStructs(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	err = createUserTableAndInserTesttData(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := database.Context(context.Background(), db)
+
+	users := []testUser{}
+	err = database.StreamStructs(ctx, "SELECT id, name, email FROM users ORDER BY id ASC", func(user testUser) error {
+		users = append(users, user)
+		if len(users) == 2 {
+			return database.ErrStopIteration
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(users) != 2 {
+		t.Fatalf("Expected 2 users, got %d", len(users))
+	}
+	if users[1].FullName != "Bob" {
+		t.Errorf("Expected name 'Bob', got '%v'", users[1].FullName)
+	}
+}
@@ -0,0 +1,279 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// structTagName is the struct tag used to map columns to struct fields
+const structTagName = "db"
+
+// structFieldsCache caches the column to field index mapping per struct type
+var structFieldsCache sync.Map // map[reflect.Type]map[string][]int
+
+// structFields returns the mapping of column names to field index paths
+// for the given struct type.
+//
+// Business logic:
+//   - the column name is taken from the `db` struct tag
+//   - if there is no tag, the snake_case of the field name is used
+//   - fields tagged with `db:"-"` and unexported fields are skipped
+//   - embedded structs without a tag are flattened into the parent
+func structFields(t reflect.Type) map[string][]int {
+	if cached, ok := structFieldsCache.Load(t); ok {
+		return cached.(map[string][]int)
+	}
+
+	fields := map[string][]int{}
+	collectStructFields(t, nil, fields)
+
+	structFieldsCache.Store(t, fields)
+
+	return fields
+}
+
+func collectStructFields(t reflect.Type, parentIndex []int, fields map[string][]int) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag, hasTag := field.Tag.Lookup(structTagName)
+		tag = strings.TrimSpace(strings.Split(tag, ",")[0])
+
+		if tag == "-" {
+			continue
+		}
+
+		index := append(append([]int{}, parentIndex...), i)
+
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+
+		if field.Anonymous && !hasTag && fieldType.Kind() == reflect.Struct {
+			// Unexported embedded pointers cannot be allocated
+			if !field.IsExported() && field.Type.Kind() == reflect.Pointer {
+				continue
+			}
+			collectStructFields(fieldType, index, fields)
+			continue
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		column := tag
+		if column == "" {
+			column = toSnakeCase(field.Name)
+		}
+
+		// Fields closer to the root take precedence over embedded ones
+		if existing, ok := fields[column]; ok && len(existing) <= len(index) {
+			continue
+		}
+
+		fields[column] = index
+	}
+}
+
+// toSnakeCase converts a Go field name to snake_case,
+// i.e. "UserID" becomes "user_id" and "HTTPServer" becomes "http_server".
+func toSnakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				prevLower := unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1])
+				nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+				if prevLower || (nextLower && unicode.IsUpper(runes[i-1])) {
+					b.WriteRune('_')
+				}
+			}
+			b.WriteRune(unicode.ToLower(r))
+			continue
+		}
+
+		b.WriteRune(r)
+	}
+
+	return b.String()
+}
+
+// fieldByIndexAlloc returns the field at the given index path,
+// allocating any nil embedded struct pointers along the way.
+func fieldByIndexAlloc(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+
+	return v
+}
+
+// structScanDestinations returns the scan destinations for the given columns
+// pointing to the fields of the struct value v.
+//
+// Columns without a matching field are scanned into a discarded value,
+// unless strict is true, in which case an error listing them is returned.
+func structScanDestinations(v reflect.Value, columns []string, strict bool) ([]any, error) {
+	fields := structFields(v.Type())
+	destinations := make([]any, len(columns))
+	unmatched := []string{}
+
+	for i, column := range columns {
+		index, ok := fields[column]
+
+		if !ok {
+			unmatched = append(unmatched, column)
+			destinations[i] = new(any)
+			continue
+		}
+
+		destinations[i] = scanDestination(fieldByIndexAlloc(v, index))
+	}
+
+	if strict && len(unmatched) > 0 {
+		return nil, errors.New("no matching struct fields for columns: " + strings.Join(unmatched, ", "))
+	}
+
+	return destinations, nil
+}
+
+// isMappableStruct checks if the type is a struct that should be mapped
+// field by field, as opposed to scanned as a single value, like time.Time
+// or types implementing sql.Scanner.
+func isMappableStruct(t reflect.Type) bool {
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+
+	if t == reflect.TypeFor[time.Time]() {
+		return false
+	}
+
+	return !reflect.PointerTo(t).Implements(reflect.TypeFor[sql.Scanner]())
+}
+
+// scanRow scans the current row into a value of type T.
+//
+// Mappable structs are scanned field by field, any other type
+// is scanned as a single value from a single column.
+func scanRow[T any](rows *sql.Rows, columns []string, strict bool) (T, error) {
+	var item T
+
+	err := scanRowInto(rows, reflect.ValueOf(&item).Elem(), columns, strict)
+
+	return item, err
+}
+
+// scanRowInto scans the current row into the addressable value v,
+// the same way as scanRow.
+func scanRowInto(rows *sql.Rows, v reflect.Value, columns []string, strict bool) error {
+	if !isMappableStruct(v.Type()) {
+		if len(columns) != 1 {
+			return errors.New("expected 1 column for type " + v.Type().String() + ", got " + strconv.Itoa(len(columns)))
+		}
+
+		return rows.Scan(scanDestination(v))
+	}
+
+	destinations, err := structScanDestinations(v, columns, strict)
+	if err != nil {
+		return err
+	}
+
+	return rows.Scan(destinations...)
+}
+
+// scanDestination returns the scan destination for the addressable value v.
+//
+// The time.Time, *time.Time and sql.NullTime values are scanned with
+// a timeScanner, as the drivers differ in how they return timestamps,
+// any other value is scanned by database/sql directly. Pointers and the
+// sql.Null* types are set to nil and invalid for NULL by database/sql.
+func scanDestination(v reflect.Value) any {
+	switch v.Type() {
+	case reflect.TypeFor[time.Time](), reflect.TypeFor[*time.Time](), reflect.TypeFor[sql.NullTime]():
+		return timeScanner{dest: v}
+	}
+
+	return v.Addr().Interface()
+}
+
+// timeScanner scans a timestamp into a time.Time, *time.Time or sql.NullTime,
+// accepting the timestamps returned as text (i.e. by SQLite, or by MySQL
+// without parseTime) in addition to time.Time.
+type timeScanner struct {
+	dest reflect.Value
+}
+
+// Scan implements sql.Scanner.
+func (s timeScanner) Scan(src any) error {
+	if src == nil {
+		if s.dest.Type() == reflect.TypeFor[time.Time]() {
+			return errors.New("converting NULL to time.Time is unsupported, use *time.Time or sql.NullTime")
+		}
+
+		s.dest.SetZero()
+		return nil
+	}
+
+	t, ok := AsTime(src)
+	if !ok {
+		return fmt.Errorf("converting %T to time.Time is unsupported", src)
+	}
+
+	switch s.dest.Type() {
+	case reflect.TypeFor[*time.Time]():
+		s.dest.Set(reflect.ValueOf(&t))
+	case reflect.TypeFor[sql.NullTime]():
+		s.dest.Set(reflect.ValueOf(sql.NullTime{Time: t, Valid: true}))
+	default:
+		s.dest.Set(reflect.ValueOf(t))
+	}
+
+	return nil
+}
+
+// scanRowsToStructs scans all the rows into a slice of structs of type T.
+func scanRowsToStructs[T any](rows *sql.Rows, strict bool) ([]T, error) {
+	if reflect.TypeFor[T]().Kind() != reflect.Struct {
+		return []T{}, errors.New("type " + reflect.TypeFor[T]().String() + " is not a struct")
+	}
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return []T{}, err
+	}
+
+	list := []T{}
+
+	for rows.Next() {
+		item, err := scanRow[T](rows, columns, strict)
+		if err != nil {
+			return []T{}, err
+		}
+
+		list = append(list, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return []T{}, err
+	}
+
+	return list, nil
+}
@@ -0,0 +1,132 @@
+package database
+
+import (
+	"database/sql"
+	"time"
+)
+
+// QueryTimeout works like Query, but applies the timeout to just this call,
+// without deriving the context manually, i.e. for a one-off slow query.
+//
+// The timeout also applies while reading the rows, after which they are
+// closed by the database/sql package. The context of the timeout is released
+// right away if the query fails, or when the timeout passes otherwise.
+//
+// Example usage:
+//
+//	rows, err := QueryTimeout(ctx, 30*time.Second, "SELECT * FROM events WHERE created_at > ?", since)
+//	if err != nil {
+//		return err
+//	}
+//	defer rows.Close()
+//
+// Parameters:
+// - ctx (QueryableContext): The context to use for the query execution.
+// - timeout (time.Duration): The timeout of the query.
+// - sqlStr (string): The SQL query to execute.
+// - args (any): Optional arguments to pass to the query.
+//
+// Returns:
+// - *sql.Rows: The rows of the query, which must be closed by the caller.
+// - error: An error if the query failed or timed out.
+func QueryTimeout(ctx QueryableContext, timeout time.Duration, sqlStr string, args ...any) (*sql.Rows, error) {
+	timeoutCtx, cancel := ctx.WithTimeout(timeout)
+
+	rows, err := Query(timeoutCtx, sqlStr, args...)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	// The rows are read within the timeout, so it is released after it
+	time.AfterFunc(timeout, cancel)
+
+	return rows, nil
+}
+
+// ExecuteTimeout works like Execute, but applies the timeout to just this call.
+//
+// Example usage:
+//
+//	result, err := ExecuteTimeout(ctx, 5*time.Second, "DELETE FROM sessions WHERE expires_at < ?", time.Now())
+//
+// Parameters:
+// - ctx (QueryableContext): The context to use for the query execution.
+// - timeout (time.Duration): The timeout of the statement.
+// - sqlStr (string): The SQL statement to execute.
+// - args (any): Optional arguments to pass to the statement.
+//
+// Returns:
+// - sql.Result: The result of the statement.
+// - error: An error if the statement failed or timed out.
+func ExecuteTimeout(ctx QueryableContext, timeout time.Duration, sqlStr string, args ...any) (sql.Result, error) {
+	timeoutCtx, cancel := ctx.WithTimeout(timeout)
+	defer cancel()
+
+	return Execute(timeoutCtx, sqlStr, args...)
+}
+
+// SelectToMapAnyTimeout works like SelectToMapAny, but applies the timeout
+// to just this call, including reading the rows.
+//
+// Example usage:
+//
+//	listMap, err := SelectToMapAnyTimeout(ctx, 10*time.Second, "SELECT * FROM reports")
+//
+// Parameters:
+// - ctx (QueryableContext): The context to use for the query execution.
+// - timeout (time.Duration): The timeout of the query.
+// - sqlStr (string): The SQL query to execute.
+// - args (any): Optional arguments to pass to the query.
+//
+// Returns:
+// - []map[string]any: A slice of maps containing the query results.
+// - error: An error if the query failed or timed out.
+func SelectToMapAnyTimeout(ctx QueryableContext, timeout time.Duration, sqlStr string, args ...any) ([]map[string]any, error) {
+	timeoutCtx, cancel := ctx.WithTimeout(timeout)
+	defer cancel()
+
+	return SelectToMapAny(timeoutCtx, sqlStr, args...)
+}
+
+// SelectToMapStringTimeout works like SelectToMapString, but applies the
+// timeout to just this call, including reading the rows.
+//
+// Parameters:
+// - ctx (QueryableContext): The context to use for the query execution.
+// - timeout (time.Duration): The timeout of the query.
+// - sqlStr (string): The SQL query to execute.
+// - args (any): Optional arguments to pass to the query.
+//
+// Returns:
+// - []map[string]string: A slice of maps containing the query results.
+// - error: An error if the query failed or timed out.
+func SelectToMapStringTimeout(ctx QueryableContext, timeout time.Duration, sqlStr string, args ...any) ([]map[string]string, error) {
+	timeoutCtx, cancel := ctx.WithTimeout(timeout)
+	defer cancel()
+
+	return SelectToMapString(timeoutCtx, sqlStr, args...)
+}
+
+// SelectToStructsTimeout works like SelectToStructs, but applies the
+// timeout to just this call, including reading the rows.
+//
+// Example usage:
+//
+//	users, err := SelectToStructsTimeout[User](ctx, 10*time.Second, "SELECT * FROM users")
+//
+// Parameters:
+// - ctx (QueryableContext): The context to use for the query execution.
+// - timeout (time.Duration): The timeout of the query.
+// - sqlStr (string): The SQL query to execute.
+// - args (any): Optional arguments to pass to the query.
+//
+// Returns:
+// - []T: A slice of structs containing the query results.
+// - error: An error if the query failed or timed out.
+func SelectToStructsTimeout[T any](ctx QueryableContext, timeout time.Duration, sqlStr string, args ...any) ([]T, error) {
+	timeoutCtx, cancel := ctx.WithTimeout(timeout)
+	defer cancel()
+
+	return SelectToStructs[T](timeoutCtx, sqlStr, args...)
+}
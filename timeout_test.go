@@ -0,0 +1,71 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	database "github.com/dracory/database"
+)
+
+func TestTimeoutHelpers(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	if err := createUserTableAndInserTesttData(db); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := database.Context(context.Background(), db)
+
+	rows, err := database.QueryTimeout(ctx, time.Second, "SELECT id FROM users")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	count := 0
+	for rows.Next() {
+		count++
+	}
+	rows.Close()
+
+	if count != 3 {
+		t.Errorf("Expected 3 rows, got %d", count)
+	}
+
+	if _, err := database.ExecuteTimeout(ctx, time.Second, "UPDATE users SET name = ? WHERE id = ?", "Updated", 1); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	users, err := database.SelectToStructsTimeout[testUser](ctx, time.Second, "SELECT * FROM users ORDER BY id")
+	if err != nil || len(users) != 3 || users[0].FullName != "Updated" {
+		t.Errorf("Unexpected users %v and error %v", users, err)
+	}
+
+	if _, err := database.SelectToMapStringTimeout(ctx, time.Second, "SELECT * FROM users"); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	// The timeout applies to the call only, not to the context
+	start := time.Now()
+
+	if _, err := database.SelectToMapAnyTimeout(ctx, 50*time.Millisecond, longRunningSQL); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Expected the query to be aborted, it took %v", elapsed)
+	}
+
+	if ctx.Err() != nil {
+		t.Errorf("Expected the context not to be canceled, got %v", ctx.Err())
+	}
+
+	if _, err := database.ExecuteTimeout(ctx, 50*time.Millisecond, "CREATE TABLE numbers AS "+longRunningSQL); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded, got %v", err)
+	}
+}
@@ -0,0 +1,102 @@
+// Package tracing adds OpenTelemetry tracing to the statements executed
+// through the github.com/dracory/database helpers.
+//
+// It is kept in a separate module, so that the database module itself,
+// and the projects which do not import this package, do not depend on OpenTelemetry.
+//
+// The package is imported like this:
+//
+//	import "github.com/dracory/database/tracing"
+package tracing
+
+import (
+	"context"
+
+	"github.com/dracory/database"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName is the name of the tracer
+const instrumentationName = "github.com/dracory/database"
+
+// SetTracerProvider enables tracing of the statements executed through the
+// database helpers, i.e. Query, Execute and SelectToMapAny, using a tracer
+// from the given provider.
+//
+// Each statement is recorded as a span named after the helper, with the
+// db.system and db.statement attributes. The span status is set to error
+// if the statement fails.
+//
+// Setting it to nil disables the tracing.
+//
+// Example:
+//
+//	tracing.SetTracerProvider(otel.GetTracerProvider())
+//
+// Parameters:
+// - provider: The tracer provider, or nil.
+func SetTracerProvider(provider trace.TracerProvider) {
+	if provider == nil {
+		database.SetQueryHook(nil)
+		return
+	}
+
+	database.SetQueryHook(&tracingHook{tracer: provider.Tracer(instrumentationName)})
+}
+
+// tracingHook implements database.QueryHook, starting a span before
+// each statement and ending it after
+type tracingHook struct {
+	tracer trace.Tracer
+}
+
+var _ database.QueryHook = (*tracingHook)(nil)
+
+func (h *tracingHook) BeforeQuery(ctx context.Context, info database.QueryLog) context.Context {
+	ctx, _ = h.tracer.Start(ctx, info.Operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system", dbSystem(info.Dialect)),
+			attribute.String("db.statement", info.SQL),
+		),
+	)
+
+	return ctx
+}
+
+func (h *tracingHook) AfterQuery(ctx context.Context, info database.QueryLog) {
+	span := trace.SpanFromContext(ctx)
+
+	if info.RowsAffected >= 0 {
+		span.SetAttributes(attribute.Int64("db.rows_affected", info.RowsAffected))
+	}
+
+	if info.RowsReturned >= 0 {
+		span.SetAttributes(attribute.Int64("db.rows_returned", info.RowsReturned))
+	}
+
+	if info.Error != nil {
+		span.RecordError(info.Error)
+		span.SetStatus(codes.Error, info.Error.Error())
+	}
+
+	span.End()
+}
+
+// dbSystem maps the database type to the OpenTelemetry db.system value
+func dbSystem(dialect string) string {
+	switch dialect {
+	case database.DATABASE_TYPE_POSTGRES:
+		return "postgresql"
+	case database.DATABASE_TYPE_MYSQL:
+		return "mysql"
+	case database.DATABASE_TYPE_SQLITE:
+		return "sqlite"
+	case database.DATABASE_TYPE_MSSQL:
+		return "mssql"
+	}
+
+	return "other_sql"
+}
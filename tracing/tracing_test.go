@@ -0,0 +1,76 @@
+package tracing_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/dracory/database"
+	"github.com/dracory/database/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	_ "modernc.org/sqlite"
+)
+
+func TestSetTracerProvider(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	recorder := tracetest.NewSpanRecorder()
+	tracing.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer tracing.SetTracerProvider(nil)
+
+	ctx := database.Context(context.Background(), db)
+
+	_, err = database.Execute(ctx, "CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT)")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	_, err = database.SelectToMapAny(ctx, "INVALID SQL")
+	if err == nil {
+		t.Fatal("Expected error for invalid SQL")
+	}
+
+	spans := recorder.Ended()
+
+	if len(spans) != 2 {
+		t.Fatalf("Expected 2 spans, got %d", len(spans))
+	}
+
+	if spans[0].Name() != "Execute" {
+		t.Errorf("Expected span name 'Execute', got %q", spans[0].Name())
+	}
+
+	attributes := attribute.NewSet(spans[0].Attributes()...)
+
+	if system, _ := attributes.Value("db.system"); system.AsString() != "sqlite" {
+		t.Errorf("Expected db.system 'sqlite', got %q", system.AsString())
+	}
+
+	if statement, _ := attributes.Value("db.statement"); statement.AsString() != "CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT)" {
+		t.Errorf("Unexpected db.statement %q", statement.AsString())
+	}
+
+	if spans[1].Name() != "SelectToMapAny" || spans[1].Status().Code != codes.Error {
+		t.Errorf("Expected failed SelectToMapAny span, got %q with status %v", spans[1].Name(), spans[1].Status().Code)
+	}
+
+	// Test tracing can be disabled
+	tracing.SetTracerProvider(nil)
+
+	_, err = database.Execute(ctx, "DELETE FROM users")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(recorder.Ended()) != 2 {
+		t.Errorf("Expected no more spans after disabling, got %d", len(recorder.Ended()))
+	}
+}
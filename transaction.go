@@ -0,0 +1,181 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strconv"
+	"sync/atomic"
+)
+
+// savepointCounter is used to generate unique savepoint names
+var savepointCounter atomic.Uint64
+
+// Transaction executes the given function within a database transaction.
+//
+// It begins a new transaction on the database, wraps it in a QueryableContext
+// and passes it to fn. If fn returns nil, the transaction is committed.
+// If fn returns an error, the transaction is rolled back and the error is returned.
+// If fn panics, the transaction is rolled back and the panic is re-raised.
+//
+// If ctx is a QueryableContext that already carries a transaction, no new
+// transaction is started. Instead a SAVEPOINT is created on the existing
+// transaction, and it is released on success or rolled back to on failure.
+// This allows nesting Transaction calls, where only the outermost call
+// commits the transaction.
+//
+// Example usage:
+//
+//	err := database.Transaction(context.Background(), db, func(txCtx database.QueryableContext) error {
+//		_, err := database.Execute(txCtx, "INSERT INTO users (name) VALUES (?)", "John Doe")
+//		return err
+//	})
+//
+// Parameters:
+// - ctx (context.Context): The parent context for the transaction.
+// - db (*sql.DB): The database to begin the transaction on.
+// - fn (func(QueryableContext) error): The function to execute within the transaction.
+//
+// Returns:
+// - error: An error if the transaction could not be started, fn failed, or the commit failed.
+func Transaction(ctx context.Context, db *sql.DB, fn func(txCtx QueryableContext) error) error {
+	return TransactionWithOptions(ctx, db, nil, fn)
+}
+
+// TransactionWithOptions executes the given function within a database
+// transaction started with the given options, i.e. isolation level and
+// read-only mode. Otherwise it behaves exactly like Transaction.
+//
+// When called with a context that already carries a transaction, a savepoint
+// is used and the options are ignored, as savepoints inherit the options
+// of the enclosing transaction.
+//
+// Example usage:
+//
+//	opts := &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true}
+//	err := database.TransactionWithOptions(ctx, db, opts, func(txCtx database.QueryableContext) error {
+//		report, err = database.SelectToMapString(txCtx, "SELECT * FROM orders")
+//		return err
+//	})
+//
+// Parameters:
+// - ctx (context.Context): The parent context for the transaction.
+// - db (*sql.DB): The database to begin the transaction on.
+// - opts (*sql.TxOptions): The transaction options, may be nil for the driver defaults.
+// - fn (func(QueryableContext) error): The function to execute within the transaction.
+//
+// Returns:
+// - error: An error if the transaction could not be started, fn failed, or the commit failed.
+func TransactionWithOptions(ctx context.Context, db *sql.DB, opts *sql.TxOptions, fn func(txCtx QueryableContext) error) error {
+	if fn == nil {
+		return errors.New("transaction function is nil")
+	}
+
+	// Nested call, use a savepoint on the existing transaction
+	if qCtx, ok := ctx.(QueryableContext); ok && qCtx.IsTx() {
+		return savepointTransaction(qCtx, fn)
+	}
+
+	if db == nil {
+		return errors.New("db is nil")
+	}
+
+	tx, err := db.BeginTx(ctx, opts)
+
+	if err != nil {
+		return err
+	}
+
+	var txCtx QueryableContext
+
+	if qCtx, ok := ctx.(QueryableContext); ok {
+		txCtx = qCtx.WithQueryable(tx)
+	} else {
+		txCtx = NewQueryableContext(ctx, tx)
+	}
+
+	// Roll back and re-panic if fn panics
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(txCtx); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			return errors.Join(err, rollbackErr)
+		}
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// savepointTransaction executes the given function within a savepoint
+// on the transaction carried by the context.
+func savepointTransaction(ctx QueryableContext, fn func(txCtx QueryableContext) error) error {
+	databaseType := ctx.databaseType()
+	name := "sp_" + strconv.Itoa(ctx.txDepth+1) + "_" + strconv.FormatUint(savepointCounter.Add(1), 10)
+
+	_, err := ctx.queryable.ExecContext(ctx, savepointSQL(databaseType, name))
+
+	if err != nil {
+		return err
+	}
+
+	spCtx := ctx
+	spCtx.txDepth++
+
+	// Roll back to the savepoint and re-panic if fn panics
+	defer func() {
+		if p := recover(); p != nil {
+			_, _ = ctx.queryable.ExecContext(ctx, rollbackToSavepointSQL(databaseType, name))
+			panic(p)
+		}
+	}()
+
+	if err := fn(spCtx); err != nil {
+		_, rollbackErr := ctx.queryable.ExecContext(ctx, rollbackToSavepointSQL(databaseType, name))
+		if rollbackErr != nil {
+			return errors.Join(err, rollbackErr)
+		}
+		return err
+	}
+
+	releaseSQL := releaseSavepointSQL(databaseType, name)
+
+	if releaseSQL == "" {
+		return nil
+	}
+
+	_, err = ctx.queryable.ExecContext(ctx, releaseSQL)
+
+	return err
+}
+
+func savepointSQL(databaseType string, name string) string {
+	if databaseType == DATABASE_TYPE_MSSQL {
+		return "SAVE TRANSACTION " + name
+	}
+
+	return "SAVEPOINT " + name
+}
+
+func rollbackToSavepointSQL(databaseType string, name string) string {
+	if databaseType == DATABASE_TYPE_MSSQL {
+		return "ROLLBACK TRANSACTION " + name
+	}
+
+	return "ROLLBACK TO SAVEPOINT " + name
+}
+
+// releaseSavepointSQL returns an empty string for MSSQL,
+// which does not support releasing savepoints
+func releaseSavepointSQL(databaseType string, name string) string {
+	if databaseType == DATABASE_TYPE_MSSQL {
+		return ""
+	}
+
+	return "RELEASE SAVEPOINT " + name
+}
@@ -0,0 +1,148 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// retryBaseDelay is the delay before the first retry, doubled on each subsequent retry
+const retryBaseDelay = 50 * time.Millisecond
+
+// TransactionWithRetry executes the given function within a database transaction,
+// retrying the whole transaction when it fails with a retryable error.
+//
+// Retryable errors are deadlocks and serialization failures:
+//   - Postgres: SQLSTATE 40001 (serialization_failure), 40P01 (deadlock_detected)
+//   - MySQL: 1213 (deadlock found), 1205 (lock wait timeout exceeded)
+//
+// The detection is dialect-aware, based on DatabaseType(db). Non-retryable
+// errors are returned immediately. Between attempts the function waits
+// with exponential backoff, starting at 50ms.
+//
+// If ctx already carries a transaction, the function is executed once within
+// a savepoint (see Transaction), as only the outermost transaction can be retried.
+//
+// Example usage:
+//
+//	err := database.TransactionWithRetry(ctx, db, 3, func(txCtx database.QueryableContext) error {
+//		_, err := database.Execute(txCtx, "UPDATE accounts SET balance = balance - ? WHERE id = ?", 100, 1)
+//		return err
+//	})
+//
+// Parameters:
+// - ctx (context.Context): The parent context for the transaction.
+// - db (*sql.DB): The database to begin the transaction on.
+// - maxAttempts (int): The maximum number of times to run the transaction, must be at least 1.
+// - fn (func(QueryableContext) error): The function to execute within the transaction.
+//
+// Returns:
+// - error: The error of the last attempt, or nil on success.
+func TransactionWithRetry(ctx context.Context, db *sql.DB, maxAttempts int, fn func(txCtx QueryableContext) error) error {
+	if maxAttempts < 1 {
+		return errors.New("max attempts must be at least 1")
+	}
+
+	if qCtx, ok := ctx.(QueryableContext); ok && qCtx.IsTx() {
+		return Transaction(ctx, db, fn)
+	}
+
+	if db == nil {
+		return errors.New("db is nil")
+	}
+
+	databaseType := DatabaseType(db)
+	delay := retryBaseDelay
+
+	var err error
+
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		err = Transaction(ctx, db, fn)
+
+		if err == nil || !isRetryableError(databaseType, err) || attempt == maxAttempts {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.Join(err, ctx.Err())
+		case <-time.After(delay):
+		}
+
+		delay *= 2
+	}
+
+	return err
+}
+
+// isRetryableError checks if the error is a deadlock or serialization
+// failure for the given database type.
+func isRetryableError(databaseType string, err error) bool {
+	if err == nil {
+		return false
+	}
+
+	switch databaseType {
+	case DATABASE_TYPE_POSTGRES:
+		state := errorSQLState(err)
+		return state == "40001" || state == "40P01"
+	case DATABASE_TYPE_MYSQL:
+		number := errorNumber(err)
+		return number == 1213 || number == 1205
+	}
+
+	return false
+}
+
+// errorSQLState returns the SQLSTATE code of a driver error, or an empty string.
+//
+// Both lib/pq and pgx errors expose the code through a SQLState() method.
+func errorSQLState(err error) string {
+	var stateErr interface{ SQLState() string }
+
+	if errors.As(err, &stateErr) {
+		return stateErr.SQLState()
+	}
+
+	return ""
+}
+
+// errorNumber returns the vendor error number of a driver error, or zero.
+//
+// The go-sql-driver/mysql error exposes the number through a public Number field.
+// Reflection is used so that the driver does not need to be imported.
+func errorNumber(err error) int {
+	if err == nil {
+		return 0
+	}
+
+	v := reflect.ValueOf(err)
+
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+
+	if v.Kind() == reflect.Struct && strings.Contains(v.Type().String(), DATABASE_TYPE_MYSQL) {
+		number := v.FieldByName("Number")
+
+		if number.IsValid() && number.CanUint() {
+			return int(number.Uint())
+		}
+	}
+
+	switch e := err.(type) {
+	case interface{ Unwrap() error }:
+		return errorNumber(e.Unwrap())
+	case interface{ Unwrap() []error }:
+		for _, inner := range e.Unwrap() {
+			if number := errorNumber(inner); number != 0 {
+				return number
+			}
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,58 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	database "github.com/dracory/database"
+)
+
+func TestTransactionWithRetry(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	err = createUserTableAndInserTesttData(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Test invalid max attempts
+	err = database.TransactionWithRetry(context.Background(), db, 0, func(txCtx database.QueryableContext) error {
+		return nil
+	})
+	if err == nil {
+		t.Error("Expected error for zero max attempts")
+	}
+
+	// Test successful transaction
+	attempts := 0
+	err = database.TransactionWithRetry(context.Background(), db, 3, func(txCtx database.QueryableContext) error {
+		attempts++
+		_, err := database.Execute(txCtx, "INSERT INTO users (name, email) VALUES (?, ?)", "Dave", "dave@example.com")
+		return err
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if attempts != 1 {
+		t.Errorf("Expected 1 attempt, got %d", attempts)
+	}
+
+	// Test non-retryable error is returned immediately
+	errExpected := errors.New("not retryable")
+	attempts = 0
+	err = database.TransactionWithRetry(context.Background(), db, 3, func(txCtx database.QueryableContext) error {
+		attempts++
+		return errExpected
+	})
+	if !errors.Is(err, errExpected) {
+		t.Fatalf("Expected error %v, got %v", errExpected, err)
+	}
+	if attempts != 1 {
+		t.Errorf("Expected 1 attempt, got %d", attempts)
+	}
+}
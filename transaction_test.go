@@ -3,6 +3,7 @@ package database_test
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"testing"
 
 	database "github.com/dracory/database"
@@ -160,3 +161,206 @@ func TestTransactionRollback(t *testing.T) {
 		t.Errorf("Expected 0 rows after rollback, got %d", count)
 	}
 }
+
+func TestTransactionCommit(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	err = createUserTableAndInserTesttData(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = database.Transaction(context.Background(), db, func(txCtx database.QueryableContext) error {
+		if !txCtx.IsTx() {
+			t.Error("Expected transaction context")
+		}
+		_, err := database.Execute(txCtx, "INSERT INTO users (name, email) VALUES (?, ?)", "Dave", "dave@example.com")
+		return err
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var count int
+	err = db.QueryRow("SELECT COUNT(*) FROM users").Scan(&count)
+	if err != nil {
+		t.Fatalf("Failed to count rows: %v", err)
+	}
+
+	if count != 4 {
+		t.Errorf("Expected 4 rows after commit, got %d", count)
+	}
+}
+
+func TestTransactionRollbackOnError(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	err = createUserTableAndInserTesttData(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	errExpected := errors.New("something went wrong")
+
+	err = database.Transaction(context.Background(), db, func(txCtx database.QueryableContext) error {
+		_, err := database.Execute(txCtx, "INSERT INTO users (name, email) VALUES (?, ?)", "Dave", "dave@example.com")
+		if err != nil {
+			return err
+		}
+		return errExpected
+	})
+	if !errors.Is(err, errExpected) {
+		t.Fatalf("Expected error %v, got %v", errExpected, err)
+	}
+
+	var count int
+	err = db.QueryRow("SELECT COUNT(*) FROM users").Scan(&count)
+	if err != nil {
+		t.Fatalf("Failed to count rows: %v", err)
+	}
+
+	if count != 3 {
+		t.Errorf("Expected 3 rows after rollback, got %d", count)
+	}
+}
+
+func TestTransactionRollbackOnPanic(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	err = createUserTableAndInserTesttData(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	func() {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("Expected panic to be re-raised")
+			}
+		}()
+
+		_ = database.Transaction(context.Background(), db, func(txCtx database.QueryableContext) error {
+			_, err := database.Execute(txCtx, "INSERT INTO users (name, email) VALUES (?, ?)", "Dave", "dave@example.com")
+			if err != nil {
+				return err
+			}
+			panic("boom")
+		})
+	}()
+
+	var count int
+	err = db.QueryRow("SELECT COUNT(*) FROM users").Scan(&count)
+	if err != nil {
+		t.Fatalf("Failed to count rows: %v", err)
+	}
+
+	if count != 3 {
+		t.Errorf("Expected 3 rows after panic, got %d", count)
+	}
+}
+
+func TestTransactionNilDB(t *testing.T) {
+	err := database.Transaction(context.Background(), nil, func(txCtx database.QueryableContext) error {
+		return nil
+	})
+	if err == nil {
+		t.Fatal("Expected error for nil db")
+	}
+}
+
+func TestTransactionNestedSavepoint(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	err = createUserTableAndInserTesttData(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	errInner := errors.New("inner failed")
+
+	err = database.Transaction(context.Background(), db, func(txCtx database.QueryableContext) error {
+		_, err := database.Execute(txCtx, "INSERT INTO users (name, email) VALUES (?, ?)", "Dave", "dave@example.com")
+		if err != nil {
+			return err
+		}
+
+		// Inner transaction fails, only its changes are rolled back
+		err = database.Transaction(txCtx, db, func(spCtx database.QueryableContext) error {
+			_, err := database.Execute(spCtx, "INSERT INTO users (name, email) VALUES (?, ?)", "Eve", "eve@example.com")
+			if err != nil {
+				return err
+			}
+			return errInner
+		})
+		if !errors.Is(err, errInner) {
+			t.Errorf("Expected error %v, got %v", errInner, err)
+		}
+
+		// Inner transaction succeeds, its changes are kept
+		return database.Transaction(txCtx, db, func(spCtx database.QueryableContext) error {
+			_, err := database.Execute(spCtx, "INSERT INTO users (name, email) VALUES (?, ?)", "Frank", "frank@example.com")
+			return err
+		})
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	names, err := database.SelectToMapString(database.Context(context.Background(), db), "SELECT name FROM users ORDER BY id ASC")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(names) != 5 {
+		t.Fatalf("Expected 5 rows, got %d", len(names))
+	}
+
+	if names[3]["name"] != "Dave" || names[4]["name"] != "Frank" {
+		t.Errorf("Expected Dave and Frank to be committed, got %v", names)
+	}
+}
+
+func TestTransactionWithOptionsReadOnly(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	err = createUserTableAndInserTesttData(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	opts := &sql.TxOptions{Isolation: sql.LevelSerializable, ReadOnly: true}
+
+	var rows []map[string]string
+	err = database.TransactionWithOptions(context.Background(), db, opts, func(txCtx database.QueryableContext) error {
+		var err error
+		rows, err = database.SelectToMapString(txCtx, "SELECT * FROM users")
+		return err
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(rows) != 3 {
+		t.Errorf("Expected 3 rows, got %d", len(rows))
+	}
+}
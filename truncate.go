@@ -0,0 +1,170 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"strings"
+)
+
+// Truncate deletes all the rows of the given tables, or of all the tables
+// of the database (see Tables) if none are given. It is mainly meant for
+// resetting the database between integration tests.
+//
+// The rows are deleted as follows, based on DatabaseType:
+//   - SQLite: DELETE FROM each table, with the foreign key checks disabled,
+//     and the AUTOINCREMENT counters reset in sqlite_sequence
+//   - MySQL: TRUNCATE TABLE each table, with the foreign key checks disabled
+//   - Postgres: a single TRUNCATE TABLE ... RESTART IDENTITY CASCADE, which
+//     also truncates the tables referencing the given ones
+//
+// The foreign key checks are disabled for the session only, and restored
+// to their previous setting afterwards. When the context carries a DB,
+// a single connection is used for the whole operation.
+//
+// Note that SQLite ignores disabling the foreign key checks inside
+// a transaction, and MySQL commits the current transaction on TRUNCATE.
+//
+// Example usage:
+//
+//	err := Truncate(ctx, "posts", "users")
+//	err := Truncate(ctx) // all tables
+//
+// Parameters:
+// - ctx (QueryableContext): The context to use for the query execution.
+// - tables (string): The names of the tables, or none for all tables.
+//
+// Returns:
+// - error: An error if the database type is not supported, or a statement failed.
+func Truncate(ctx QueryableContext, tables ...string) error {
+	if ctx.queryable == nil {
+		return ErrNoQueryable
+	}
+
+	dbType := ctx.databaseType()
+
+	if dbType != DATABASE_TYPE_SQLITE && dbType != DATABASE_TYPE_MYSQL && dbType != DATABASE_TYPE_POSTGRES {
+		return errors.New("truncate is not supported for database type: " + dbType)
+	}
+
+	return withSingleConn(ctx, func(ctx QueryableContext) error {
+		if len(tables) == 0 {
+			var err error
+			if tables, err = Tables(ctx); err != nil {
+				return err
+			}
+		}
+
+		if len(tables) == 0 {
+			return nil
+		}
+
+		quotedTables, err := quoteIdentifiers(dbType, tables)
+		if err != nil {
+			return err
+		}
+
+		switch dbType {
+		case DATABASE_TYPE_SQLITE:
+			return truncateSqlite(ctx, tables, quotedTables)
+		case DATABASE_TYPE_MYSQL:
+			return truncateMysql(ctx, quotedTables)
+		}
+
+		_, err = ctx.execContext("Truncate", "TRUNCATE TABLE "+strings.Join(quotedTables, ", ")+" RESTART IDENTITY CASCADE")
+		return err
+	})
+}
+
+// truncateSqlite deletes the rows of the tables with the foreign key checks
+// disabled, and resets their AUTOINCREMENT counters
+func truncateSqlite(ctx QueryableContext, tables []string, quotedTables []string) (err error) {
+	var foreignKeys int
+
+	row, run := ctx.queryRowContext("Truncate", "PRAGMA foreign_keys")
+	if err := row.Scan(&foreignKeys); err != nil {
+		run.end(-1, err)
+		return err
+	}
+	run.end(1, nil)
+
+	if foreignKeys == 1 {
+		if _, err := ctx.execContext("Truncate", "PRAGMA foreign_keys = OFF"); err != nil {
+			return err
+		}
+
+		defer func() {
+			_, restoreErr := ctx.execContext("Truncate", "PRAGMA foreign_keys = ON")
+			err = errors.Join(err, restoreErr)
+		}()
+	}
+
+	for _, quotedTable := range quotedTables {
+		if _, err := ctx.execContext("Truncate", "DELETE FROM "+quotedTable); err != nil {
+			return err
+		}
+	}
+
+	// The sqlite_sequence table only exists once an AUTOINCREMENT table is created
+	hasSequence, err := Exists(ctx, "SELECT 1 FROM sqlite_master WHERE type = 'table' AND name = 'sqlite_sequence'")
+	if err != nil || !hasSequence {
+		return err
+	}
+
+	for _, table := range tables {
+		if _, err := ctx.execContext("Truncate", "DELETE FROM sqlite_sequence WHERE name = ?", table); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// truncateMysql truncates the tables with the foreign key checks disabled
+func truncateMysql(ctx QueryableContext, quotedTables []string) (err error) {
+	var foreignKeyChecks int
+
+	row, run := ctx.queryRowContext("Truncate", "SELECT @@FOREIGN_KEY_CHECKS")
+	if err := row.Scan(&foreignKeyChecks); err != nil {
+		run.end(-1, err)
+		return err
+	}
+	run.end(1, nil)
+
+	if foreignKeyChecks == 1 {
+		if _, err := ctx.execContext("Truncate", "SET FOREIGN_KEY_CHECKS = 0"); err != nil {
+			return err
+		}
+
+		defer func() {
+			_, restoreErr := ctx.execContext("Truncate", "SET FOREIGN_KEY_CHECKS = 1")
+			err = errors.Join(err, restoreErr)
+		}()
+	}
+
+	for _, quotedTable := range quotedTables {
+		if _, err := ctx.execContext("Truncate", "TRUNCATE TABLE "+quotedTable); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// withSingleConn calls fn with a context carrying a single connection of the
+// database, so that session settings apply to all the statements of fn.
+// If the context carries a Tx or a Conn, it is used as it is.
+func withSingleConn(ctx QueryableContext, fn func(ctx QueryableContext) error) error {
+	db, isDB := ctx.queryable.(*sql.DB)
+
+	if !isDB {
+		return fn(ctx)
+	}
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	return fn(ctx.WithQueryable(conn))
+}
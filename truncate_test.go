@@ -0,0 +1,95 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	database "github.com/dracory/database"
+)
+
+func TestTruncate(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	// Test nil querier error
+	err = database.Truncate(database.Context(context.Background(), nil))
+	if err == nil || err.Error() != "querier (db/tx/conn) is nil" {
+		t.Errorf("Expected nil querier error, got %v", err)
+	}
+
+	ctx := database.Context(context.Background(), db)
+
+	statements := []string{
+		"PRAGMA foreign_keys = ON",
+		"CREATE TABLE authors (id INTEGER PRIMARY KEY AUTOINCREMENT, name TEXT)",
+		"CREATE TABLE books (id INTEGER PRIMARY KEY AUTOINCREMENT, author_id INTEGER REFERENCES authors(id), title TEXT)",
+		"CREATE TABLE tags (id INTEGER PRIMARY KEY, name TEXT)",
+		"INSERT INTO authors (name) VALUES ('Alice'), ('Bob')",
+		"INSERT INTO books (author_id, title) VALUES (1, 'First'), (2, 'Second')",
+		"INSERT INTO tags (name) VALUES ('go')",
+	}
+
+	for _, statement := range statements {
+		if _, err := database.Execute(ctx, statement); err != nil {
+			t.Fatalf("Failed to execute %q: %v", statement, err)
+		}
+	}
+
+	// Test the given tables are truncated, even if referenced by a foreign key
+	err = database.Truncate(ctx, "authors", "books")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for table, expected := range map[string]int64{"authors": 0, "books": 0, "tags": 1} {
+		count, err := database.Count(ctx, "SELECT COUNT(*) FROM "+table)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if count != expected {
+			t.Errorf("Expected %d rows in %s, got %d", expected, table, count)
+		}
+	}
+
+	// Test the AUTOINCREMENT counter is reset
+	_, err = database.Execute(ctx, "INSERT INTO authors (name) VALUES ('Charlie')")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	id, _, err := database.SelectOne[int64](ctx, "SELECT id FROM authors")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if id != 1 {
+		t.Errorf("Expected id 1 after truncate, got %d", id)
+	}
+
+	// Test the foreign key checks are restored
+	foreignKeys, _, err := database.SelectOne[int64](ctx, "PRAGMA foreign_keys")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if foreignKeys != 1 {
+		t.Errorf("Expected foreign keys to be enabled again, got %d", foreignKeys)
+	}
+
+	// Test all tables are truncated
+	err = database.Truncate(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for _, table := range []string{"authors", "books", "tags"} {
+		count, err := database.Count(ctx, "SELECT COUNT(*) FROM "+table)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if count != 0 {
+			t.Errorf("Expected no rows in %s, got %d", table, count)
+		}
+	}
+}
@@ -3,6 +3,8 @@ package database
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"time"
 )
 
 // NewQueryableContext returns a new context with the given QueryableInterface.
@@ -10,7 +12,12 @@ import (
 // Note: For convenience, a shortcut alias function 'Context' is provided in funcs.go
 // that calls this function with the same parameters.
 func NewQueryableContext(ctx context.Context, queryable QueryableInterface) QueryableContext {
-	return QueryableContext{Context: ctx, queryable: queryable}
+	// A NullQueryable is the same as no queryable
+	if _, ok := queryable.(NullQueryable); ok {
+		queryable = nil
+	}
+
+	return QueryableContext{Context: ctx, queryable: queryable, lastQuery: newLastQuery()}
 }
 
 // NewQueryableContextOr returns the existing QueryableContext if the provided context
@@ -18,12 +25,41 @@ func NewQueryableContext(ctx context.Context, queryable QueryableInterface) Quer
 //
 // Note: For convenience, a shortcut alias function 'ContextOr' is provided in funcs.go
 // that calls this function with the same parameters.
+//
+// If the context was derived from a QueryableContext with the standard library
+// (i.e. context.WithValue), the queryable and options of the QueryableContext
+// are recovered, and the derived context is kept as the embedded context.
+//
+// If several QueryableContexts are nested, the closest one to ctx takes
+// precedence, the same way as context values, even if it has no queryable.
+// The QueryableContexts are found by an unexported context key, so a
+// QueryableContext stored as a value under another key is not found.
 func NewQueryableContextOr(ctx context.Context, queryable QueryableInterface) QueryableContext {
 	if qCtx, ok := ctx.(QueryableContext); ok {
 		return qCtx
 	}
 
-	return QueryableContext{Context: ctx, queryable: queryable}
+	if qCtx, ok := derivedQueryableContext(ctx); ok {
+		qCtx.Context = ctx
+		return qCtx
+	}
+
+	return NewQueryableContext(ctx, queryable)
+}
+
+// queryableContextKey is the key, for which a QueryableContext returns
+// itself from Value, so that it can be found from derived contexts
+type queryableContextKey struct{}
+
+// derivedQueryableContext finds the QueryableContext a context was derived from
+func derivedQueryableContext(ctx context.Context) (QueryableContext, bool) {
+	if ctx == nil {
+		return QueryableContext{}, false
+	}
+
+	qCtx, ok := ctx.Value(queryableContextKey{}).(QueryableContext)
+
+	return qCtx, ok
 }
 
 // Verify that QueryableContext implements the context.Context interface.
@@ -31,9 +67,40 @@ var _ context.Context = QueryableContext{}
 
 // QueryableContext extends the context.Context interface with a queryable field.
 // The queryable field may be of type *sql.DB, *sql.Conn, or *sql.Tx.
+//
+// Note that deriving a context with the standard library (i.e. context.WithValue,
+// context.WithTimeout) returns a plain context.Context, which is not a
+// QueryableContext. Use the WithValue, WithTimeout and WithDeadline methods
+// instead, to keep the queryable.
 type QueryableContext struct {
 	context.Context
 	queryable QueryableInterface
+
+	// txDepth is the savepoint nesting depth within a transaction,
+	// zero for the outermost transaction
+	txDepth int
+
+	// rebind enables rewriting ? placeholders for the database type
+	rebind bool
+
+	// lastQuery captures the last executed statement in debug mode,
+	// nil if the debug mode was disabled when the context was created
+	lastQuery *lastQuery
+
+	// dryRun skips the statements that write, see WithDryRun
+	dryRun bool
+
+	// excludeDeleted excludes the soft deleted rows in the builders,
+	// see WithExcludeDeleted
+	excludeDeleted bool
+
+	// maxRows limits the rows read by SelectToMapAny and the like,
+	// zero for no limit, see WithMaxRows
+	maxRows int
+
+	// dialect overrides the database type detected from the queryable,
+	// see WithDialect
+	dialect string
 }
 
 func (ctx QueryableContext) IsDB() bool {
@@ -74,6 +141,262 @@ func (ctx QueryableContext) IsTx() bool {
 	return ok
 }
 
+// Queryable returns the queryable (DB, Tx or Conn) carried by the context,
+// or a NullQueryable if there is none, whose methods return ErrNoQueryable.
 func (ctx QueryableContext) Queryable() QueryableInterface {
+	if ctx.queryable == nil {
+		return NullQueryable{}
+	}
+
 	return ctx.queryable
 }
+
+// WithRebind returns a copy of the context, which rewrites the ? placeholders
+// of the queries into the placeholder style of the database type (see Rebind),
+// before executing them.
+//
+// Example:
+//
+//	qCtx := database.Context(ctx, postgresDB).WithRebind()
+//	// executed as SELECT * FROM users WHERE id = $1
+//	rows, err := database.Query(qCtx, "SELECT * FROM users WHERE id = ?", 1)
+func (ctx QueryableContext) WithRebind() QueryableContext {
+	ctx.rebind = true
+	return ctx
+}
+
+// WithDialect returns a copy of the context, in which the helpers that
+// depend on the database type (i.e. Rebind, Upsert, the query builders)
+// use the given database type, instead of detecting it from the queryable.
+// This is useful when the type can not be detected, i.e. with a queryable
+// without a real driver in tests. An empty database type restores the detection.
+//
+// Example:
+//
+//	qCtx := database.Context(ctx, queryable).WithDialect(database.DATABASE_TYPE_POSTGRES).WithRebind()
+//	// executed as SELECT * FROM users WHERE id = $1
+//	rows, err := database.Query(qCtx, "SELECT * FROM users WHERE id = ?", 1)
+func (ctx QueryableContext) WithDialect(dbType string) QueryableContext {
+	ctx.dialect = dbType
+	return ctx
+}
+
+// databaseType returns the database type set with WithDialect,
+// or detects it from the queryable otherwise
+func (ctx QueryableContext) databaseType() string {
+	if ctx.dialect != "" {
+		return ctx.dialect
+	}
+
+	return DatabaseType(ctx.queryable)
+}
+
+// Value returns the value associated with the key in the embedded context.
+func (ctx QueryableContext) Value(key any) any {
+	if _, ok := key.(queryableContextKey); ok {
+		return ctx
+	}
+
+	if ctx.Context == nil {
+		return nil
+	}
+
+	return ctx.Context.Value(key)
+}
+
+// WithValue returns a copy of the context with the key and value added
+// to the embedded context, keeping the queryable and the options.
+//
+// Unlike context.WithValue, which returns a plain context.Context,
+// the result can still be passed to the database functions.
+//
+// Example:
+//
+//	qCtx := database.Context(ctx, db).WithValue(requestIDKey, "abc")
+//
+// Parameters:
+// - key: The key, should be of an unexported type, as with context.WithValue.
+// - val: The value.
+//
+// Returns:
+// - QueryableContext: A new context with the value.
+func (ctx QueryableContext) WithValue(key, val any) QueryableContext {
+	ctx.Context = context.WithValue(ctx.parent(), key, val)
+	return ctx
+}
+
+// WithTimeout returns a copy of the context with the timeout applied
+// to the embedded context, keeping the queryable and the options.
+//
+// Unlike context.WithTimeout, which returns a plain context.Context,
+// the result can still be passed to the database functions.
+//
+// Example:
+//
+//	qCtx, cancel := database.Context(ctx, db).WithTimeout(5 * time.Second)
+//	defer cancel()
+//	rows, err := database.SelectToMapAny(qCtx, "SELECT * FROM users")
+//
+// Parameters:
+// - timeout: The timeout, after which the context is canceled.
+//
+// Returns:
+// - QueryableContext: A new context with the timeout.
+// - context.CancelFunc: The function to release the resources of the timeout.
+func (ctx QueryableContext) WithTimeout(timeout time.Duration) (QueryableContext, context.CancelFunc) {
+	parent, cancel := context.WithTimeout(ctx.parent(), timeout)
+	ctx.Context = parent
+	return ctx, cancel
+}
+
+// WithDeadline returns a copy of the context with the deadline applied
+// to the embedded context, keeping the queryable and the options.
+//
+// Parameters:
+// - deadline: The time, after which the context is canceled.
+//
+// Returns:
+// - QueryableContext: A new context with the deadline.
+// - context.CancelFunc: The function to release the resources of the deadline.
+func (ctx QueryableContext) WithDeadline(deadline time.Time) (QueryableContext, context.CancelFunc) {
+	parent, cancel := context.WithDeadline(ctx.parent(), deadline)
+	ctx.Context = parent
+	return ctx, cancel
+}
+
+// parent returns the embedded context, or context.Background if it is nil
+func (ctx QueryableContext) parent() context.Context {
+	if ctx.Context == nil {
+		return context.Background()
+	}
+
+	return ctx.Context
+}
+
+// BeginTx begins a transaction on the underlying *sql.DB and returns
+// a new QueryableContext carrying the *sql.Tx.
+//
+// The caller is responsible for committing or rolling back the transaction,
+// which can be obtained via Queryable().
+//
+// Example:
+//
+//	txCtx, err := dbCtx.BeginTx(&sql.TxOptions{Isolation: sql.LevelSerializable})
+//
+// Parameters:
+// - opts: The transaction options, may be nil.
+//
+// Returns:
+// - QueryableContext: A new context carrying the transaction.
+// - error: An error if the context does not carry a *sql.DB, or the transaction could not be started.
+func (ctx QueryableContext) BeginTx(opts *sql.TxOptions) (QueryableContext, error) {
+	if ctx.queryable == nil {
+		return QueryableContext{}, ErrNoQueryable
+	}
+
+	if ctx.IsTx() {
+		return QueryableContext{}, errors.New("cannot begin transaction, context already carries a transaction")
+	}
+
+	if ctx.IsConn() {
+		return QueryableContext{}, errors.New("cannot begin transaction, context carries a connection, not a db")
+	}
+
+	db, ok := ctx.queryable.(*sql.DB)
+
+	if !ok {
+		return QueryableContext{}, errors.New("cannot begin transaction, context does not carry a db")
+	}
+
+	tx, err := db.BeginTx(ctx.parent(), opts)
+
+	if err != nil {
+		return QueryableContext{}, err
+	}
+
+	return ctx.WithQueryable(tx), nil
+}
+
+// Commit commits the transaction carried by the context,
+// i.e. the one started with BeginTx.
+//
+// Example:
+//
+//	txCtx, err := dbCtx.BeginTx(nil)
+//	if err != nil {
+//		return err
+//	}
+//	defer txCtx.Rollback()
+//
+//	if _, err := database.Execute(txCtx, "UPDATE users SET active = 1"); err != nil {
+//		return err
+//	}
+//
+//	return txCtx.Commit()
+//
+// Returns:
+// - error: An error if the context does not carry a transaction, or the commit failed.
+func (ctx QueryableContext) Commit() error {
+	tx, err := ctx.tx("commit")
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Rollback rolls back the transaction carried by the context. Rolling back
+// a transaction which is already committed or rolled back returns
+// sql.ErrTxDone, so it can be deferred after BeginTx.
+//
+// Returns:
+// - error: An error if the context does not carry a transaction, or the rollback failed.
+func (ctx QueryableContext) Rollback() error {
+	tx, err := ctx.tx("rollback")
+	if err != nil {
+		return err
+	}
+
+	return tx.Rollback()
+}
+
+// tx returns the transaction carried by the context, for the operation
+func (ctx QueryableContext) tx(operation string) (*sql.Tx, error) {
+	if ctx.queryable == nil {
+		return nil, ErrNoQueryable
+	}
+
+	tx, ok := ctx.queryable.(*sql.Tx)
+
+	if !ok {
+		return nil, errors.New("cannot " + operation + ", context does not carry a transaction")
+	}
+
+	// The savepoints of a managed transaction are released by Transaction
+	if ctx.txDepth > 0 {
+		return nil, errors.New("cannot " + operation + ", context carries a savepoint of a managed transaction")
+	}
+
+	return tx, nil
+}
+
+// WithQueryable returns a copy of the context carrying the given queryable
+// (DB, Tx or Conn) instead of its current one, i.e. to route to a shard.
+//
+// The embedded context (deadlines, cancellation and values) is kept untouched,
+// as are the options of the context, i.e. placeholder rebinding.
+//
+// Example:
+//
+//	shardCtx := qCtx.WithQueryable(shards[tenantID%len(shards)])
+//	rows, err := database.Query(shardCtx, "SELECT * FROM orders WHERE tenant_id = ?", tenantID)
+func (ctx QueryableContext) WithQueryable(queryable QueryableInterface) QueryableContext {
+	// A NullQueryable is the same as no queryable
+	if _, ok := queryable.(NullQueryable); ok {
+		queryable = nil
+	}
+
+	ctx.queryable = queryable
+	ctx.txDepth = 0
+	return ctx
+}
@@ -0,0 +1,460 @@
+package database_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	database "github.com/dracory/database"
+)
+
+func TestQueryableContextBeginTx(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	err = createUserTableAndInserTesttData(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Test nil querier error
+	_, err = database.Context(context.Background(), nil).BeginTx(nil)
+	if err == nil {
+		t.Error("Expected error for nil querier")
+	}
+
+	// Test successful begin
+	txCtx, err := database.Context(context.Background(), db).BeginTx(nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !txCtx.IsTx() {
+		t.Fatal("Expected transaction context")
+	}
+
+	tx := txCtx.Queryable().(*sql.Tx)
+
+	// Test begin on a transaction context
+	_, err = txCtx.BeginTx(nil)
+	if err == nil {
+		t.Error("Expected error when context already carries a transaction")
+	}
+
+	_, err = database.Execute(txCtx, "DELETE FROM users")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if err := tx.Rollback(); err != nil {
+		t.Fatalf("Failed to rollback: %v", err)
+	}
+
+	var count int
+	err = db.QueryRow("SELECT COUNT(*) FROM users").Scan(&count)
+	if err != nil {
+		t.Fatalf("Failed to count rows: %v", err)
+	}
+
+	if count != 3 {
+		t.Errorf("Expected 3 rows after rollback, got %d", count)
+	}
+
+	// Test begin on a zero value context, without a parent context
+	txCtx, err = database.QueryableContext{}.WithQueryable(db).BeginTx(nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if err := txCtx.Rollback(); err != nil {
+		t.Fatalf("Failed to rollback: %v", err)
+	}
+
+	// Test begin on a connection context
+	conn, err := db.Conn(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	_, err = database.Context(context.Background(), conn).BeginTx(nil)
+	if err == nil {
+		t.Error("Expected error when context carries a connection")
+	}
+}
+
+func TestQueryableContextWithTimeout(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	qCtx, cancel := database.Context(context.Background(), db).WithRebind().WithTimeout(time.Minute)
+	defer cancel()
+
+	if qCtx.Queryable() != db {
+		t.Error("Expected the queryable to be kept")
+	}
+
+	deadline, ok := qCtx.Deadline()
+	if !ok || time.Until(deadline) > time.Minute {
+		t.Errorf("Expected a deadline within a minute, got %v, %v", deadline, ok)
+	}
+
+	if _, err := database.Execute(qCtx, "SELECT 1"); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	// Test the context is canceled when the timeout expires
+	expiredCtx, cancelExpired := qCtx.WithTimeout(time.Nanosecond)
+	defer cancelExpired()
+
+	<-expiredCtx.Done()
+
+	if _, err := database.Execute(expiredCtx, "SELECT 1"); err == nil {
+		t.Error("Expected error for expired context")
+	}
+}
+
+func TestQueryableContextWithDeadline(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	expected := time.Now().Add(time.Hour)
+
+	qCtx, cancel := database.Context(context.Background(), db).WithDeadline(expected)
+	defer cancel()
+
+	if qCtx.Queryable() != db {
+		t.Error("Expected the queryable to be kept")
+	}
+
+	deadline, ok := qCtx.Deadline()
+	if !ok || !deadline.Equal(expected) {
+		t.Errorf("Expected deadline %v, got %v", expected, deadline)
+	}
+
+	cancel()
+
+	if qCtx.Err() != context.Canceled {
+		t.Errorf("Expected context to be canceled, got %v", qCtx.Err())
+	}
+}
+
+type testContextKey struct{}
+
+func TestQueryableContextWithValue(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	qCtx := database.Context(context.Background(), db).WithValue(testContextKey{}, "value")
+
+	if qCtx.Queryable() != db {
+		t.Error("Expected the queryable to be kept")
+	}
+
+	if qCtx.Value(testContextKey{}) != "value" {
+		t.Errorf("Expected value 'value', got %v", qCtx.Value(testContextKey{}))
+	}
+
+	if _, err := database.Execute(qCtx, "SELECT 1"); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestContextOrRecoversDerivedContext(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	derived := context.WithValue(database.Context(context.Background(), db).WithRebind(), testContextKey{}, "value")
+
+	if database.IsQueryableContext(derived) {
+		t.Error("Expected a context derived with the standard library not to be a QueryableContext")
+	}
+
+	if q, ok := database.From(derived); !ok || q != db {
+		t.Errorf("Expected From to find the queryable, got %v, %v", q, ok)
+	}
+
+	qCtx := database.ContextOr(derived, nil)
+
+	if qCtx.Queryable() != db {
+		t.Error("Expected ContextOr to recover the queryable")
+	}
+
+	if qCtx.Value(testContextKey{}) != "value" {
+		t.Errorf("Expected the derived value to be kept, got %v", qCtx.Value(testContextKey{}))
+	}
+
+	if _, err := database.Execute(qCtx, "SELECT 1"); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	// Test a plain context is not affected
+	plain := database.ContextOr(context.Background(), db)
+
+	if plain.Queryable() != db {
+		t.Error("Expected the given queryable for a plain context")
+	}
+}
+
+func TestQueryableContextWithQueryable(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	shard, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer shard.Close()
+
+	deadline := time.Now().Add(time.Minute)
+	parent, cancel := context.WithDeadline(context.WithValue(context.Background(), testContextKey{}, "value"), deadline)
+	defer cancel()
+
+	qCtx := database.Context(parent, db)
+	shardCtx := qCtx.WithQueryable(shard)
+
+	if shardCtx.Queryable() != shard {
+		t.Error("Expected the queryable to be replaced")
+	}
+
+	if qCtx.Queryable() != db {
+		t.Error("Expected the original context to be unchanged")
+	}
+
+	if shardCtx.Context != parent {
+		t.Error("Expected the embedded context to be kept")
+	}
+
+	if d, ok := shardCtx.Deadline(); !ok || !d.Equal(deadline) {
+		t.Errorf("Expected the deadline to be kept, got %v", d)
+	}
+
+	if shardCtx.Value(testContextKey{}) != "value" {
+		t.Errorf("Expected the value to be kept, got %v", shardCtx.Value(testContextKey{}))
+	}
+}
+
+func TestNestedQueryableContexts(t *testing.T) {
+	inner, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer inner.Close()
+
+	outer, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer outer.Close()
+
+	other, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer other.Close()
+
+	type innerKey struct{}
+	type outerKey struct{}
+
+	// A QueryableContext wrapped by another, with values in between
+	nested := context.WithValue(
+		database.Context(
+			context.WithValue(database.Context(context.Background(), inner), innerKey{}, "inner"),
+			outer,
+		),
+		outerKey{},
+		"outer",
+	)
+
+	if database.IsQueryableContext(nested) {
+		t.Error("Expected a context derived with the standard library not to be a QueryableContext")
+	}
+
+	// The closest QueryableContext takes precedence
+	if q, ok := database.From(nested); !ok || q != outer {
+		t.Errorf("Expected From to find the outer queryable, got %v, %v", q, ok)
+	}
+
+	qCtx := database.ContextOr(nested, other)
+
+	if qCtx.Queryable() != outer {
+		t.Error("Expected ContextOr to recover the outer queryable")
+	}
+
+	if qCtx.Value(innerKey{}) != "inner" || qCtx.Value(outerKey{}) != "outer" {
+		t.Errorf("Expected the values of all the levels, got %v and %v", qCtx.Value(innerKey{}), qCtx.Value(outerKey{}))
+	}
+
+	// A QueryableContext without a queryable still shadows the inner one
+	shadowing := database.Context(database.Context(context.Background(), inner), nil)
+
+	if q, ok := database.From(shadowing); ok {
+		t.Errorf("Expected no queryable, got %v", q)
+	}
+
+	if database.ContextOr(shadowing, other).IsDB() {
+		t.Error("Expected ContextOr to return the context without a queryable as it is")
+	}
+
+	// A QueryableContext stored as a value under another key is not found
+	stored := context.WithValue(context.Background(), innerKey{}, database.Context(context.Background(), inner))
+
+	if q, ok := database.From(stored); ok {
+		t.Errorf("Expected no queryable for a context stored as a value, got %v", q)
+	}
+
+	if database.ContextOr(stored, other).Queryable() != other {
+		t.Error("Expected ContextOr to use the given queryable")
+	}
+}
+
+func TestQueryableContextCommitAndRollback(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	if err := createUserTableAndInserTesttData(db); err != nil {
+		t.Fatal(err)
+	}
+
+	dbCtx := database.Context(context.Background(), db)
+
+	if err := dbCtx.Commit(); err == nil {
+		t.Error("Expected an error committing without a transaction")
+	}
+
+	if err := dbCtx.Rollback(); err == nil {
+		t.Error("Expected an error rolling back without a transaction")
+	}
+
+	if err := database.Context(context.Background(), nil).Commit(); !errors.Is(err, database.ErrNoQueryable) {
+		t.Errorf("Expected ErrNoQueryable, got %v", err)
+	}
+
+	// Rolled back
+	txCtx, err := dbCtx.BeginTx(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := database.Execute(txCtx, "DELETE FROM users WHERE id = 1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := txCtx.Rollback(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if err := txCtx.Rollback(); !errors.Is(err, sql.ErrTxDone) {
+		t.Errorf("Expected sql.ErrTxDone, got %v", err)
+	}
+
+	// Committed
+	txCtx, err = dbCtx.BeginTx(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := database.Execute(txCtx, "DELETE FROM users WHERE id = 2"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := txCtx.Commit(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	count, err := database.Count(dbCtx, "SELECT COUNT(*) FROM users")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if count != 2 {
+		t.Errorf("Expected only the committed delete, got %d users", count)
+	}
+
+	// The savepoints of a managed transaction cannot be committed
+	err = database.Transaction(dbCtx, db, func(txCtx database.QueryableContext) error {
+		return database.Transaction(txCtx, db, func(spCtx database.QueryableContext) error {
+			if err := spCtx.Commit(); err == nil {
+				t.Error("Expected an error committing a savepoint")
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestQueryableContextWithDialect(t *testing.T) {
+	// The mock has no database type, as there is no driver to detect it from
+	mock := database.NewMockQueryable()
+	defer mock.Close()
+
+	mock.ExpectQuery("SELECT name FROM users WHERE id = $1").
+		WithArgs(1).
+		WillReturnRows([]string{"name"}, []any{"Alice"})
+	mock.ExpectQuery(`INSERT INTO "users" ("name") VALUES ($1) RETURNING "id"`).
+		WithArgs("Bob").
+		WillReturnRows([]string{"id"}, []any{7})
+
+	ctx := database.Context(context.Background(), mock).WithDialect(database.DATABASE_TYPE_POSTGRES).WithRebind()
+
+	rows, err := database.SelectToMapString(ctx, "SELECT name FROM users WHERE id = ?", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(rows) != 1 || rows[0]["name"] != "Alice" {
+		t.Errorf("Unexpected rows: %v", rows)
+	}
+
+	id, err := database.Insert(ctx, "users", map[string]any{"name": "Bob"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if id != 7 {
+		t.Errorf("Expected id 7, got %d", id)
+	}
+
+	mock.AssertExpectations(t)
+
+	// An empty dialect restores the detection from the queryable
+	mock = database.NewMockQueryable().SetDatabaseType(database.DATABASE_TYPE_MYSQL)
+	defer mock.Close()
+
+	mock.ExpectQuery("SELECT name FROM users WHERE id = ?").
+		WithArgs(1).
+		WillReturnRows([]string{"name"}, []any{"Alice"})
+
+	ctx = database.Context(context.Background(), mock).WithDialect(database.DATABASE_TYPE_POSTGRES).WithDialect("").WithRebind()
+
+	if _, err := database.SelectToMapString(ctx, "SELECT name FROM users WHERE id = ?", 1); err != nil {
+		t.Fatal(err)
+	}
+
+	mock.AssertExpectations(t)
+}
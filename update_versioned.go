@@ -0,0 +1,133 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrStaleVersion is returned by UpdateVersioned when no row has the
+// expected version, as it was modified (or deleted) by someone else.
+var ErrStaleVersion = errors.New("stale version")
+
+// UpdateVersioned updates a row with optimistic locking: the row is only
+// updated if its version column still has the expected version, and the
+// version is incremented along with the other columns, using
+//
+//	UPDATE table SET col = ?, ..., version = version + 1 WHERE pk = ? AND version = ?
+//
+// The row must contain the value of the primary key column, which is not
+// updated. The version column must not be set in the row, as it is
+// incremented by the statement.
+//
+// If no row was updated, an error wrapping ErrStaleVersion is returned,
+// i.e. to reload the row and retry, or report a conflict to the user.
+//
+// Example usage:
+//
+//	err := UpdateVersioned(ctx, "users", map[string]any{"id": 1, "name": "John"}, "id", "version", 3)
+//	if errors.Is(err, ErrStaleVersion) {
+//		return errors.New("user was modified by someone else")
+//	}
+//
+// Parameters:
+// - ctx (QueryableContext): The context to use for the query execution.
+// - table (string): The name of the table.
+// - row (map[string]any): The primary key and the values to update, by column name.
+// - pk (string): The name of the primary key column.
+// - versionCol (string): The name of the version column.
+// - expectedVersion (int64): The version the row is expected to have.
+//
+// Returns:
+// - error: An error if the row is invalid, the statement failed, or the version is stale.
+func UpdateVersioned(ctx QueryableContext, table string, row map[string]any, pk string, versionCol string, expectedVersion int64) error {
+	if ctx.queryable == nil {
+		return ErrNoQueryable
+	}
+
+	sqlStr, args, err := updateVersionedSQL(ctx.databaseType(), table, row, pk, versionCol, expectedVersion)
+	if err != nil {
+		return err
+	}
+
+	result, err := ctx.execContext("UpdateVersioned", sqlStr, args...)
+	if err != nil {
+		return err
+	}
+
+	// In dry run mode no rows are affected, so there is nothing to check
+	if ctx.dryRun {
+		return nil
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return fmt.Errorf("%w: %s %s = %v does not have %s %d", ErrStaleVersion, table, pk, row[pk], versionCol, expectedVersion)
+	}
+
+	return nil
+}
+
+// updateVersionedSQL builds the optimistic locking update statement
+// for the database type
+func updateVersionedSQL(dbType string, table string, row map[string]any, pk string, versionCol string, expectedVersion int64) (string, []any, error) {
+	if pk == "" || versionCol == "" {
+		return "", nil, errors.New("primary key and version columns are required")
+	}
+
+	pkValue, ok := row[pk]
+	if !ok {
+		return "", nil, errors.New("row must contain the primary key column " + pk)
+	}
+
+	if _, ok := row[versionCol]; ok {
+		return "", nil, errors.New("row must not contain the version column " + versionCol + ", it is incremented")
+	}
+
+	values := make(map[string]any, len(row))
+	for column, value := range row {
+		if column != pk {
+			values[column] = value
+		}
+	}
+
+	columns, args := sortedRowColumns(values)
+
+	quotedTable, err := quoteIdentifier(dbType, table)
+	if err != nil {
+		return "", nil, err
+	}
+
+	quotedColumns, err := quoteIdentifiers(dbType, columns)
+	if err != nil {
+		return "", nil, err
+	}
+
+	quotedPK, err := quoteIdentifier(dbType, pk)
+	if err != nil {
+		return "", nil, err
+	}
+
+	quotedVersion, err := quoteIdentifier(dbType, versionCol)
+	if err != nil {
+		return "", nil, err
+	}
+
+	assignments := make([]string, 0, len(quotedColumns)+1)
+	for _, quotedColumn := range quotedColumns {
+		assignments = append(assignments, quotedColumn+" = ?")
+	}
+
+	assignments = append(assignments, quotedVersion+" = "+quotedVersion+" + 1")
+
+	sqlStr := "UPDATE " + quotedTable + " SET " + strings.Join(assignments, ", ") +
+		" WHERE " + quotedPK + " = ? AND " + quotedVersion + " = ?"
+
+	args = append(args, pkValue, expectedVersion)
+
+	return Rebind(dbType, sqlStr), args, nil
+}
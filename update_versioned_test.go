@@ -0,0 +1,74 @@
+package database_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	database "github.com/dracory/database"
+)
+
+func TestUpdateVersioned(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	ctx := database.Context(context.Background(), db)
+
+	if _, err := database.Execute(ctx, "CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT, version INTEGER NOT NULL DEFAULT 1)"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := database.Execute(ctx, "INSERT INTO users (name) VALUES ('Alice')"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := database.UpdateVersioned(ctx, "users", map[string]any{"id": 1, "name": "Alicia"}, "id", "version", 1); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	row, err := database.SelectOneMap(ctx, "SELECT name, version FROM users WHERE id = 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if row["name"] != "Alicia" || row["version"] != int64(2) {
+		t.Errorf("Expected the name updated and the version incremented, got %v", row)
+	}
+
+	// The version is now 2, so updating version 1 again is stale
+	err = database.UpdateVersioned(ctx, "users", map[string]any{"id": 1, "name": "Ali"}, "id", "version", 1)
+	if !errors.Is(err, database.ErrStaleVersion) {
+		t.Errorf("Expected ErrStaleVersion, got %v", err)
+	}
+
+	if err := database.UpdateVersioned(ctx, "users", map[string]any{"name": "Ali"}, "id", "version", 2); err == nil {
+		t.Error("Expected an error without the primary key")
+	}
+
+	if err := database.UpdateVersioned(ctx, "users", map[string]any{"id": 1, "version": 5}, "id", "version", 2); err == nil {
+		t.Error("Expected an error with the version column in the row")
+	}
+}
+
+func TestUpdateVersionedPostgresSQL(t *testing.T) {
+	mock := database.NewMockQueryable().SetDatabaseType(database.DATABASE_TYPE_POSTGRES)
+	defer mock.Close()
+
+	mock.ExpectExec(`UPDATE "users" SET "email" = $1, "name" = $2, "version" = "version" + 1 WHERE "id" = $3 AND "version" = $4`).
+		WithArgs("john@example.com", "John", 7, int64(3)).
+		WillReturnResult(0, 1)
+
+	err := database.UpdateVersioned(database.Context(context.Background(), mock), "users", map[string]any{
+		"id":    7,
+		"name":  "John",
+		"email": "john@example.com",
+	}, "id", "version", 3)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	mock.AssertExpectations(t)
+}
@@ -0,0 +1,152 @@
+package database
+
+import (
+	"database/sql"
+	"errors"
+	"slices"
+	"strings"
+)
+
+// Upsert inserts the row into the table, or updates the existing row if
+// it conflicts on the given columns (i.e. the primary key or a unique index).
+// On conflict, all the columns of the row except the conflict columns are updated.
+//
+// The statement is built for the dialect returned by DatabaseType:
+//   - Postgres, SQLite: INSERT ... ON CONFLICT (...) DO UPDATE SET ...
+//   - MySQL: INSERT ... ON DUPLICATE KEY UPDATE ...
+//
+// For MySQL the conflict is detected on any unique index of the table,
+// as it does not support specifying the conflict columns.
+//
+// Example usage:
+//
+//	result, err := Upsert(ctx, "users", []string{"email"}, map[string]any{
+//		"email": "john@example.com",
+//		"name":  "John Doe",
+//	})
+//
+// Parameters:
+// - ctx (QueryableContext): The context to use for the query execution.
+// - table (string): The name of the table.
+// - conflictColumns ([]string): The columns identifying the existing row.
+// - row (map[string]any): The values to insert, by column name.
+//
+// Returns:
+// - sql.Result: The result of the statement.
+// - error: An error if the row is invalid, the database type is not supported, or the statement failed.
+func Upsert(ctx QueryableContext, table string, conflictColumns []string, row map[string]any) (sql.Result, error) {
+	if ctx.queryable == nil {
+		return nil, ErrNoQueryable
+	}
+
+	sqlStr, args, err := upsertSQL(ctx.databaseType(), table, conflictColumns, row)
+	if err != nil {
+		return nil, err
+	}
+
+	return ctx.execContext("Upsert", sqlStr, args...)
+}
+
+// upsertSQL builds the upsert statement for the database type
+func upsertSQL(dbType string, table string, conflictColumns []string, row map[string]any) (string, []any, error) {
+	if table == "" {
+		return "", nil, errors.New("table name is required")
+	}
+
+	if len(conflictColumns) == 0 {
+		return "", nil, errors.New("at least one conflict column is required")
+	}
+
+	if len(row) == 0 {
+		return "", nil, errors.New("row must have at least one column")
+	}
+
+	for _, column := range conflictColumns {
+		if _, ok := row[column]; !ok {
+			return "", nil, errors.New("conflict column is missing from the row: " + column)
+		}
+	}
+
+	if dbType != DATABASE_TYPE_POSTGRES && dbType != DATABASE_TYPE_SQLITE && dbType != DATABASE_TYPE_MYSQL {
+		return "", nil, errors.New("upsert is not supported for database type: " + dbType)
+	}
+
+	columns, args := sortedRowColumns(row)
+
+	quotedTable, err := quoteIdentifier(dbType, table)
+	if err != nil {
+		return "", nil, err
+	}
+
+	quotedColumns, err := quoteIdentifiers(dbType, columns)
+	if err != nil {
+		return "", nil, err
+	}
+
+	quotedConflictColumns, err := quoteIdentifiers(dbType, conflictColumns)
+	if err != nil {
+		return "", nil, err
+	}
+
+	updateColumns := []string{}
+	for i, column := range columns {
+		if !slices.Contains(conflictColumns, column) {
+			updateColumns = append(updateColumns, quotedColumns[i])
+		}
+	}
+
+	var b strings.Builder
+	b.WriteString("INSERT INTO " + quotedTable + " (" + strings.Join(quotedColumns, ", ") + ")")
+	b.WriteString(" VALUES (" + strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", ") + ")")
+
+	if dbType == DATABASE_TYPE_MYSQL {
+		// Without columns to update, the conflict column is set to itself,
+		// so the statement does not fail
+		sets := []string{quotedConflictColumns[0] + " = " + quotedConflictColumns[0]}
+
+		if len(updateColumns) > 0 {
+			sets = make([]string, len(updateColumns))
+			for i, column := range updateColumns {
+				sets[i] = column + " = VALUES(" + column + ")"
+			}
+		}
+
+		b.WriteString(" ON DUPLICATE KEY UPDATE " + strings.Join(sets, ", "))
+
+		return b.String(), args, nil
+	}
+
+	b.WriteString(" ON CONFLICT (" + strings.Join(quotedConflictColumns, ", ") + ")")
+
+	if len(updateColumns) == 0 {
+		b.WriteString(" DO NOTHING")
+		return Rebind(dbType, b.String()), args, nil
+	}
+
+	sets := make([]string, len(updateColumns))
+	for i, column := range updateColumns {
+		sets[i] = column + " = EXCLUDED." + column
+	}
+
+	b.WriteString(" DO UPDATE SET " + strings.Join(sets, ", "))
+
+	return Rebind(dbType, b.String()), args, nil
+}
+
+// sortedRowColumns returns the columns of the row sorted by name, and their
+// values in the same order, so that the same row always results in the
+// same statement
+func sortedRowColumns(row map[string]any) ([]string, []any) {
+	columns := make([]string, 0, len(row))
+	for column := range row {
+		columns = append(columns, column)
+	}
+	slices.Sort(columns)
+
+	args := make([]any, len(columns))
+	for i, column := range columns {
+		args[i] = row[column]
+	}
+
+	return columns, args
+}
@@ -0,0 +1,74 @@
+package database
+
+import "testing"
+
+func TestUpsertSQL(t *testing.T) {
+	row := map[string]any{"email": "john@example.com", "name": "John", "age": 30}
+
+	tests := []struct {
+		name            string
+		dbType          string
+		conflictColumns []string
+		row             map[string]any
+		expected        string
+	}{
+		{
+			name:            "postgres",
+			dbType:          DATABASE_TYPE_POSTGRES,
+			conflictColumns: []string{"email"},
+			row:             row,
+			expected:        `INSERT INTO "users" ("age", "email", "name") VALUES ($1, $2, $3) ON CONFLICT ("email") DO UPDATE SET "age" = EXCLUDED."age", "name" = EXCLUDED."name"`,
+		},
+		{
+			name:            "sqlite",
+			dbType:          DATABASE_TYPE_SQLITE,
+			conflictColumns: []string{"email"},
+			row:             row,
+			expected:        `INSERT INTO "users" ("age", "email", "name") VALUES (?, ?, ?) ON CONFLICT ("email") DO UPDATE SET "age" = EXCLUDED."age", "name" = EXCLUDED."name"`,
+		},
+		{
+			name:            "mysql",
+			dbType:          DATABASE_TYPE_MYSQL,
+			conflictColumns: []string{"email"},
+			row:             row,
+			expected:        "INSERT INTO `users` (`age`, `email`, `name`) VALUES (?, ?, ?) ON DUPLICATE KEY UPDATE `age` = VALUES(`age`), `name` = VALUES(`name`)",
+		},
+		{
+			name:            "postgres only conflict columns",
+			dbType:          DATABASE_TYPE_POSTGRES,
+			conflictColumns: []string{"email"},
+			row:             map[string]any{"email": "john@example.com"},
+			expected:        `INSERT INTO "users" ("email") VALUES ($1) ON CONFLICT ("email") DO NOTHING`,
+		},
+		{
+			name:            "mysql only conflict columns",
+			dbType:          DATABASE_TYPE_MYSQL,
+			conflictColumns: []string{"email"},
+			row:             map[string]any{"email": "john@example.com"},
+			expected:        "INSERT INTO `users` (`email`) VALUES (?) ON DUPLICATE KEY UPDATE `email` = `email`",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqlStr, args, err := upsertSQL(tt.dbType, "users", tt.conflictColumns, tt.row)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if sqlStr != tt.expected {
+				t.Errorf("Expected:\n%s\ngot:\n%s", tt.expected, sqlStr)
+			}
+			if len(args) != len(tt.row) {
+				t.Errorf("Expected %d args, got %d", len(tt.row), len(args))
+			}
+		})
+	}
+
+	if _, _, err := upsertSQL(DATABASE_TYPE_MSSQL, "users", []string{"email"}, row); err == nil {
+		t.Error("Expected error for unsupported database type")
+	}
+
+	if _, _, err := upsertSQL(DATABASE_TYPE_SQLITE, "users", []string{"id"}, row); err == nil {
+		t.Error("Expected error for conflict column missing from the row")
+	}
+}
@@ -0,0 +1,57 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	database "github.com/dracory/database"
+)
+
+func TestUpsert(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	err = createUserTableAndInserTesttData(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Test nil querier error
+	_, err = database.Upsert(database.Context(context.Background(), nil), "users", []string{"id"}, map[string]any{"id": 1})
+	if err == nil || err.Error() != "querier (db/tx/conn) is nil" {
+		t.Errorf("Expected nil querier error, got %v", err)
+	}
+
+	ctx := database.Context(context.Background(), db)
+
+	// Test the existing row is updated
+	_, err = database.Upsert(ctx, "users", []string{"id"}, map[string]any{"id": 1, "name": "Alicia", "email": "alicia@example.com"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	name, _, err := database.SelectOne[string](ctx, "SELECT name FROM users WHERE id = ?", 1)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if name != "Alicia" {
+		t.Errorf("Expected name 'Alicia', got %q", name)
+	}
+
+	// Test a new row is inserted
+	_, err = database.Upsert(ctx, "users", []string{"id"}, map[string]any{"id": 4, "name": "Dave", "email": "dave@example.com"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	count, err := database.Count(ctx, "SELECT COUNT(*) FROM users")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if count != 4 {
+		t.Errorf("Expected 4 users, got %d", count)
+	}
+}
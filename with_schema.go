@@ -0,0 +1,131 @@
+package database
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+)
+
+// WithSchema calls fn with a context carrying a pinned connection (see
+// AcquireConn), on which the default schema is switched, i.e. to the schema
+// of a tenant, so that the unqualified table names resolve in it.
+//
+// The schema is switched as follows, based on DatabaseType:
+//   - Postgres: SET search_path TO schema, reset with RESET search_path
+//   - MySQL: USE schema, reset with USE of the previous database
+//
+// The schema is reset before the connection is released, so the switch does
+// not leak to the other connections of the pool. If it cannot be reset, the
+// connection is closed instead of being returned to the pool.
+//
+// Example usage:
+//
+//	err := WithSchema(ctx, "tenant_1", func(ctx QueryableContext) error {
+//		users, err := SelectToMapAny(ctx, "SELECT * FROM users")
+//		...
+//	})
+//
+// Parameters:
+// - ctx (QueryableContext): The context carrying the database.
+// - schema (string): The name of the schema (database for MySQL).
+// - fn (func(QueryableContext) error): The function to call with the connection.
+//
+// Returns:
+// - error: The error of fn, or an error if the database type is not supported,
+// the context does not carry a *sql.DB, or the schema could not be switched.
+func WithSchema(ctx QueryableContext, schema string, fn func(ctx QueryableContext) error) error {
+	if ctx.queryable == nil {
+		return ErrNoQueryable
+	}
+
+	if schema == "" {
+		return errors.New("schema is required")
+	}
+
+	dbType := ctx.databaseType()
+
+	quotedSchema, err := quoteIdentifier(dbType, schema)
+	if err != nil {
+		return err
+	}
+
+	var switchSQL string
+
+	switch dbType {
+	case DATABASE_TYPE_POSTGRES, DATABASE_TYPE_PGX:
+		switchSQL = "SET search_path TO " + quotedSchema
+	case DATABASE_TYPE_MYSQL:
+		switchSQL = "USE " + quotedSchema
+	default:
+		return errors.New("switching schema is not supported for database type: " + dbType)
+	}
+
+	connCtx, release, err := AcquireConn(ctx)
+	if err != nil {
+		return err
+	}
+
+	resetSQL, err := schemaResetSQL(connCtx, dbType)
+	if err != nil {
+		return errors.Join(err, release())
+	}
+
+	// The schema is switched even in dry run mode, as the reads of fn are executed
+	sessionCtx := connCtx.WithDryRun(false)
+
+	if _, err := sessionCtx.execContext("WithSchema", switchSQL); err != nil {
+		return errors.Join(err, releaseSchemaConn(sessionCtx, resetSQL, release))
+	}
+
+	err = fn(connCtx)
+
+	return errors.Join(err, releaseSchemaConn(sessionCtx, resetSQL, release))
+}
+
+// schemaResetSQL returns the statement resetting the schema of the connection
+// to its current one, or an empty string if it cannot be reset
+func schemaResetSQL(connCtx QueryableContext, dbType string) (string, error) {
+	if dbType != DATABASE_TYPE_MYSQL {
+		return "RESET search_path", nil
+	}
+
+	var current sql.NullString
+
+	row, run := connCtx.queryRowContext("WithSchema", "SELECT DATABASE()")
+	if err := row.Scan(&current); err != nil {
+		run.end(-1, err)
+		return "", err
+	}
+	run.end(1, nil)
+
+	// A connection without a database cannot go back to having none
+	if !current.Valid {
+		return "", nil
+	}
+
+	quotedCurrent, err := quoteIdentifier(dbType, current.String)
+	if err != nil {
+		return "", err
+	}
+
+	return "USE " + quotedCurrent, nil
+}
+
+// releaseSchemaConn resets the schema of the connection and releases it,
+// or closes it if the schema cannot be reset
+func releaseSchemaConn(connCtx QueryableContext, resetSQL string, release func() error) error {
+	if resetSQL != "" {
+		if _, err := connCtx.execContext("WithSchema", resetSQL); err == nil {
+			return release()
+		}
+	}
+
+	conn := connCtx.queryable.(*sql.Conn)
+
+	// Returning driver.ErrBadConn closes the connection instead of returning it to the pool
+	_ = conn.Raw(func(any) error {
+		return driver.ErrBadConn
+	})
+
+	return nil
+}
@@ -0,0 +1,119 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"strings"
+	"testing"
+
+	_ "modernc.org/sqlite"
+)
+
+func TestReleaseSchemaConn(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	ctx := NewQueryableContext(context.Background(), db)
+
+	// A reset statement returns the connection to the pool
+	connCtx, release, err := AcquireConn(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := releaseSchemaConn(connCtx, "SELECT 1", release); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if stats := db.Stats(); stats.Idle != 1 || stats.InUse != 0 {
+		t.Errorf("Expected the connection to be idle, got %+v", stats)
+	}
+
+	// A failed or missing reset closes the connection
+	for _, resetSQL := range []string{"INVALID SQL", ""} {
+		connCtx, release, err = AcquireConn(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := releaseSchemaConn(connCtx, resetSQL, release); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if stats := db.Stats(); stats.OpenConnections != 0 {
+			t.Errorf("%q: expected the connection to be closed, got %+v", resetSQL, stats)
+		}
+	}
+}
+
+// recordingConnector is a driver, which records the statements sent to it,
+// and returns no rows for the queries
+type recordingConnector struct {
+	statements *[]string
+}
+
+func (c recordingConnector) Connect(context.Context) (driver.Conn, error) {
+	return recordingConn(c), nil
+}
+
+func (c recordingConnector) Driver() driver.Driver {
+	return nil
+}
+
+type recordingConn recordingConnector
+
+func (c recordingConn) Prepare(string) (driver.Stmt, error) {
+	return nil, driver.ErrSkip
+}
+
+func (c recordingConn) Close() error {
+	return nil
+}
+
+func (c recordingConn) Begin() (driver.Tx, error) {
+	return nil, driver.ErrSkip
+}
+
+func (c recordingConn) ExecContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	*c.statements = append(*c.statements, query)
+	return driver.RowsAffected(0), nil
+}
+
+func (c recordingConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	*c.statements = append(*c.statements, query)
+	return &mockRows{columns: []string{"name"}}, nil
+}
+
+func TestWithSchemaDryRun(t *testing.T) {
+	statements := []string{}
+
+	db := sql.OpenDB(recordingConnector{statements: &statements})
+	defer db.Close()
+
+	ctx := NewQueryableContext(context.Background(), db).WithDialect(DATABASE_TYPE_POSTGRES).WithDryRun(true)
+
+	err := WithSchema(ctx, "tenant_1", func(ctx QueryableContext) error {
+		if _, err := SelectToMapString(ctx, "SELECT name FROM users"); err != nil {
+			return err
+		}
+
+		// The writes of fn are still skipped
+		_, err := Execute(ctx, "DELETE FROM users")
+		return err
+	})
+
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// The read runs against the tenant schema
+	expected := []string{`SET search_path TO "tenant_1"`, "SELECT name FROM users", "RESET search_path"}
+
+	if strings.Join(statements, "; ") != strings.Join(expected, "; ") {
+		t.Errorf("Expected statements %q, got %q", expected, statements)
+	}
+}
@@ -0,0 +1,44 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	database "github.com/dracory/database"
+)
+
+func TestWithSchemaErrors(t *testing.T) {
+	db, err := initSqliteDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	ctx := database.Context(context.Background(), db)
+
+	called := false
+	fn := func(ctx database.QueryableContext) error {
+		called = true
+		return nil
+	}
+
+	if err := database.WithSchema(ctx, "tenant_1", fn); err == nil {
+		t.Error("Expected an error for an unsupported database type")
+	}
+
+	if err := database.WithSchema(ctx, "", fn); err == nil {
+		t.Error("Expected an error for an empty schema")
+	}
+
+	if err := database.WithSchema(database.Context(context.Background(), nil), "tenant_1", fn); err == nil {
+		t.Error("Expected an error without a queryable")
+	}
+
+	if called {
+		t.Error("Expected fn not to be called")
+	}
+
+	if db.Stats().InUse != 0 {
+		t.Errorf("Expected no connection in use, got %d", db.Stats().InUse)
+	}
+}